@@ -9,12 +9,14 @@ import (
 	"time"
 
 	_ "github.com/lib/pq"
+	"github.com/redis/go-redis/v9"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 
 	"privacy-social-backend/internal/api"
 	"privacy-social-backend/internal/config"
 	"privacy-social-backend/internal/repository"
+	"privacy-social-backend/internal/service/safetyalert"
 	"privacy-social-backend/internal/worker"
 )
 
@@ -52,6 +54,19 @@ func main() {
 	cleanupWorker := worker.NewCleanupWorker(store)
 	cleanupWorker.Start()
 	// cleanupWorker.StartCrossingDetector() // Disabled: Switched to Redis-based Realtime Detection
+	trustWorker := worker.NewTrustWorker(store)
+	trustWorker.Start()
+
+	redisOpt, err := redis.ParseURL(config.RedisAddress)
+	if err != nil {
+		redisOpt = &redis.Options{Addr: config.RedisAddress}
+	}
+	redisClient := redis.NewClient(redisOpt)
+	alerts := safetyalert.NewDispatcher(store, redisClient, safetyalert.NewNotifier(config.AlertWebhookURL))
+	checkinWorker := worker.NewCheckinWorker(store, alerts)
+	checkinWorker.Start()
+	storyPublishWorker := worker.NewStoryPublishWorker(store, redisClient)
+	storyPublishWorker.Start()
 
 	server, err := api.NewServer(config, store)
 	if err != nil {
@@ -0,0 +1,5 @@
+-- Note: PostgreSQL cannot drop the 'safety_warning' notification_type value
+DROP INDEX IF EXISTS idx_user_restrictions_active;
+DROP TABLE IF EXISTS user_restrictions;
+DROP INDEX IF EXISTS idx_location_strikes_user;
+DROP TABLE IF EXISTS location_strikes;
@@ -0,0 +1,28 @@
+-- Strike history for the fake-GPS scoring engine
+CREATE TABLE location_strikes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    score REAL NOT NULL,
+    signals JSONB NOT NULL DEFAULT '[]'::jsonb,
+    action VARCHAR(20) NOT NULL CHECK (action IN ('warn', 'drop', 'restrict', 'shadow_ban')),
+    geohash VARCHAR(5) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_location_strikes_user ON location_strikes(user_id, created_at DESC);
+
+-- Feature restrictions applied to a user (e.g. no crossings after repeated GPS strikes)
+CREATE TABLE user_restrictions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('crossings')),
+    source VARCHAR(20) NOT NULL,
+    reason TEXT NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    lifted_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_user_restrictions_active ON user_restrictions(user_id, kind, expires_at) WHERE lifted_at IS NULL;
+
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'safety_warning';
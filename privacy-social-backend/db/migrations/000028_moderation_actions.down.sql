@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_ban_appeals_status;
+DROP INDEX IF EXISTS idx_ban_appeals_pending;
+DROP TABLE IF EXISTS ban_appeals;
+
+DROP INDEX IF EXISTS idx_moderation_actions_user;
+DROP TABLE IF EXISTS moderation_actions;
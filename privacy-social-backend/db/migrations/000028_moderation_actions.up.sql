@@ -0,0 +1,30 @@
+-- Record of every ban/unban with its reason, source and evidence
+CREATE TABLE moderation_actions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    action VARCHAR(20) NOT NULL CHECK (action IN ('shadow_ban', 'unban')),
+    source VARCHAR(20) NOT NULL CHECK (source IN ('auto_gps', 'report_threshold', 'admin')),
+    reason TEXT NOT NULL,
+    evidence JSONB NOT NULL DEFAULT '{}'::jsonb,
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_moderation_actions_user ON moderation_actions(user_id, created_at DESC);
+
+-- Appeals filed by restricted users
+CREATE TABLE ban_appeals (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    moderation_action_id UUID REFERENCES moderation_actions(id) ON DELETE SET NULL,
+    message TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected')),
+    reviewer_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    decision_note TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    decided_at TIMESTAMPTZ
+);
+
+-- One open appeal per user
+CREATE UNIQUE INDEX idx_ban_appeals_pending ON ban_appeals(user_id) WHERE status = 'pending';
+CREATE INDEX idx_ban_appeals_status ON ban_appeals(status, created_at);
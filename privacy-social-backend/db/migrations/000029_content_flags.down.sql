@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_content_flags_status;
+DROP INDEX IF EXISTS idx_content_flags_pending;
+DROP TABLE IF EXISTS content_flags;
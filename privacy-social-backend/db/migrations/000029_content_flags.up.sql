@@ -0,0 +1,18 @@
+-- Content held for moderator review. Pending flags hide the content from everyone but its author.
+CREATE TABLE content_flags (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    content_type VARCHAR(20) NOT NULL CHECK (content_type IN ('story', 'message', 'profile')),
+    content_id UUID NOT NULL, -- story id, message id, or user id for profile fields
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE, -- author
+    field VARCHAR(20) NOT NULL, -- caption, message, bio, username, full_name
+    content TEXT NOT NULL, -- snapshot of the flagged text
+    rule VARCHAR(100) NOT NULL,
+    score REAL NOT NULL DEFAULT 0,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'removed')),
+    reviewer_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_content_flags_pending ON content_flags(content_type, content_id) WHERE status = 'pending';
+CREATE INDEX idx_content_flags_status ON content_flags(status, created_at);
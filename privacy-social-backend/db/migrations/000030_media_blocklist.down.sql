@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS media_blocklist;
+DROP TABLE IF EXISTS media_hashes;
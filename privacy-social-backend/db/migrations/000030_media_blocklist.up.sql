@@ -0,0 +1,19 @@
+-- Perceptual hashes of uploaded images, keyed by their public URL
+CREATE TABLE media_hashes (
+    media_url TEXT PRIMARY KEY,
+    uploader_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    dhash BIGINT NOT NULL,
+    phash BIGINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Admin-managed blocklist, matched by Hamming distance on upload
+CREATE TABLE media_blocklist (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    dhash BIGINT NOT NULL,
+    phash BIGINT NOT NULL,
+    reason TEXT NOT NULL,
+    source_story_id UUID, -- story the hash was taken from (may be deleted)
+    added_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_reports_target_story;
+DROP INDEX IF EXISTS idx_reports_target_user;
+DROP TABLE IF EXISTS report_escalation_reports;
+DROP TABLE IF EXISTS report_escalations;
+
+DELETE FROM user_restrictions WHERE kind = 'messages';
+ALTER TABLE user_restrictions DROP CONSTRAINT IF EXISTS user_restrictions_kind_check;
+ALTER TABLE user_restrictions ADD CONSTRAINT user_restrictions_kind_check CHECK (kind IN ('crossings'));
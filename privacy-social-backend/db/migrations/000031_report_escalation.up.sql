@@ -0,0 +1,33 @@
+-- Reports can now restrict direct messages as well as crossings
+ALTER TABLE user_restrictions DROP CONSTRAINT IF EXISTS user_restrictions_kind_check;
+ALTER TABLE user_restrictions ADD CONSTRAINT user_restrictions_kind_check CHECK (kind IN ('crossings', 'messages'));
+
+-- Automatic actions taken when weighted reports cross a threshold. Each can be reverted by an admin.
+CREATE TABLE report_escalations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    target_type VARCHAR(20) NOT NULL CHECK (target_type IN ('story', 'user')),
+    target_id UUID NOT NULL, -- story id or user id
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE, -- owner of the reported content
+    action VARCHAR(20) NOT NULL CHECK (action IN ('hide_story', 'restrict_user')),
+    weighted_reports REAL NOT NULL,
+    reporters INT NOT NULL,
+    content_flag_id UUID REFERENCES content_flags(id) ON DELETE SET NULL,
+    trust_penalty INT NOT NULL DEFAULT 0,
+    reverted_at TIMESTAMPTZ,
+    reverted_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_report_escalations_target ON report_escalations(target_type, target_id, created_at DESC);
+CREATE INDEX idx_report_escalations_created ON report_escalations(created_at DESC);
+
+-- Reports that counted towards an escalation. Reverted escalations lower the reporters' weight.
+CREATE TABLE report_escalation_reports (
+    escalation_id UUID NOT NULL REFERENCES report_escalations(id) ON DELETE CASCADE,
+    report_id UUID NOT NULL REFERENCES reports(id) ON DELETE CASCADE,
+    PRIMARY KEY (escalation_id, report_id)
+);
+
+CREATE INDEX idx_report_escalation_reports_report ON report_escalation_reports(report_id);
+CREATE INDEX idx_reports_target_user ON reports(target_user_id, created_at);
+CREATE INDEX idx_reports_target_story ON reports(target_story_id, created_at);
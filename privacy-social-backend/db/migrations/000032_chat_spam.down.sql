@@ -0,0 +1,3 @@
+DELETE FROM reports WHERE reporter_id IS NULL;
+ALTER TABLE reports DROP COLUMN IF EXISTS source;
+ALTER TABLE reports ALTER COLUMN reporter_id SET NOT NULL;
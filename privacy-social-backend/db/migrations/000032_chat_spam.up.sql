@@ -0,0 +1,3 @@
+-- System reports (e.g. from the chat spam detector) have no human reporter
+ALTER TABLE reports ALTER COLUMN reporter_id DROP NOT NULL;
+ALTER TABLE reports ADD COLUMN source VARCHAR(20) NOT NULL DEFAULT 'user' CHECK (source IN ('user', 'system'));
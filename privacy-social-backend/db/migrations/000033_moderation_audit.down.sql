@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS moderation_audit_no_truncate ON moderation_audit;
+DROP TRIGGER IF EXISTS moderation_audit_no_update ON moderation_audit;
+DROP TABLE IF EXISTS moderation_audit;
+DROP FUNCTION IF EXISTS moderation_audit_append_only();
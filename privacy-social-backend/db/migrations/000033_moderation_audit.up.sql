@@ -0,0 +1,33 @@
+-- Append-only record of every admin and moderator action
+CREATE TABLE moderation_audit (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    actor_id UUID NOT NULL, -- no FK: entries must outlive deleted accounts
+    actor_role VARCHAR(20) NOT NULL,
+    action VARCHAR(50) NOT NULL,
+    target_type VARCHAR(20) NOT NULL,
+    target_id UUID NOT NULL,
+    reason TEXT NOT NULL,
+    before JSONB,
+    after JSONB,
+    request_id VARCHAR(64) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_moderation_audit_created ON moderation_audit(created_at DESC);
+CREATE INDEX idx_moderation_audit_actor ON moderation_audit(actor_id, created_at DESC);
+CREATE INDEX idx_moderation_audit_target ON moderation_audit(target_type, target_id, created_at DESC);
+CREATE INDEX idx_moderation_audit_action ON moderation_audit(action, created_at DESC);
+
+CREATE FUNCTION moderation_audit_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'moderation_audit is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER moderation_audit_no_update
+    BEFORE UPDATE OR DELETE ON moderation_audit
+    FOR EACH ROW EXECUTE FUNCTION moderation_audit_append_only();
+
+CREATE TRIGGER moderation_audit_no_truncate
+    BEFORE TRUNCATE ON moderation_audit
+    FOR EACH STATEMENT EXECUTE FUNCTION moderation_audit_append_only();
@@ -0,0 +1,17 @@
+-- Note: PostgreSQL cannot drop the 'report_reviewed' notification_type value
+DROP TABLE IF EXISTS report_outcomes;
+DROP TABLE IF EXISTS report_notes;
+DROP INDEX IF EXISTS idx_reports_assignee;
+DROP INDEX IF EXISTS idx_reports_target_type;
+DROP INDEX IF EXISTS idx_reports_status;
+DELETE FROM reports WHERE target_type = 'story' AND target_story_id IS NULL;
+ALTER TABLE reports DROP CONSTRAINT IF EXISTS reports_target_story_id_fkey;
+ALTER TABLE reports ADD CONSTRAINT reports_target_story_id_fkey
+    FOREIGN KEY (target_story_id) REFERENCES stories(id) ON DELETE CASCADE;
+ALTER TABLE reports
+    DROP COLUMN IF EXISTS target_type,
+    DROP COLUMN IF EXISTS updated_at,
+    DROP COLUMN IF EXISTS closed_at,
+    DROP COLUMN IF EXISTS assignee_id,
+    DROP COLUMN IF EXISTS priority,
+    DROP COLUMN IF EXISTS status;
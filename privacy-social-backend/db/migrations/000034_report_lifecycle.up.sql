@@ -0,0 +1,44 @@
+-- Case workflow for reports. is_resolved stays in sync with closed statuses for existing queries.
+ALTER TABLE reports
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'in_review', 'actioned', 'dismissed')),
+    ADD COLUMN priority VARCHAR(10) NOT NULL DEFAULT 'normal' CHECK (priority IN ('low', 'normal', 'high', 'urgent')),
+    ADD COLUMN assignee_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    ADD COLUMN closed_at TIMESTAMPTZ,
+    ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+
+UPDATE reports SET status = 'actioned', closed_at = created_at WHERE is_resolved = true;
+
+-- Cases must outlive the story they are about (e.g. when the outcome removed it)
+ALTER TABLE reports ADD COLUMN target_type VARCHAR(10) NOT NULL DEFAULT 'user' CHECK (target_type IN ('user', 'story'));
+UPDATE reports SET target_type = 'story' WHERE target_story_id IS NOT NULL;
+ALTER TABLE reports DROP CONSTRAINT IF EXISTS reports_target_story_id_fkey;
+ALTER TABLE reports ADD CONSTRAINT reports_target_story_id_fkey
+    FOREIGN KEY (target_story_id) REFERENCES stories(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_reports_status ON reports(status, priority, created_at DESC);
+CREATE INDEX idx_reports_target_type ON reports(target_type, created_at DESC);
+CREATE INDEX idx_reports_assignee ON reports(assignee_id, status) WHERE assignee_id IS NOT NULL;
+
+-- Internal moderator notes, never shown to the reporter or the reported user
+CREATE TABLE report_notes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    report_id UUID NOT NULL REFERENCES reports(id) ON DELETE CASCADE,
+    author_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_report_notes_report ON report_notes(report_id, created_at);
+
+-- Actions taken as a result of a report
+CREATE TABLE report_outcomes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    report_id UUID NOT NULL REFERENCES reports(id) ON DELETE CASCADE,
+    action VARCHAR(20) NOT NULL CHECK (action IN ('story_removed', 'user_warned', 'user_banned')),
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_report_outcomes_report ON report_outcomes(report_id);
+
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'report_reviewed';
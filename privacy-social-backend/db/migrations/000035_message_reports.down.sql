@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_reports_target_message;
+DROP TABLE IF EXISTS report_evidence;
+DELETE FROM reports WHERE target_type = 'message';
+ALTER TABLE reports DROP CONSTRAINT IF EXISTS reports_target_user_id_fkey;
+ALTER TABLE reports ADD CONSTRAINT reports_target_user_id_fkey
+    FOREIGN KEY (target_user_id) REFERENCES users(id) ON DELETE CASCADE;
+ALTER TABLE reports DROP CONSTRAINT IF EXISTS reports_target_type_check;
+ALTER TABLE reports ADD CONSTRAINT reports_target_type_check CHECK (target_type IN ('user', 'story'));
+ALTER TABLE reports DROP COLUMN IF EXISTS target_message_id;
@@ -0,0 +1,31 @@
+-- Direct messages can be reported. No FK: the message may expire or be deleted after the report.
+ALTER TABLE reports ADD COLUMN target_message_id UUID;
+ALTER TABLE reports DROP CONSTRAINT IF EXISTS reports_target_type_check;
+ALTER TABLE reports ADD CONSTRAINT reports_target_type_check CHECK (target_type IN ('user', 'story', 'message'));
+
+-- Cases must also outlive the reported account
+ALTER TABLE reports DROP CONSTRAINT IF EXISTS reports_target_user_id_fkey;
+ALTER TABLE reports ADD CONSTRAINT reports_target_user_id_fkey
+    FOREIGN KEY (target_user_id) REFERENCES users(id) ON DELETE SET NULL;
+
+-- Moderator-only copies of reported messages and their surrounding conversation.
+-- Sender/receiver have no FK so snapshots survive message expiry and account deletion.
+CREATE TABLE report_evidence (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    report_id UUID NOT NULL REFERENCES reports(id) ON DELETE CASCADE,
+    message_id UUID NOT NULL,
+    sender_id UUID NOT NULL,
+    receiver_id UUID NOT NULL,
+    content TEXT NOT NULL,
+    media_type VARCHAR(20),
+    original_media_url TEXT,
+    media_path TEXT, -- copy in the evidence store, never served publicly
+    is_reported BOOLEAN NOT NULL DEFAULT false, -- the reported message itself, not context
+    sent_at TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_report_evidence_report ON report_evidence(report_id, sent_at);
+CREATE INDEX idx_report_evidence_expires ON report_evidence(expires_at);
+CREATE INDEX idx_reports_target_message ON reports(target_message_id) WHERE target_message_id IS NOT NULL;
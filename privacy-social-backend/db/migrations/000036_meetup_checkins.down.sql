@@ -0,0 +1,3 @@
+-- Note: PostgreSQL cannot drop the 'safety_alert' notification_type value
+DROP TABLE IF EXISTS meetup_checkins;
+DROP TABLE IF EXISTS emergency_contacts;
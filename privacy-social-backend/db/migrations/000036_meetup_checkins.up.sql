@@ -0,0 +1,31 @@
+-- People alerted when a meetup check-in is missed or panic mode is triggered.
+-- Either an accepted connection (in-app) or an external email/phone contact.
+CREATE TABLE emergency_contacts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    contact_user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    email VARCHAR(255),
+    phone VARCHAR(32),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (contact_user_id IS NOT NULL OR email IS NOT NULL OR phone IS NOT NULL)
+);
+
+CREATE INDEX idx_emergency_contacts_user ON emergency_contacts(user_id, created_at);
+CREATE UNIQUE INDEX idx_emergency_contacts_contact_user ON emergency_contacts(user_id, contact_user_id) WHERE contact_user_id IS NOT NULL;
+
+-- A meetup the user has to confirm they're safe after, before the deadline
+CREATE TABLE meetup_checkins (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    note TEXT,
+    deadline TIMESTAMPTZ NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'confirmed', 'cancelled', 'alerted')),
+    closed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_meetup_checkins_active_user ON meetup_checkins(user_id) WHERE status = 'active';
+CREATE INDEX idx_meetup_checkins_deadline ON meetup_checkins(deadline) WHERE status = 'active';
+
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'safety_alert';
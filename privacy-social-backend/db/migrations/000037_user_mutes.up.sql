@@ -0,0 +1,16 @@
+-- Mutes hide a user's stories and/or silence their messages for the muter only.
+-- Unlike blocks they are one-sided and invisible to the muted user.
+CREATE TABLE user_mutes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    muter_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    muted_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    mute_stories BOOLEAN NOT NULL DEFAULT false,
+    mute_messages BOOLEAN NOT NULL DEFAULT false,
+    expires_at TIMESTAMPTZ, -- NULL mutes until removed
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (muter_id, muted_id),
+    CHECK (muter_id <> muted_id),
+    CHECK (mute_stories OR mute_messages)
+);
+
+CREATE INDEX idx_user_mutes_muted ON user_mutes(muted_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS hidden_messages;
+DROP TABLE IF EXISTS hidden_words;
@@ -0,0 +1,20 @@
+-- Words and phrases a user doesn't want to see. "*" is a wildcard within a word.
+CREATE TABLE hidden_words (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    phrase VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_hidden_words_user_phrase ON hidden_words(user_id, LOWER(phrase));
+
+-- Incoming messages that matched the receiver's hidden words when sent.
+-- They are kept out of the conversation and listed under hidden requests.
+CREATE TABLE hidden_messages (
+    message_id UUID NOT NULL REFERENCES messages(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (message_id, user_id)
+);
+
+CREATE INDEX idx_hidden_messages_user ON hidden_messages(user_id, created_at DESC);
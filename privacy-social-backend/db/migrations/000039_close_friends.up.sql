@@ -0,0 +1,11 @@
+-- Close friends: the audience of close_friends stories. Members are accepted
+-- connections and are never told they were added.
+CREATE TABLE close_friends (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    friend_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, friend_id),
+    CHECK (user_id <> friend_id)
+);
+
+CREATE INDEX idx_close_friends_friend ON close_friends(friend_id);
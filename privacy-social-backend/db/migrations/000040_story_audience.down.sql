@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS story_hidden_from;
+DROP TABLE IF EXISTS story_audience;
@@ -0,0 +1,21 @@
+-- Per-story audience rules. A story with any 'allow' rows is only shown to
+-- those users; 'exclude' rows hide it from specific users. The author always sees it.
+CREATE TABLE story_audience (
+    story_id UUID NOT NULL REFERENCES stories(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    rule VARCHAR(10) NOT NULL CHECK (rule IN ('allow', 'exclude')),
+    PRIMARY KEY (story_id, user_id)
+);
+
+CREATE INDEX idx_story_audience_user ON story_audience(user_id);
+
+-- "Hide my stories from": applies to every story of the user, current and future
+CREATE TABLE story_hidden_from (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    hidden_user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, hidden_user_id),
+    CHECK (user_id <> hidden_user_id)
+);
+
+CREATE INDEX idx_story_hidden_from_hidden ON story_hidden_from(hidden_user_id);
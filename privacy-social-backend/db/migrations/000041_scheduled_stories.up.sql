@@ -0,0 +1,9 @@
+-- Stories waiting for their publish time. A story with a row here is hidden
+-- from every read until the publish worker removes it.
+CREATE TABLE scheduled_stories (
+    story_id UUID PRIMARY KEY REFERENCES stories(id) ON DELETE CASCADE,
+    publish_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_scheduled_stories_publish_at ON scheduled_stories(publish_at);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS highlight_items;
+DROP TABLE IF EXISTS highlights;
@@ -0,0 +1,25 @@
+-- Highlights: named collections of archived stories shown on the profile.
+-- The audience reuses the story visibility levels.
+CREATE TABLE highlights (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    title VARCHAR(50) NOT NULL,
+    cover_archive_id UUID REFERENCES archived_stories(id) ON DELETE SET NULL,
+    audience story_availability NOT NULL DEFAULT 'public',
+    position INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_highlights_user ON highlights(user_id, position);
+
+-- Deleting an archived story removes it from every highlight
+CREATE TABLE highlight_items (
+    highlight_id UUID NOT NULL REFERENCES highlights(id) ON DELETE CASCADE,
+    archive_id UUID NOT NULL REFERENCES archived_stories(id) ON DELETE CASCADE,
+    position INT NOT NULL DEFAULT 0,
+    added_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (highlight_id, archive_id)
+);
+
+CREATE INDEX idx_highlight_items_archive ON highlight_items(archive_id);
@@ -0,0 +1,5 @@
+-- Note: PostgreSQL cannot drop the 'story_expiring' notification_type value
+ALTER TABLE archived_stories
+    DROP COLUMN IF EXISTS reaction_count,
+    DROP COLUMN IF EXISTS view_count;
+ALTER TABLE privacy_settings DROP COLUMN IF EXISTS auto_archive;
@@ -0,0 +1,10 @@
+-- Auto-archive: expiring stories are copied to the archive before deletion
+ALTER TABLE privacy_settings ADD COLUMN auto_archive BOOLEAN NOT NULL DEFAULT false;
+
+-- Engagement at archive time
+ALTER TABLE archived_stories
+    ADD COLUMN view_count INT NOT NULL DEFAULT 0,
+    ADD COLUMN reaction_count INT NOT NULL DEFAULT 0;
+
+-- Sent shortly before a story expires when auto-archive is off
+ALTER TYPE notification_type ADD VALUE IF NOT EXISTS 'story_expiring';
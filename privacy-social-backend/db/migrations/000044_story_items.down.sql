@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS story_item_views;
+DROP TABLE IF EXISTS story_items;
@@ -0,0 +1,22 @@
+-- Story sequences: ordered items inside one story. The story row keeps the
+-- first item's media as its cover, so feeds and the map show one card, and
+-- items expire with the story.
+CREATE TABLE story_items (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    story_id UUID NOT NULL REFERENCES stories(id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    media_url TEXT NOT NULL,
+    media_type VARCHAR(10) NOT NULL,
+    caption TEXT,
+    duration_ms INT NOT NULL DEFAULT 5000,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (story_id, position)
+);
+
+-- Per-item views, so owners can see where viewers drop off
+CREATE TABLE story_item_views (
+    item_id UUID NOT NULL REFERENCES story_items(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    viewed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (item_id, user_id)
+);
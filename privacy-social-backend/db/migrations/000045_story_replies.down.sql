@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS message_story_refs;
+ALTER TABLE privacy_settings DROP COLUMN IF EXISTS story_replies;
@@ -0,0 +1,19 @@
+-- Who can reply to your stories by DM. Connections follow the usual chat
+-- rules; non-connections also need who_can_message = 'everyone'.
+ALTER TABLE privacy_settings ADD COLUMN story_replies VARCHAR(20) NOT NULL DEFAULT 'connections'
+    CHECK (story_replies IN ('everyone', 'connections', 'off'));
+
+-- Story replies: the message links to the story and keeps a snapshot of it,
+-- so the context survives the story's expiry
+CREATE TABLE message_story_refs (
+    message_id UUID PRIMARY KEY REFERENCES messages(id) ON DELETE CASCADE,
+    story_id UUID REFERENCES stories(id) ON DELETE SET NULL,
+    story_owner_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    media_type VARCHAR(10) NOT NULL,
+    thumbnail_url TEXT,
+    caption TEXT,
+    story_created_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_message_story_refs_story ON message_story_refs(story_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS story_sticker_responses;
+DROP TABLE IF EXISTS story_stickers;
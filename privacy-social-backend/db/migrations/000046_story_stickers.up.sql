@@ -0,0 +1,24 @@
+-- Interactive stickers on stories: polls (2-4 options), emoji sliders and
+-- open questions. They expire and are deleted with the story.
+CREATE TABLE story_stickers (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    story_id UUID NOT NULL REFERENCES stories(id) ON DELETE CASCADE,
+    kind VARCHAR(10) NOT NULL CHECK (kind IN ('poll', 'slider', 'question')),
+    prompt TEXT NOT NULL,
+    options TEXT[] NOT NULL DEFAULT '{}', -- Poll options
+    emoji VARCHAR(10),                    -- Slider emoji
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_story_stickers_story ON story_stickers(story_id);
+
+-- One response per viewer and sticker; the column used depends on the kind
+CREATE TABLE story_sticker_responses (
+    sticker_id UUID NOT NULL REFERENCES story_stickers(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    option_index INT,
+    slider_value INT CHECK (slider_value BETWEEN 0 AND 100),
+    answer TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (sticker_id, user_id)
+);
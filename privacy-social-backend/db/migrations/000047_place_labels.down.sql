@@ -0,0 +1,2 @@
+ALTER TABLE archived_stories DROP COLUMN IF EXISTS place_label;
+ALTER TABLE stories DROP COLUMN IF EXISTS place_label;
@@ -0,0 +1,4 @@
+-- Coarse place label ("Shoreditch, London") from the offline geocoder, set
+-- only while show_location is on. Kept when the story is archived.
+ALTER TABLE stories ADD COLUMN place_label TEXT;
+ALTER TABLE archived_stories ADD COLUMN place_label TEXT;
@@ -0,0 +1 @@
+ALTER TABLE report_escalations DROP COLUMN IF EXISTS expires_at;
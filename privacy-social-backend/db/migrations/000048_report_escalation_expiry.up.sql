@@ -0,0 +1,11 @@
+-- When an escalation's effect ends on its own (restrict_user: the restrictions'
+-- expires_at). NULL means it lasts until an admin reverts it.
+ALTER TABLE report_escalations ADD COLUMN expires_at TIMESTAMPTZ;
+
+-- Restrictions were created in the same transaction as their escalation
+UPDATE report_escalations e
+SET expires_at = (
+    SELECT MAX(r.expires_at) FROM user_restrictions r
+    WHERE r.user_id = e.user_id AND r.source = 'report_threshold' AND r.created_at = e.created_at
+)
+WHERE e.action = 'restrict_user';
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_meetup_checkins_alerting;
+UPDATE meetup_checkins SET status = 'alerted', closed_at = COALESCE(closed_at, NOW()) WHERE status = 'alerting';
+ALTER TABLE meetup_checkins DROP COLUMN IF EXISTS next_alert_at;
+ALTER TABLE meetup_checkins DROP COLUMN IF EXISTS alert_attempts;
+ALTER TABLE meetup_checkins DROP CONSTRAINT IF EXISTS meetup_checkins_status_check;
+ALTER TABLE meetup_checkins ADD CONSTRAINT meetup_checkins_status_check
+    CHECK (status IN ('active', 'confirmed', 'cancelled', 'alerted'));
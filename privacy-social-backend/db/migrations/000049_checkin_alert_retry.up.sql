@@ -0,0 +1,9 @@
+-- Missed check-ins stay 'alerting' until their alert is delivered; failed
+-- deliveries are retried once next_alert_at passes
+ALTER TABLE meetup_checkins DROP CONSTRAINT IF EXISTS meetup_checkins_status_check;
+ALTER TABLE meetup_checkins ADD CONSTRAINT meetup_checkins_status_check
+    CHECK (status IN ('active', 'confirmed', 'cancelled', 'alerting', 'alerted'));
+ALTER TABLE meetup_checkins ADD COLUMN alert_attempts INT NOT NULL DEFAULT 0;
+ALTER TABLE meetup_checkins ADD COLUMN next_alert_at TIMESTAMPTZ;
+
+CREATE INDEX idx_meetup_checkins_alerting ON meetup_checkins(next_alert_at) WHERE status = 'alerting';
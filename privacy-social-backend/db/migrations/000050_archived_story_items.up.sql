@@ -0,0 +1,18 @@
+-- Items of archived story sequences. story_items go with their story, so the
+-- archive keeps its own copy; the archived story keeps the cover as before.
+CREATE TABLE archived_story_items (
+    archive_id UUID NOT NULL REFERENCES archived_stories(id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    media_url TEXT NOT NULL,
+    media_type VARCHAR(10) NOT NULL,
+    caption TEXT,
+    duration_ms INT NOT NULL DEFAULT 5000,
+    PRIMARY KEY (archive_id, position)
+);
+
+-- Sequences archived before this table whose story is still live
+INSERT INTO archived_story_items (archive_id, position, media_url, media_type, caption, duration_ms)
+SELECT a.id, si.position, si.media_url, si.media_type, si.caption, si.duration_ms
+FROM archived_stories a
+JOIN story_items si ON si.story_id = a.story_id
+ON CONFLICT (archive_id, position) DO NOTHING;
@@ -1,16 +1,40 @@
 -- name: ArchiveStory :one
 INSERT INTO archived_stories (
     user_id, story_id, media_url, media_type, caption,
-    geohash, geom, is_anonymous, show_location, original_created_at
+    geohash, geom, is_anonymous, show_location, original_created_at,
+    view_count, reaction_count, place_label
 )
 SELECT 
     s.user_id, s.id, s.media_url, s.media_type, s.caption,
-    s.geohash, s.geom, s.is_anonymous, s.show_location, s.created_at
+    s.geohash, s.geom, s.is_anonymous, s.show_location, s.created_at,
+    (SELECT COUNT(*) FROM story_views v WHERE v.story_id = s.id),
+    (SELECT COUNT(*) FROM story_reactions r WHERE r.story_id = s.id),
+    s.place_label
 FROM stories s
 WHERE s.id = $1 AND s.user_id = $2
+  -- Stories held for review can't be archived until they're approved
+  AND NOT EXISTS (
+    SELECT 1 FROM content_flags cf
+    WHERE cf.content_type = 'story' AND cf.content_id = s.id AND cf.status IN ('pending', 'removed')
+  )
 ON CONFLICT (user_id, story_id) DO NOTHING
 RETURNING *;
 
+-- Copies a sequence's items into the archive. Run with ArchiveStory, in its transaction.
+-- name: ArchiveStoryItems :many
+INSERT INTO archived_story_items (archive_id, position, media_url, media_type, caption, duration_ms)
+SELECT a.id, si.position, si.media_url, si.media_type, si.caption, si.duration_ms
+FROM archived_stories a
+JOIN story_items si ON si.story_id = a.story_id
+WHERE a.id = $1
+ON CONFLICT (archive_id, position) DO NOTHING
+RETURNING *;
+
+-- name: ListArchivedStoryItems :many
+SELECT * FROM archived_story_items
+WHERE archive_id = ANY(@archive_ids::uuid[])
+ORDER BY archive_id, position;
+
 -- name: GetArchivedStories :many
 SELECT * FROM archived_stories
 WHERE user_id = $1
@@ -25,6 +49,11 @@ WHERE id = $1 AND user_id = $2;
 DELETE FROM archived_stories
 WHERE id = $1 AND user_id = $2;
 
+-- Removes every archive copy of a story. Run with moderation deletes, in their transaction.
+-- name: DeleteStoryArchives :exec
+DELETE FROM archived_stories
+WHERE story_id = $1;
+
 -- name: CountArchivedStories :one
 SELECT COUNT(*) FROM archived_stories
 WHERE user_id = $1;
@@ -0,0 +1,26 @@
+-- name: CreateModerationAudit :exec
+INSERT INTO moderation_audit (
+  actor_id,
+  actor_role,
+  action,
+  target_type,
+  target_id,
+  reason,
+  before,
+  after,
+  request_id
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9
+);
+
+-- Admin: Audit log with optional filters, newest first
+-- name: ListModerationAudit :many
+SELECT * FROM moderation_audit
+WHERE (sqlc.narg('actor_id')::uuid IS NULL OR actor_id = sqlc.narg('actor_id'))
+  AND (sqlc.narg('action')::text IS NULL OR action = sqlc.narg('action'))
+  AND (sqlc.narg('target_type')::text IS NULL OR target_type = sqlc.narg('target_type'))
+  AND (sqlc.narg('target_id')::uuid IS NULL OR target_id = sqlc.narg('target_id'))
+  AND (sqlc.narg('since')::timestamptz IS NULL OR created_at >= sqlc.narg('since'))
+  AND (sqlc.narg('until')::timestamptz IS NULL OR created_at < sqlc.narg('until'))
+ORDER BY created_at DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
@@ -0,0 +1,76 @@
+-- name: CreateEmergencyContact :one
+INSERT INTO emergency_contacts (
+  user_id,
+  contact_user_id,
+  name,
+  email,
+  phone
+) VALUES (
+  $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: ListEmergencyContacts :many
+SELECT * FROM emergency_contacts
+WHERE user_id = $1
+ORDER BY created_at;
+
+-- name: CountEmergencyContacts :one
+SELECT COUNT(*) FROM emergency_contacts
+WHERE user_id = $1;
+
+-- name: DeleteEmergencyContact :one
+DELETE FROM emergency_contacts
+WHERE id = $1 AND user_id = $2
+RETURNING *;
+
+-- Drops both users from each other's emergency contacts when one blocks the other
+-- name: DeleteEmergencyContactPair :exec
+DELETE FROM emergency_contacts
+WHERE (user_id = @user_id AND contact_user_id = @other_user_id::uuid)
+   OR (user_id = @other_user_id AND contact_user_id = @user_id);
+
+-- name: CreateMeetupCheckin :one
+INSERT INTO meetup_checkins (
+  user_id,
+  note,
+  deadline
+) VALUES (
+  $1, $2, $3
+) RETURNING *;
+
+-- name: GetActiveMeetupCheckin :one
+SELECT * FROM meetup_checkins
+WHERE user_id = $1 AND status = 'active'
+LIMIT 1;
+
+-- Confirm or cancel the active check-in before the worker alerts
+-- name: CloseMeetupCheckin :one
+UPDATE meetup_checkins
+SET status = $2, closed_at = NOW()
+WHERE user_id = $1 AND status = 'active'
+RETURNING *;
+
+-- Claims missed check-ins for alerting. The claim is a lease: if delivery
+-- fails the check-in is claimed again after next_alert_at, backing off up to
+-- 15 minutes, so a missed check-in is never dropped.
+-- name: ClaimOverdueMeetupCheckins :many
+UPDATE meetup_checkins
+SET
+  status = 'alerting',
+  alert_attempts = alert_attempts + 1,
+  next_alert_at = NOW() + LEAST(alert_attempts + 1, 15) * INTERVAL '1 minute'
+WHERE id IN (
+  SELECT id FROM meetup_checkins
+  WHERE (status = 'active' AND deadline < NOW())
+     OR (status = 'alerting' AND next_alert_at < NOW())
+  ORDER BY deadline
+  LIMIT $1
+  FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
+
+-- Closes a check-in once its alert was delivered
+-- name: MarkMeetupCheckinAlerted :exec
+UPDATE meetup_checkins
+SET status = 'alerted', closed_at = NOW(), next_alert_at = NULL
+WHERE id = $1 AND status = 'alerting';
@@ -0,0 +1,25 @@
+-- name: AddCloseFriend :exec
+INSERT INTO close_friends (
+  user_id,
+  friend_id
+) VALUES (
+  $1, $2
+)
+ON CONFLICT (user_id, friend_id) DO NOTHING;
+
+-- name: RemoveCloseFriend :exec
+DELETE FROM close_friends
+WHERE user_id = $1 AND friend_id = $2;
+
+-- name: ListCloseFriends :many
+SELECT u.id, u.username, u.full_name, u.avatar_url, cf.created_at AS added_at
+FROM close_friends cf
+JOIN users u ON u.id = cf.friend_id
+WHERE cf.user_id = $1
+ORDER BY u.username;
+
+-- Drops both users from each other's list when their connection ends
+-- name: DeleteCloseFriendPair :exec
+DELETE FROM close_friends
+WHERE (user_id = $1 AND friend_id = $2)
+   OR (user_id = $2 AND friend_id = $1);
@@ -102,5 +102,6 @@ SELECT
 FROM users u
 WHERE u.id NOT IN (SELECT id FROM excluded_users)
 AND u.is_shadow_banned = false
+AND u.trust_level >= $3
 ORDER BY mutual_count DESC, u.created_at DESC
 LIMIT $2;
@@ -0,0 +1,47 @@
+-- name: CreateContentFlag :one
+INSERT INTO content_flags (
+  content_type,
+  content_id,
+  user_id,
+  field,
+  content,
+  rule,
+  score
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- name: GetContentFlag :one
+SELECT * FROM content_flags
+WHERE id = $1;
+
+-- name: HasPendingContentFlag :one
+SELECT EXISTS (
+    SELECT 1 FROM content_flags
+    WHERE content_type = $1 AND content_id = $2
+    AND status = 'pending'
+);
+
+-- name: ListPendingProfileFlags :many
+SELECT * FROM content_flags
+WHERE content_type = 'profile' AND user_id = $1 AND status = 'pending'
+ORDER BY created_at ASC;
+
+-- Admin: Review queue
+-- name: ListContentFlags :many
+SELECT f.*, u.username
+FROM content_flags f
+JOIN users u ON f.user_id = u.id
+WHERE f.status = $1
+ORDER BY f.created_at ASC
+LIMIT $2 OFFSET $3;
+
+-- Admin: Approve or remove flagged content
+-- name: ReviewContentFlag :one
+UPDATE content_flags
+SET
+  status = $2,
+  reviewer_id = $3,
+  reviewed_at = NOW()
+WHERE id = $1 AND status = 'pending'
+RETURNING *;
@@ -0,0 +1,91 @@
+-- Open reports on a story with what is needed to weight each reporter
+-- name: ListStoryReportWeights :many
+SELECT r.id AS report_id,
+  r.reporter_id,
+  u.trust_level,
+  u.created_at AS reporter_created_at,
+  (SELECT COUNT(*) FROM report_escalation_reports er
+   JOIN report_escalations e ON er.escalation_id = e.id
+   JOIN reports fr ON er.report_id = fr.id
+   WHERE fr.reporter_id = r.reporter_id AND e.reverted_at IS NOT NULL)::int AS false_reports
+FROM reports r
+JOIN users u ON r.reporter_id = u.id
+WHERE r.target_story_id = $1
+  AND r.created_at > $2
+  AND r.is_resolved = false;
+
+-- Open reports against a user or any of their stories
+-- name: ListUserReportWeights :many
+SELECT r.id AS report_id,
+  r.reporter_id,
+  u.trust_level,
+  u.created_at AS reporter_created_at,
+  (SELECT COUNT(*) FROM report_escalation_reports er
+   JOIN report_escalations e ON er.escalation_id = e.id
+   JOIN reports fr ON er.report_id = fr.id
+   WHERE fr.reporter_id = r.reporter_id AND e.reverted_at IS NOT NULL)::int AS false_reports
+FROM reports r
+JOIN users u ON r.reporter_id = u.id
+LEFT JOIN stories s ON r.target_story_id = s.id
+WHERE (r.target_user_id = $1 OR s.user_id = $1)
+  AND r.created_at > $2
+  AND r.is_resolved = false;
+
+-- name: CreateReportEscalation :one
+INSERT INTO report_escalations (
+  target_type,
+  target_id,
+  user_id,
+  action,
+  weighted_reports,
+  reporters,
+  content_flag_id,
+  trust_penalty,
+  expires_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING *;
+
+-- name: AddReportToEscalation :exec
+INSERT INTO report_escalation_reports (
+  escalation_id,
+  report_id
+) VALUES (
+  $1, $2
+) ON CONFLICT DO NOTHING;
+
+-- name: GetLatestReportEscalation :one
+SELECT * FROM report_escalations
+WHERE target_type = $1 AND target_id = $2
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- Admin: Automatic actions, newest first
+-- name: ListReportEscalations :many
+SELECT e.*,
+  u.username
+FROM report_escalations e
+JOIN users u ON e.user_id = u.id
+ORDER BY e.created_at DESC
+LIMIT $1 OFFSET $2;
+
+-- Admin: Undo an automatic action
+-- name: RevertReportEscalation :one
+UPDATE report_escalations
+SET
+  reverted_at = NOW(),
+  reverted_by = $2
+WHERE id = $1 AND reverted_at IS NULL
+RETURNING *;
+
+-- Shift trust by a delta, never below zero
+-- name: AdjustUserTrust :one
+UPDATE users
+SET trust_level = GREATEST(0, trust_level + $2)
+WHERE id = $1
+RETURNING trust_level;
+
+-- name: LiftUserRestrictionsBySource :exec
+UPDATE user_restrictions
+SET lifted_at = NOW()
+WHERE user_id = $1 AND source = $2 AND lifted_at IS NULL;
@@ -0,0 +1,54 @@
+-- Messages around a point in a conversation, oldest first
+-- name: ListMessageContext :many
+SELECT * FROM (
+  (SELECT * FROM messages
+   WHERE ((sender_id = sqlc.arg('user_a') AND receiver_id = sqlc.arg('user_b'))
+      OR (sender_id = sqlc.arg('user_b') AND receiver_id = sqlc.arg('user_a')))
+     AND created_at <= sqlc.arg('around')
+   ORDER BY created_at DESC
+   LIMIT sqlc.arg('context_size')::int + 1)
+  UNION ALL
+  (SELECT * FROM messages
+   WHERE ((sender_id = sqlc.arg('user_a') AND receiver_id = sqlc.arg('user_b'))
+      OR (sender_id = sqlc.arg('user_b') AND receiver_id = sqlc.arg('user_a')))
+     AND created_at > sqlc.arg('around')
+   ORDER BY created_at ASC
+   LIMIT sqlc.arg('context_size')::int)
+) m
+ORDER BY created_at;
+
+-- name: CreateReportEvidence :one
+INSERT INTO report_evidence (
+  report_id,
+  message_id,
+  sender_id,
+  receiver_id,
+  content,
+  media_type,
+  original_media_url,
+  media_path,
+  is_reported,
+  sent_at,
+  expires_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+) RETURNING *;
+
+-- name: ListReportEvidence :many
+SELECT * FROM report_evidence
+WHERE report_id = $1
+ORDER BY sent_at;
+
+-- name: GetReportEvidence :one
+SELECT * FROM report_evidence
+WHERE id = $1 AND report_id = $2;
+
+-- Evidence past retention, kept while its case is still open. Returns stored media to remove.
+-- name: DeleteExpiredReportEvidence :many
+DELETE FROM report_evidence e
+WHERE e.expires_at < NOW()
+  AND NOT EXISTS (
+    SELECT 1 FROM reports r
+    WHERE r.id = e.report_id AND r.status IN ('open', 'in_review')
+  )
+RETURNING e.media_path;
@@ -0,0 +1,27 @@
+-- Per-viewer inputs for feed ranking, as of the time the feed is ranked at.
+-- Engagement, connections and stories after as_of are left out, so every page
+-- of a feed ranks on the same signals. Visibility is the caller's job.
+-- name: ListFeedRankingSignals :many
+SELECT s.id AS story_id,
+       s.user_id AS author_id,
+       s.created_at,
+       ST_Distance(s.geom::geography, ST_MakePoint(@lng::float8, @lat::float8)::geography)::float8 AS distance_meters,
+       COALESCE(u.boost_expires_at > @as_of::timestamptz, false)::bool AS is_boosted,
+       u.is_premium,
+       EXISTS (
+         SELECT 1 FROM connections c
+         WHERE (c.requester_id = @viewer_id AND c.target_id = s.user_id OR c.requester_id = s.user_id AND c.target_id = @viewer_id)
+           AND c.status = 'accepted'
+           AND c.updated_at <= @as_of
+       ) AS is_connection,
+       -- The viewer's views and reactions on the author's other stories
+       (SELECT COUNT(*) FROM story_views sv JOIN stories os ON os.id = sv.story_id
+        WHERE sv.user_id = @viewer_id AND os.user_id = s.user_id AND os.id <> s.id AND sv.viewed_at <= @as_of)
+       + (SELECT COUNT(*) FROM story_reactions sr JOIN stories os ON os.id = sr.story_id
+        WHERE sr.user_id = @viewer_id AND os.user_id = s.user_id AND os.id <> s.id AND sr.created_at <= @as_of) AS viewer_interactions,
+       (SELECT COUNT(*) FROM story_views sv WHERE sv.story_id = s.id AND sv.viewed_at <= @as_of) AS view_count,
+       (SELECT COUNT(*) FROM story_reactions sr WHERE sr.story_id = s.id AND sr.created_at <= @as_of) AS reaction_count
+FROM stories s
+JOIN users u ON u.id = s.user_id
+WHERE s.id = ANY(@story_ids::uuid[])
+  AND s.created_at <= @as_of;
@@ -0,0 +1,51 @@
+-- name: CreateHiddenWord :one
+INSERT INTO hidden_words (
+  user_id,
+  phrase
+) VALUES (
+  $1, $2
+) RETURNING *;
+
+-- name: ListHiddenWords :many
+SELECT * FROM hidden_words
+WHERE user_id = $1
+ORDER BY created_at DESC;
+
+-- name: ListHiddenPhrases :many
+SELECT phrase FROM hidden_words
+WHERE user_id = $1;
+
+-- name: DeleteHiddenWord :one
+DELETE FROM hidden_words
+WHERE id = $1 AND user_id = $2
+RETURNING *;
+
+-- Moves an incoming message to the receiver's hidden requests
+-- name: HideMessage :exec
+INSERT INTO hidden_messages (
+  message_id,
+  user_id
+) VALUES (
+  $1, $2
+)
+ON CONFLICT (message_id, user_id) DO NOTHING;
+
+-- Moves a hidden message back into its conversation
+-- name: UnhideMessage :one
+DELETE FROM hidden_messages
+WHERE message_id = $1 AND user_id = $2
+RETURNING message_id;
+
+-- name: ListHiddenMessages :many
+SELECT m.id, m.sender_id, m.receiver_id, m.content, m.is_read, m.created_at, m.read_at, m.expires_at, m.media_url, m.media_type,
+       u.username AS sender_username, u.avatar_url AS sender_avatar_url
+FROM hidden_messages hm
+JOIN messages m ON m.id = hm.message_id
+JOIN users u ON u.id = m.sender_id
+WHERE hm.user_id = $1
+  AND (m.expires_at IS NULL OR m.expires_at > NOW())
+  AND NOT EXISTS (
+    SELECT 1 FROM content_flags cf
+    WHERE cf.content_type = 'message' AND cf.content_id = m.id AND cf.status = 'pending'
+  )
+ORDER BY m.created_at DESC;
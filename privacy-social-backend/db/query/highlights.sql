@@ -0,0 +1,186 @@
+-- name: CreateHighlight :one
+INSERT INTO highlights (
+  user_id,
+  title,
+  cover_archive_id,
+  audience,
+  position
+) VALUES (
+  $1, $2, $3, $4,
+  (SELECT COALESCE(MAX(position) + 1, 0) FROM highlights WHERE user_id = $1)
+) RETURNING *;
+
+-- name: UpdateHighlight :one
+UPDATE highlights
+SET
+  title = COALESCE(sqlc.narg('title'), title),
+  cover_archive_id = COALESCE(sqlc.narg('cover_archive_id'), cover_archive_id),
+  audience = COALESCE(sqlc.narg('audience'), audience),
+  updated_at = NOW()
+WHERE id = @id AND user_id = @user_id
+RETURNING *;
+
+-- name: DeleteHighlight :one
+DELETE FROM highlights
+WHERE id = $1 AND user_id = $2
+RETURNING id;
+
+-- A user's highlights as the viewer may see them. The cover falls back to the first item.
+-- Archives of stories held for review or removed by moderators don't count.
+-- name: ListProfileHighlights :many
+SELECT h.id, h.title, h.audience, h.position,
+       COALESCE(
+         (SELECT a.media_url FROM archived_stories a
+          WHERE a.id = h.cover_archive_id
+            AND NOT EXISTS (
+              SELECT 1 FROM content_flags cf
+              WHERE cf.content_type = 'story' AND cf.content_id = a.story_id AND cf.status IN ('pending', 'removed')
+            )),
+         (SELECT a.media_url FROM highlight_items hi
+          JOIN archived_stories a ON a.id = hi.archive_id
+          WHERE hi.highlight_id = h.id
+            AND NOT EXISTS (
+              SELECT 1 FROM content_flags cf
+              WHERE cf.content_type = 'story' AND cf.content_id = a.story_id AND cf.status IN ('pending', 'removed')
+            )
+          ORDER BY hi.position, hi.added_at
+          LIMIT 1),
+         ''
+       )::text AS cover_url,
+       (SELECT COUNT(*) FROM highlight_items hi
+        JOIN archived_stories a ON a.id = hi.archive_id
+        WHERE hi.highlight_id = h.id
+          AND NOT EXISTS (
+            SELECT 1 FROM content_flags cf
+            WHERE cf.content_type = 'story' AND cf.content_id = a.story_id AND cf.status IN ('pending', 'removed')
+          )) AS item_count
+FROM highlights h
+JOIN users u ON u.id = h.user_id
+WHERE h.user_id = $1
+  AND (
+    h.user_id = $2
+    OR (
+      u.is_shadow_banned = false
+      AND NOT EXISTS (
+        SELECT 1 FROM blocked_users bu
+        WHERE (bu.blocker_id = $2 AND bu.blocked_id = h.user_id)
+           OR (bu.blocker_id = h.user_id AND bu.blocked_id = $2)
+      )
+      AND NOT EXISTS (
+        SELECT 1 FROM story_hidden_from shf
+        WHERE shf.user_id = h.user_id AND shf.hidden_user_id = $2
+      )
+      -- Profile visibility: public, connections, or private (owner only)
+      AND (
+        COALESCE(u.profile_visibility, 'public') = 'public'
+        OR (u.profile_visibility = 'connections' AND EXISTS (
+          SELECT 1 FROM connections cn
+          WHERE (cn.requester_id = $2 AND cn.target_id = h.user_id OR cn.requester_id = h.user_id AND cn.target_id = $2)
+            AND cn.status = 'accepted'
+        ))
+      )
+      -- Highlight audience
+      AND (
+        h.audience = 'public'
+        OR (h.audience = 'connections' AND EXISTS (
+          SELECT 1 FROM connections cn
+          WHERE (cn.requester_id = $2 AND cn.target_id = h.user_id OR cn.requester_id = h.user_id AND cn.target_id = $2)
+            AND cn.status = 'accepted'
+        ))
+        OR (h.audience = 'close_friends' AND EXISTS (
+          SELECT 1 FROM close_friends clf
+          WHERE clf.user_id = h.user_id AND clf.friend_id = $2
+        ))
+      )
+    )
+  )
+ORDER BY h.position, h.created_at;
+
+-- The highlight, if the viewer may see it
+-- name: GetHighlightForViewer :one
+SELECT h.id, h.user_id, h.title, h.cover_archive_id, h.audience, h.position, h.created_at, h.updated_at
+FROM highlights h
+JOIN users u ON u.id = h.user_id
+WHERE h.id = $1
+  AND (
+    h.user_id = $2
+    OR (
+      u.is_shadow_banned = false
+      AND NOT EXISTS (
+        SELECT 1 FROM blocked_users bu
+        WHERE (bu.blocker_id = $2 AND bu.blocked_id = h.user_id)
+           OR (bu.blocker_id = h.user_id AND bu.blocked_id = $2)
+      )
+      AND NOT EXISTS (
+        SELECT 1 FROM story_hidden_from shf
+        WHERE shf.user_id = h.user_id AND shf.hidden_user_id = $2
+      )
+      -- Profile visibility: public, connections, or private (owner only)
+      AND (
+        COALESCE(u.profile_visibility, 'public') = 'public'
+        OR (u.profile_visibility = 'connections' AND EXISTS (
+          SELECT 1 FROM connections cn
+          WHERE (cn.requester_id = $2 AND cn.target_id = h.user_id OR cn.requester_id = h.user_id AND cn.target_id = $2)
+            AND cn.status = 'accepted'
+        ))
+      )
+      -- Highlight audience
+      AND (
+        h.audience = 'public'
+        OR (h.audience = 'connections' AND EXISTS (
+          SELECT 1 FROM connections cn
+          WHERE (cn.requester_id = $2 AND cn.target_id = h.user_id OR cn.requester_id = h.user_id AND cn.target_id = $2)
+            AND cn.status = 'accepted'
+        ))
+        OR (h.audience = 'close_friends' AND EXISTS (
+          SELECT 1 FROM close_friends clf
+          WHERE clf.user_id = h.user_id AND clf.friend_id = $2
+        ))
+      )
+    )
+  );
+
+-- name: ListHighlightItems :many
+SELECT a.id, a.media_url, a.media_type, a.caption, a.original_created_at, hi.position
+FROM highlight_items hi
+JOIN archived_stories a ON a.id = hi.archive_id
+WHERE hi.highlight_id = $1
+  -- Moderation: stories held for review or removed stay out of highlights
+  AND NOT EXISTS (
+    SELECT 1 FROM content_flags cf
+    WHERE cf.content_type = 'story' AND cf.content_id = a.story_id AND cf.status IN ('pending', 'removed')
+  )
+ORDER BY hi.position, hi.added_at;
+
+-- Adds one of the owner's archived stories to the end of their highlight.
+-- No row means the highlight or the archived story isn't theirs.
+-- name: AddHighlightItem :one
+INSERT INTO highlight_items (highlight_id, archive_id, position)
+SELECT h.id, a.id,
+       (SELECT COALESCE(MAX(position) + 1, 0) FROM highlight_items WHERE highlight_id = h.id)
+FROM highlights h
+JOIN archived_stories a ON a.user_id = h.user_id
+WHERE h.id = $1 AND a.id = $2 AND h.user_id = $3
+ON CONFLICT (highlight_id, archive_id) DO UPDATE SET position = highlight_items.position
+RETURNING archive_id;
+
+-- name: RemoveHighlightItem :exec
+DELETE FROM highlight_items hi
+USING highlights h
+WHERE hi.highlight_id = h.id
+  AND hi.highlight_id = $1 AND hi.archive_id = $2 AND h.user_id = $3;
+
+-- Positions follow the order of the ids; ids that aren't the user's are ignored
+-- name: ReorderHighlights :exec
+UPDATE highlights h
+SET position = o.ord::int, updated_at = NOW()
+FROM unnest($2::uuid[]) WITH ORDINALITY AS o(id, ord)
+WHERE h.id = o.id AND h.user_id = $1;
+
+-- name: ReorderHighlightItems :exec
+UPDATE highlight_items hi
+SET position = o.ord::int
+FROM unnest($3::uuid[]) WITH ORDINALITY AS o(id, ord), highlights h
+WHERE hi.archive_id = o.id
+  AND hi.highlight_id = h.id
+  AND h.id = $1 AND h.user_id = $2;
@@ -0,0 +1,40 @@
+-- name: CreateMediaHash :exec
+INSERT INTO media_hashes (
+  media_url,
+  uploader_id,
+  dhash,
+  phash
+) VALUES (
+  $1, $2, $3, $4
+) ON CONFLICT (media_url) DO NOTHING;
+
+-- name: GetMediaHash :one
+SELECT * FROM media_hashes
+WHERE media_url = $1;
+
+-- name: AddMediaBlocklistEntry :one
+INSERT INTO media_blocklist (
+  dhash,
+  phash,
+  reason,
+  source_story_id,
+  added_by
+) VALUES (
+  $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- All hashes, compared by Hamming distance in the application
+-- name: ListMediaBlocklistHashes :many
+SELECT id, dhash, phash FROM media_blocklist;
+
+-- Admin: List blocklist entries
+-- name: ListMediaBlocklist :many
+SELECT * FROM media_blocklist
+ORDER BY created_at DESC
+LIMIT $1 OFFSET $2;
+
+-- Admin: Remove a blocklist entry
+-- name: DeleteMediaBlocklistEntry :one
+DELETE FROM media_blocklist
+WHERE id = $1
+RETURNING *;
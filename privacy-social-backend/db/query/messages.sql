@@ -30,6 +30,16 @@ FROM messages m
 WHERE ((m.sender_id = $1 AND m.receiver_id = $2)
    OR (m.sender_id = $2 AND m.receiver_id = $1))
    AND (m.expires_at IS NULL OR m.expires_at > NOW())
+   -- Moderation: messages held for review are only visible to their sender
+   AND (m.sender_id = $1 OR NOT EXISTS (
+     SELECT 1 FROM content_flags cf
+     WHERE cf.content_type = 'message' AND cf.content_id = m.id AND cf.status = 'pending'
+   ))
+   -- Hidden words: matching incoming messages are listed under hidden requests instead
+   AND NOT EXISTS (
+     SELECT 1 FROM hidden_messages hm
+     WHERE hm.message_id = m.id AND hm.user_id = $1
+   )
 ORDER BY m.created_at ASC;
 
 -- name: DeleteOldMessages :exec
@@ -91,7 +101,15 @@ ORDER BY mr.created_at ASC;
 
 -- name: GetUnreadMessageCount :one
 SELECT COUNT(*) FROM messages
-WHERE receiver_id = $1 AND read_at IS NULL;
+WHERE receiver_id = $1 AND read_at IS NULL
+AND NOT EXISTS (
+  SELECT 1 FROM content_flags cf
+  WHERE cf.content_type = 'message' AND cf.content_id = messages.id AND cf.status = 'pending'
+)
+AND NOT EXISTS (
+  SELECT 1 FROM hidden_messages hm
+  WHERE hm.message_id = messages.id AND hm.user_id = $1
+);
 
 -- name: GetConversationList :many
 WITH conversation_partners AS (
@@ -121,6 +139,14 @@ latest_messages AS (
   FROM messages m
   WHERE (m.sender_id = $1 OR m.receiver_id = $1)
     AND (m.expires_at IS NULL OR m.expires_at > NOW())
+    AND (m.sender_id = $1 OR NOT EXISTS (
+      SELECT 1 FROM content_flags cf
+      WHERE cf.content_type = 'message' AND cf.content_id = m.id AND cf.status = 'pending'
+    ))
+    AND NOT EXISTS (
+      SELECT 1 FROM hidden_messages hm
+      WHERE hm.message_id = m.id AND hm.user_id = $1
+    )
   ORDER BY 
     CASE 
       WHEN m.sender_id = $1 THEN m.receiver_id
@@ -143,11 +169,25 @@ SELECT
        AND m2.receiver_id = $1 
        AND m2.read_at IS NULL
        AND (m2.expires_at IS NULL OR m2.expires_at > NOW())
+       AND NOT EXISTS (
+         SELECT 1 FROM content_flags cf
+         WHERE cf.content_type = 'message' AND cf.content_id = m2.id AND cf.status = 'pending'
+       )
+       AND NOT EXISTS (
+         SELECT 1 FROM hidden_messages hm
+         WHERE hm.message_id = m2.id AND hm.user_id = $1
+       )
     ), 0
   ) as unread_count
 FROM conversation_partners cp
 JOIN users u ON u.id = cp.partner_id
 JOIN latest_messages lm ON lm.partner_id = cp.partner_id
+-- Block Logic: conversations with blocked users are hidden until unblocked
+WHERE NOT EXISTS (
+  SELECT 1 FROM blocked_users bu
+  WHERE (bu.blocker_id = $1 AND bu.blocked_id = u.id)
+     OR (bu.blocker_id = u.id AND bu.blocked_id = $1)
+)
 ORDER BY lm.last_message_at DESC;
 
 -- name: DeleteConversation :exec
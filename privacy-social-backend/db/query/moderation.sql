@@ -0,0 +1,63 @@
+-- name: CreateModerationAction :one
+INSERT INTO moderation_actions (
+  user_id,
+  action,
+  source,
+  reason,
+  evidence,
+  actor_id
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: GetLatestModerationAction :one
+SELECT * FROM moderation_actions
+WHERE user_id = $1 AND action = $2
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: ListModerationActions :many
+SELECT * FROM moderation_actions
+WHERE user_id = $1
+ORDER BY created_at DESC;
+
+-- name: CreateBanAppeal :one
+INSERT INTO ban_appeals (
+  user_id,
+  moderation_action_id,
+  message
+) VALUES (
+  $1, $2, $3
+) RETURNING *;
+
+-- name: GetBanAppeal :one
+SELECT * FROM ban_appeals
+WHERE id = $1;
+
+-- name: GetLatestBanAppeal :one
+SELECT * FROM ban_appeals
+WHERE user_id = $1
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- Admin: Appeal queue, oldest first
+-- name: ListBanAppeals :many
+SELECT a.*,
+  u.username,
+  u.is_shadow_banned
+FROM ban_appeals a
+JOIN users u ON a.user_id = u.id
+WHERE a.status = $1
+ORDER BY a.created_at ASC
+LIMIT $2 OFFSET $3;
+
+-- Admin: Decide a pending appeal
+-- name: DecideBanAppeal :one
+UPDATE ban_appeals
+SET
+  status = $2,
+  reviewer_id = $3,
+  decision_note = $4,
+  decided_at = NOW()
+WHERE id = $1 AND status = 'pending'
+RETURNING *;
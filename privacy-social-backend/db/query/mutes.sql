@@ -0,0 +1,43 @@
+-- Creates the mute or replaces its scope and expiry
+-- name: UpsertUserMute :one
+INSERT INTO user_mutes (
+  muter_id,
+  muted_id,
+  mute_stories,
+  mute_messages,
+  expires_at
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+ON CONFLICT (muter_id, muted_id) DO UPDATE
+SET mute_stories = EXCLUDED.mute_stories,
+    mute_messages = EXCLUDED.mute_messages,
+    expires_at = EXCLUDED.expires_at,
+    created_at = NOW()
+RETURNING *;
+
+-- name: DeleteUserMute :exec
+DELETE FROM user_mutes
+WHERE muter_id = $1 AND muted_id = $2;
+
+-- name: ListUserMutes :many
+SELECT m.id, m.muted_id, m.mute_stories, m.mute_messages, m.expires_at, m.created_at,
+       u.username, u.avatar_url
+FROM user_mutes m
+JOIN users u ON u.id = m.muted_id
+WHERE m.muter_id = $1
+  AND (m.expires_at IS NULL OR m.expires_at > NOW())
+ORDER BY m.created_at DESC;
+
+-- Whether muter has silenced messages from muted
+-- name: IsConversationMuted :one
+SELECT EXISTS (
+  SELECT 1 FROM user_mutes
+  WHERE muter_id = $1 AND muted_id = $2
+    AND mute_messages = true
+    AND (expires_at IS NULL OR expires_at > NOW())
+);
+
+-- name: DeleteExpiredUserMutes :exec
+DELETE FROM user_mutes
+WHERE expires_at IS NOT NULL AND expires_at < NOW();
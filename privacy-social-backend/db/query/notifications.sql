@@ -12,8 +12,14 @@ INSERT INTO notifications (
 ) RETURNING *;
 
 -- name: ListNotifications :many
-SELECT * FROM notifications
+SELECT * FROM notifications n
 WHERE user_id = $1
+  -- Block Logic: hide notifications about blocked users until unblocked
+  AND NOT EXISTS (
+    SELECT 1 FROM blocked_users bu
+    WHERE (bu.blocker_id = $1 AND bu.blocked_id = n.related_user_id)
+       OR (bu.blocker_id = n.related_user_id AND bu.blocked_id = $1)
+  )
 ORDER BY created_at DESC
 LIMIT $2 OFFSET $3;
 
@@ -29,8 +35,14 @@ SET is_read = true
 WHERE user_id = $1 AND is_read = false;
 
 -- name: CountUnreadNotifications :one
-SELECT COUNT(*) FROM notifications
-WHERE user_id = $1 AND is_read = false;
+SELECT COUNT(*) FROM notifications n
+WHERE user_id = $1 AND is_read = false
+  -- Block Logic: hide notifications about blocked users until unblocked
+  AND NOT EXISTS (
+    SELECT 1 FROM blocked_users bu
+    WHERE (bu.blocker_id = $1 AND bu.blocked_id = n.related_user_id)
+       OR (bu.blocker_id = n.related_user_id AND bu.blocked_id = $1)
+  );
 
 -- name: DeleteOldNotifications :exec
 -- Delete notifications older than 30 days
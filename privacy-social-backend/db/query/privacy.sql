@@ -3,13 +3,17 @@ SELECT * FROM privacy_settings WHERE user_id = $1;
 
 -- name: UpsertPrivacySettings :one
 INSERT INTO privacy_settings (
-    user_id, who_can_message, who_can_see_stories, show_location
+    user_id, who_can_message, who_can_see_stories, show_location, auto_archive, story_replies
 ) VALUES (
-    $1, $2, $3, $4
+    $1, $2, $3, $4, COALESCE(sqlc.narg('auto_archive')::boolean, false),
+    COALESCE(sqlc.narg('story_replies')::varchar, 'connections')
 ) ON CONFLICT (user_id) DO UPDATE
 SET 
     who_can_message = EXCLUDED.who_can_message,
     who_can_see_stories = EXCLUDED.who_can_see_stories,
     show_location = EXCLUDED.show_location,
+    -- Left unchanged when omitted
+    auto_archive = COALESCE(sqlc.narg('auto_archive')::boolean, privacy_settings.auto_archive),
+    story_replies = COALESCE(sqlc.narg('story_replies')::varchar, privacy_settings.story_replies),
     updated_at = NOW()
 RETURNING *;
@@ -4,26 +4,94 @@ INSERT INTO reports (
   target_user_id,
   target_story_id,
   reason,
-  description
+  description,
+  target_type,
+  target_message_id
 ) VALUES (
-  $1, $2, $3, $4, $5
+  $1, $2, $3, $4, $5, $6, $7
 ) RETURNING *;
 
--- Admin: List all reports
+-- Automatic report without a human reporter
+-- name: CreateSystemReport :one
+INSERT INTO reports (
+  target_user_id,
+  reason,
+  description,
+  source
+) VALUES (
+  $1, $2, $3, 'system'
+) RETURNING *;
+
+-- name: GetReport :one
+SELECT * FROM reports
+WHERE id = $1 LIMIT 1;
+
+-- Admin: Report queue with optional filters, most urgent first
 -- name: ListReports :many
-SELECT r.*, 
+SELECT r.*,
   u1.username as reporter_username,
-  u2.username as target_username
+  u2.username as target_username,
+  u3.username as assignee_username
 FROM reports r
 LEFT JOIN users u1 ON r.reporter_id = u1.id
 LEFT JOIN users u2 ON r.target_user_id = u2.id
-WHERE is_resolved = $1
-ORDER BY r.created_at DESC
-LIMIT $2 OFFSET $3;
+LEFT JOIN users u3 ON r.assignee_id = u3.id
+WHERE (sqlc.narg('status')::text IS NULL OR r.status = sqlc.narg('status'))
+  AND (sqlc.narg('reason')::report_reason IS NULL OR r.reason = sqlc.narg('reason'))
+  AND (sqlc.narg('target_type')::text IS NULL OR r.target_type = sqlc.narg('target_type'))
+  AND (sqlc.narg('assignee_id')::uuid IS NULL OR r.assignee_id = sqlc.narg('assignee_id'))
+  AND (NOT sqlc.arg('unassigned')::boolean OR r.assignee_id IS NULL)
+  AND (sqlc.narg('created_before')::timestamptz IS NULL OR r.created_at < sqlc.narg('created_before'))
+  AND (sqlc.narg('created_after')::timestamptz IS NULL OR r.created_at >= sqlc.narg('created_after'))
+ORDER BY CASE r.priority WHEN 'urgent' THEN 0 WHEN 'high' THEN 1 WHEN 'normal' THEN 2 ELSE 3 END,
+  r.created_at DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- Admin: Change status, priority or assignee of an open case
+-- name: UpdateReportTriage :one
+UPDATE reports
+SET status = COALESCE(sqlc.narg('status'), status),
+  priority = COALESCE(sqlc.narg('priority'), priority),
+  assignee_id = CASE WHEN sqlc.arg('clear_assignee')::boolean THEN NULL
+    ELSE COALESCE(sqlc.narg('assignee_id'), assignee_id) END,
+  updated_at = NOW()
+WHERE id = sqlc.arg('id') AND status IN ('open', 'in_review')
+RETURNING *;
 
--- Admin: Resolve report
+-- Admin: Close a case as actioned or dismissed
 -- name: ResolveReport :one
 UPDATE reports
-SET is_resolved = true
-WHERE id = $1
+SET status = $2,
+  is_resolved = true,
+  closed_at = NOW(),
+  updated_at = NOW()
+WHERE id = $1 AND status IN ('open', 'in_review')
 RETURNING *;
+
+-- name: CreateReportNote :one
+INSERT INTO report_notes (
+  report_id,
+  author_id,
+  body
+) VALUES (
+  $1, $2, $3
+) RETURNING *;
+
+-- name: ListReportNotes :many
+SELECT * FROM report_notes
+WHERE report_id = $1
+ORDER BY created_at;
+
+-- name: CreateReportOutcome :one
+INSERT INTO report_outcomes (
+  report_id,
+  action,
+  actor_id
+) VALUES (
+  $1, $2, $3
+) RETURNING *;
+
+-- name: ListReportOutcomes :many
+SELECT * FROM report_outcomes
+WHERE report_id = $1
+ORDER BY created_at;
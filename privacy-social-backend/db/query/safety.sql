@@ -0,0 +1,44 @@
+-- name: CreateLocationStrike :one
+INSERT INTO location_strikes (
+  user_id,
+  score,
+  signals,
+  action,
+  geohash
+) VALUES (
+  $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- Strikes issued before the user was last unbanned (e.g. an approved appeal) no longer count
+-- name: CountLocationStrikesSince :one
+SELECT COUNT(*) FROM location_strikes ls
+WHERE ls.user_id = $1 AND ls.created_at > sqlc.arg(since)
+AND ls.created_at > COALESCE((
+    SELECT MAX(ma.created_at) FROM moderation_actions ma
+    WHERE ma.user_id = ls.user_id AND ma.action = 'unban'
+), '-infinity'::timestamptz);
+
+-- name: ListLocationStrikes :many
+SELECT * FROM location_strikes
+WHERE user_id = $1
+ORDER BY created_at DESC
+LIMIT $2;
+
+-- name: CreateUserRestriction :one
+INSERT INTO user_restrictions (
+  user_id,
+  kind,
+  source,
+  reason,
+  expires_at
+) VALUES (
+  $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: HasActiveRestriction :one
+SELECT EXISTS (
+    SELECT 1 FROM user_restrictions
+    WHERE user_id = $1 AND kind = $2
+    AND lifted_at IS NULL
+    AND expires_at > now()
+);
@@ -0,0 +1,83 @@
+-- name: ScheduleStory :exec
+INSERT INTO scheduled_stories (
+  story_id,
+  publish_at
+) VALUES (
+  $1, $2
+);
+
+-- name: ListScheduledStories :many
+SELECT s.id, s.media_url, s.media_type, s.caption, s.visibility, s.is_anonymous, s.show_location,
+       ss.publish_at, s.expires_at, s.created_at,
+       ST_Y(s.geom::geometry) AS lat, ST_X(s.geom::geometry) AS lng
+FROM stories s
+JOIN scheduled_stories ss ON ss.story_id = s.id
+WHERE s.user_id = $1
+ORDER BY ss.publish_at;
+
+-- name: GetScheduledStory :one
+SELECT s.id, s.media_url, s.media_type, s.caption, s.visibility, s.is_anonymous, s.show_location,
+       ss.publish_at, s.expires_at, s.created_at,
+       ST_Y(s.geom::geometry) AS lat, ST_X(s.geom::geometry) AS lng
+FROM stories s
+JOIN scheduled_stories ss ON ss.story_id = s.id
+WHERE s.id = $1 AND s.user_id = $2;
+
+-- name: RescheduleStory :exec
+UPDATE scheduled_stories
+SET publish_at = $2
+WHERE story_id = $1;
+
+-- name: UpdateScheduledStory :one
+UPDATE stories s
+SET
+  caption = COALESCE(sqlc.narg('caption'), s.caption),
+  is_anonymous = COALESCE(sqlc.narg('is_anonymous'), s.is_anonymous),
+  show_location = COALESCE(sqlc.narg('show_location'), s.show_location),
+  -- Dropped when the location is hidden
+  place_label = CASE WHEN COALESCE(sqlc.narg('show_location'), s.show_location)
+    THEN COALESCE(sqlc.narg('place_label'), s.place_label) END,
+  visibility = COALESCE(sqlc.narg('visibility'), s.visibility),
+  expires_at = COALESCE(sqlc.narg('expires_at'), s.expires_at)
+FROM scheduled_stories ss
+WHERE ss.story_id = s.id
+  AND s.id = @id
+  AND s.user_id = @user_id
+RETURNING s.id, s.media_url, s.media_type, s.caption, s.visibility, s.is_anonymous, s.show_location,
+          ss.publish_at, s.expires_at, s.created_at,
+          ST_Y(s.geom::geometry) AS lat, ST_X(s.geom::geometry) AS lng;
+
+-- Deletes a story that hasn't been published yet
+-- name: CancelScheduledStory :one
+DELETE FROM stories s
+USING scheduled_stories ss
+WHERE ss.story_id = s.id
+  AND s.id = $1
+  AND s.user_id = $2
+RETURNING s.id;
+
+-- Publishes stories whose time has come. Expiry and created_at restart from now,
+-- keeping the lifetime chosen at scheduling time.
+-- name: PublishDueStories :many
+WITH due AS (
+  DELETE FROM scheduled_stories
+  WHERE story_id IN (
+    SELECT story_id FROM scheduled_stories
+    WHERE publish_at <= now()
+    ORDER BY publish_at
+    LIMIT $1
+    FOR UPDATE SKIP LOCKED
+  )
+  RETURNING story_id, publish_at
+)
+UPDATE stories s
+SET
+  expires_at = now() + (s.expires_at - due.publish_at),
+  created_at = now()
+FROM due
+WHERE s.id = due.story_id
+RETURNING s.id, s.user_id, s.geohash, s.caption,
+  EXISTS (
+    SELECT 1 FROM content_flags cf
+    WHERE cf.content_type = 'story' AND cf.content_id = s.id AND cf.status = 'pending'
+  ) AS held;
@@ -9,9 +9,11 @@ INSERT INTO stories (
   is_anonymous,
   show_location,
   is_premium,
-  expires_at
+  expires_at,
+  visibility,
+  place_label
 ) VALUES (
-  @user_id, @media_url, @media_type, @caption, @geohash, ST_SetSRID(ST_MakePoint(@lng::float8, @lat::float8), 4326), @is_anonymous, @show_location, @is_premium, @expires_at
+  @user_id, @media_url, @media_type, @caption, @geohash, ST_SetSRID(ST_MakePoint(@lng::float8, @lat::float8), 4326), @is_anonymous, @show_location, @is_premium, @expires_at, @visibility, @place_label
 ) RETURNING *, ST_Y(geom::geometry) as lat, ST_X(geom::geometry) as lng;
 
 -- name: GetStoryByID :one
@@ -23,41 +25,140 @@ UPDATE stories
 SET 
   caption = COALESCE(sqlc.narg('caption'), caption),
   is_anonymous = COALESCE(sqlc.narg('is_anonymous'), is_anonymous),
-  show_location = COALESCE(sqlc.narg('show_location'), show_location)
+  show_location = COALESCE(sqlc.narg('show_location'), show_location),
+  -- Dropped when the location is hidden
+  place_label = CASE WHEN COALESCE(sqlc.narg('show_location'), show_location)
+    THEN COALESCE(sqlc.narg('place_label'), place_label) END
 WHERE id = $1 
   AND user_id = $2
   AND created_at > NOW() - INTERVAL '15 minutes'
   AND expires_at > NOW()
 RETURNING *, ST_Y(geom::geometry) as lat, ST_X(geom::geometry) as lng;
 
--- name: GetStoriesWithinRadius :many
-SELECT s.*, u.username, u.avatar_url, u.is_premium,
-       ST_Y(s.geom::geometry) as lat, ST_X(s.geom::geometry) as lng
+-- name: ListFeedCandidates :many
+-- Stories near a point that some viewer may be shown. Nothing here depends
+-- on who is looking: the result is cached per area and shared between
+-- viewers, and ListFeedStories applies each viewer's rules to it.
+SELECT s.id
 FROM stories s
 JOIN users u ON s.user_id = u.id
-WHERE 
-    ST_DWithin(
+WHERE
+  ST_DWithin(
     s.geom::geography,
     ST_MakePoint(@lng::float8, @lat::float8)::geography,
     @radius_meters
   )
   AND s.expires_at > now()
-  -- Allow anonymous stories (handled in presentation)
-  -- AND (s.is_anonymous = false OR s.user_id = @user_id)
+  -- Moderation: hide content held for review
+  AND NOT EXISTS (
+    SELECT 1 FROM content_flags cf
+    WHERE cf.content_type = 'story' AND cf.content_id = s.id AND cf.status = 'pending'
+  )
+  -- Scheduled stories stay hidden until published
+  AND NOT EXISTS (SELECT 1 FROM scheduled_stories ss WHERE ss.story_id = s.id)
+  -- Anonymous stories are allowed (handled in presentation)
+  AND u.is_shadow_banned = false
+  AND u.is_ghost_mode = false
+-- Nearest distance bands first, so the cap drops the furthest stories, not nearby ones
+ORDER BY floor(ST_Distance(s.geom::geography, ST_MakePoint(@lng::float8, @lat::float8)::geography) / @band_meters::float8),
+         s.created_at DESC
+LIMIT @max_candidates;
+
+-- name: ListFeedViewerCandidates :many
+-- Stories near the viewer by the viewer, their connections and users who have
+-- them as a close friend. Merged with the shared candidates, so the cap on
+-- those never drops them. ListFeedStories applies the viewer's rules to both.
+SELECT s.id
+FROM stories s
+WHERE
+  ST_DWithin(
+    s.geom::geography,
+    ST_MakePoint(@lng::float8, @lat::float8)::geography,
+    @radius_meters
+  )
+  AND s.expires_at > now()
+  AND (
+    s.user_id = @viewer_id
+    OR EXISTS (
+      SELECT 1 FROM connections cn
+      WHERE (cn.requester_id = @viewer_id AND cn.target_id = s.user_id OR cn.requester_id = s.user_id AND cn.target_id = @viewer_id)
+        AND cn.status = 'accepted'
+    )
+    OR EXISTS (
+      SELECT 1 FROM close_friends clf
+      WHERE clf.user_id = s.user_id AND clf.friend_id = @viewer_id
+    )
+  )
+ORDER BY s.created_at DESC
+LIMIT @max_candidates;
+
+-- name: ListFeedStories :many
+-- The feed candidates one viewer may see, with their distance from the viewer.
+-- Expiry and moderation are checked again as the candidates may be cached.
+SELECT s.*, u.username, u.avatar_url, u.is_premium,
+       ST_Y(s.geom::geometry) as lat, ST_X(s.geom::geometry) as lng,
+       (SELECT COUNT(*) FROM story_items si WHERE si.story_id = s.id) AS item_count,
+       ST_Distance(s.geom::geography, ST_MakePoint(@lng::float8, @lat::float8)::geography)::float8 AS distance_meters
+FROM stories s
+JOIN users u ON s.user_id = u.id
+WHERE s.id = ANY(@story_ids::uuid[])
+  AND s.expires_at > now()
+  AND NOT EXISTS (
+    SELECT 1 FROM content_flags cf
+    WHERE cf.content_type = 'story' AND cf.content_id = s.id AND cf.status = 'pending'
+  )
+  AND NOT EXISTS (SELECT 1 FROM scheduled_stories ss WHERE ss.story_id = s.id)
   AND u.is_shadow_banned = false
   AND u.is_ghost_mode = false
-  -- Strict Streak Rule (DISABLED)
-  -- AND DATE(u.last_active_at) >= CURRENT_DATE - INTERVAL '1 day'
   -- Block Logic: Exclude if blocked by either party (using blocked_users table)
   AND NOT EXISTS (
     SELECT 1 FROM blocked_users bu 
-    WHERE (bu.blocker_id = @user_id AND bu.blocked_id = s.user_id)
-       OR (bu.blocker_id = s.user_id AND bu.blocked_id = @user_id)
+    WHERE (bu.blocker_id = @viewer_id AND bu.blocked_id = s.user_id)
+       OR (bu.blocker_id = s.user_id AND bu.blocked_id = @viewer_id)
+  )
+  -- Mutes: stories from authors the viewer muted, before the search radius is chosen
+  AND NOT EXISTS (
+    SELECT 1 FROM user_mutes um
+    WHERE um.muter_id = @viewer_id AND um.muted_id = s.user_id
+      AND um.mute_stories = true
+      AND (um.expires_at IS NULL OR um.expires_at > now())
+  )
+  -- Story visibility: connections-only and close friends audiences
+  AND (
+    s.user_id = @viewer_id
+    OR s.visibility = 'public'
+    OR (s.visibility = 'connections' AND EXISTS (
+      SELECT 1 FROM connections cn
+      WHERE (cn.requester_id = @viewer_id AND cn.target_id = s.user_id OR cn.requester_id = s.user_id AND cn.target_id = @viewer_id)
+        AND cn.status = 'accepted'
+    ))
+    OR (s.visibility = 'close_friends' AND EXISTS (
+      SELECT 1 FROM close_friends clf
+      WHERE clf.user_id = s.user_id AND clf.friend_id = @viewer_id
+    ))
+  )
+  -- Story audience: per-story allow/exclude lists and the author's hide-from list
+  AND (
+    s.user_id = @viewer_id
+    OR (
+      NOT EXISTS (
+        SELECT 1 FROM story_hidden_from shf
+        WHERE shf.user_id = s.user_id AND shf.hidden_user_id = @viewer_id
+      )
+      AND NOT EXISTS (
+        SELECT 1 FROM story_audience sa
+        WHERE sa.story_id = s.id AND sa.user_id = @viewer_id AND sa.rule = 'exclude'
+      )
+      AND (
+        NOT EXISTS (SELECT 1 FROM story_audience sa WHERE sa.story_id = s.id AND sa.rule = 'allow')
+        OR EXISTS (SELECT 1 FROM story_audience sa WHERE sa.story_id = s.id AND sa.user_id = @viewer_id AND sa.rule = 'allow')
+      )
+    )
   )
   -- Privacy Settings Logic --
   AND (
     -- Case 1: My own stories (always visible)
-    s.user_id = @user_id
+    s.user_id = @viewer_id
     OR
     (
       -- Case 2: User is NOT in Ghost Mode (using privacy_settings)
@@ -71,7 +172,7 @@ WHERE
           OR
           (ps.who_can_see_stories = 'connections' AND EXISTS (
              SELECT 1 FROM connections c 
-             WHERE (c.requester_id = @user_id AND c.target_id = s.user_id OR c.requester_id = s.user_id AND c.target_id = @user_id)
+             WHERE (c.requester_id = @viewer_id AND c.target_id = s.user_id OR c.requester_id = s.user_id AND c.target_id = @viewer_id)
              AND c.status = 'accepted'
           ))
         )
@@ -84,7 +185,7 @@ WHERE
       -- But simpler to rely on LEFT JOIN or EXISTS logic assuming rows exist.
     )
   )
-ORDER BY 
+ORDER BY
   (u.boost_expires_at > now()) DESC NULLS LAST,
   u.is_premium DESC,
   s.created_at DESC;
@@ -92,7 +193,8 @@ ORDER BY
 -- name: GetConnectionStories :many
 -- Get stories from connected users (not limited by radius)
 SELECT s.*, u.username, u.avatar_url, u.is_premium,
-       ST_Y(s.geom::geometry) as lat, ST_X(s.geom::geometry) as lng
+       ST_Y(s.geom::geometry) as lat, ST_X(s.geom::geometry) as lng,
+       (SELECT COUNT(*) FROM story_items si WHERE si.story_id = s.id) AS item_count
 FROM stories s
 JOIN users u ON s.user_id = u.id
 JOIN connections c ON 
@@ -101,6 +203,13 @@ JOIN connections c ON
 WHERE 
   c.status = 'accepted'
   AND s.expires_at > now()
+  -- Moderation: hide content held for review
+  AND NOT EXISTS (
+    SELECT 1 FROM content_flags cf
+    WHERE cf.content_type = 'story' AND cf.content_id = s.id AND cf.status = 'pending'
+  )
+  -- Scheduled stories stay hidden until published
+  AND NOT EXISTS (SELECT 1 FROM scheduled_stories ss WHERE ss.story_id = s.id)
   AND u.is_shadow_banned = false
   AND u.is_shadow_banned = false
   -- strict streak rule (DISABLED)
@@ -111,16 +220,63 @@ WHERE
     WHERE (bu.blocker_id = @user_id AND bu.blocked_id = s.user_id)
        OR (bu.blocker_id = s.user_id AND bu.blocked_id = @user_id)
   )
+  -- Mutes: stories from authors the viewer muted
+  AND NOT EXISTS (
+    SELECT 1 FROM user_mutes um
+    WHERE um.muter_id = @user_id AND um.muted_id = s.user_id
+      AND um.mute_stories = true
+      AND (um.expires_at IS NULL OR um.expires_at > now())
+  )
+  -- Story visibility: connections-only and close friends audiences
+  AND (
+    s.user_id = @user_id
+    OR s.visibility = 'public'
+    OR (s.visibility = 'connections' AND EXISTS (
+      SELECT 1 FROM connections cn
+      WHERE (cn.requester_id = @user_id AND cn.target_id = s.user_id OR cn.requester_id = s.user_id AND cn.target_id = @user_id)
+        AND cn.status = 'accepted'
+    ))
+    OR (s.visibility = 'close_friends' AND EXISTS (
+      SELECT 1 FROM close_friends clf
+      WHERE clf.user_id = s.user_id AND clf.friend_id = @user_id
+    ))
+  )
+  -- Story audience: per-story allow/exclude lists and the author's hide-from list
+  AND (
+    s.user_id = @user_id
+    OR (
+      NOT EXISTS (
+        SELECT 1 FROM story_hidden_from shf
+        WHERE shf.user_id = s.user_id AND shf.hidden_user_id = @user_id
+      )
+      AND NOT EXISTS (
+        SELECT 1 FROM story_audience sa
+        WHERE sa.story_id = s.id AND sa.user_id = @user_id AND sa.rule = 'exclude'
+      )
+      AND (
+        NOT EXISTS (SELECT 1 FROM story_audience sa WHERE sa.story_id = s.id AND sa.rule = 'allow')
+        OR EXISTS (SELECT 1 FROM story_audience sa WHERE sa.story_id = s.id AND sa.user_id = @user_id AND sa.rule = 'allow')
+      )
+    )
+  )
 ORDER BY s.created_at DESC;
 
 -- name: GetStoriesInBounds :many
 -- Get stories within a bounding box for map view
 SELECT s.*, u.username, u.avatar_url,
-       ST_Y(s.geom::geometry) as lat, ST_X(s.geom::geometry) as lng
+       ST_Y(s.geom::geometry) as lat, ST_X(s.geom::geometry) as lng,
+       (SELECT COUNT(*) FROM story_items si WHERE si.story_id = s.id) AS item_count
 FROM stories s
 JOIN users u ON s.user_id = u.id
 WHERE s.geom && ST_MakeEnvelope(@west::float8, @south::float8, @east::float8, @north::float8, 4326)
 AND s.expires_at > now()
+-- Moderation: hide content held for review
+AND NOT EXISTS (
+  SELECT 1 FROM content_flags cf
+  WHERE cf.content_type = 'story' AND cf.content_id = s.id AND cf.status = 'pending'
+)
+-- Scheduled stories stay hidden until published
+AND NOT EXISTS (SELECT 1 FROM scheduled_stories ss WHERE ss.story_id = s.id)
 AND u.is_shadow_banned = false
 AND u.is_ghost_mode = false
 -- AND DATE(u.last_active_at) >= CURRENT_DATE - INTERVAL '1 day'
@@ -129,6 +285,45 @@ AND NOT EXISTS (
     WHERE (bu.blocker_id = @current_user_id AND bu.blocked_id = s.user_id)
        OR (bu.blocker_id = s.user_id AND bu.blocked_id = @current_user_id)
 )
+-- Mutes: stories from authors the viewer muted
+AND NOT EXISTS (
+    SELECT 1 FROM user_mutes um
+    WHERE um.muter_id = @current_user_id AND um.muted_id = s.user_id
+      AND um.mute_stories = true
+      AND (um.expires_at IS NULL OR um.expires_at > now())
+)
+-- Story visibility: connections-only and close friends audiences
+AND (
+  s.user_id = @current_user_id
+  OR s.visibility = 'public'
+  OR (s.visibility = 'connections' AND EXISTS (
+    SELECT 1 FROM connections cn
+    WHERE (cn.requester_id = @current_user_id AND cn.target_id = s.user_id OR cn.requester_id = s.user_id AND cn.target_id = @current_user_id)
+      AND cn.status = 'accepted'
+  ))
+  OR (s.visibility = 'close_friends' AND EXISTS (
+    SELECT 1 FROM close_friends clf
+    WHERE clf.user_id = s.user_id AND clf.friend_id = @current_user_id
+  ))
+)
+-- Story audience: per-story allow/exclude lists and the author's hide-from list
+AND (
+  s.user_id = @current_user_id
+  OR (
+    NOT EXISTS (
+      SELECT 1 FROM story_hidden_from shf
+      WHERE shf.user_id = s.user_id AND shf.hidden_user_id = @current_user_id
+    )
+    AND NOT EXISTS (
+      SELECT 1 FROM story_audience sa
+      WHERE sa.story_id = s.id AND sa.user_id = @current_user_id AND sa.rule = 'exclude'
+    )
+    AND (
+      NOT EXISTS (SELECT 1 FROM story_audience sa WHERE sa.story_id = s.id AND sa.rule = 'allow')
+      OR EXISTS (SELECT 1 FROM story_audience sa WHERE sa.story_id = s.id AND sa.user_id = @current_user_id AND sa.rule = 'allow')
+    )
+  )
+)
 AND (
     s.user_id = @current_user_id
     OR
@@ -153,9 +348,27 @@ AND (
 ORDER BY s.created_at DESC
 LIMIT 100;
 
+-- Deletes stories expired before the cutoff. Stories of users with auto-archive
+-- on stay until AutoArchiveExpiredStories has copied them, unless moderators
+-- held or removed them.
 -- name: DeleteExpiredStories :exec
-DELETE FROM stories
-WHERE expires_at < now();
+DELETE FROM stories s
+WHERE s.expires_at < @expired_before
+  AND (
+    NOT EXISTS (
+      SELECT 1 FROM privacy_settings ps
+      WHERE ps.user_id = s.user_id AND ps.auto_archive = true
+    )
+    OR EXISTS (
+      SELECT 1 FROM archived_stories a
+      WHERE a.user_id = s.user_id AND a.story_id = s.id
+    )
+    -- Held or removed by moderators, so never archived
+    OR EXISTS (
+      SELECT 1 FROM content_flags cf
+      WHERE cf.content_type = 'story' AND cf.content_id = s.id AND cf.status IN ('pending', 'removed')
+    )
+  );
 
 -- Admin: Delete story
 -- name: DeleteStory :exec
@@ -183,4 +396,5 @@ SELECT EXISTS (
     SELECT 1 FROM stories 
     WHERE user_id = $1 
     AND expires_at > now()
+    AND NOT EXISTS (SELECT 1 FROM scheduled_stories ss WHERE ss.story_id = stories.id)
 );
@@ -0,0 +1,64 @@
+-- Copies a batch of stories expired before the cutoff, of users with auto-archive
+-- on, into their archive with engagement counts and sequence items. Run before
+-- DeleteExpiredStories with the same cutoff. Stories held or removed by
+-- moderators are never archived.
+-- name: AutoArchiveExpiredStories :many
+WITH archived AS (
+    INSERT INTO archived_stories (
+        user_id, story_id, media_url, media_type, caption,
+        geohash, geom, is_anonymous, show_location, original_created_at,
+        view_count, reaction_count, place_label
+    )
+    SELECT
+        s.user_id, s.id, s.media_url, s.media_type, s.caption,
+        s.geohash, s.geom, s.is_anonymous, s.show_location, s.created_at,
+        (SELECT COUNT(*) FROM story_views v WHERE v.story_id = s.id),
+        (SELECT COUNT(*) FROM story_reactions r WHERE r.story_id = s.id),
+        s.place_label
+    FROM stories s
+    JOIN privacy_settings ps ON ps.user_id = s.user_id AND ps.auto_archive = true
+    WHERE s.expires_at < @expired_before
+      AND NOT EXISTS (
+        SELECT 1 FROM archived_stories a
+        WHERE a.user_id = s.user_id AND a.story_id = s.id
+      )
+      -- Held or removed by moderators: left to expire, never archived
+      AND NOT EXISTS (
+        SELECT 1 FROM content_flags cf
+        WHERE cf.content_type = 'story' AND cf.content_id = s.id AND cf.status IN ('pending', 'removed')
+      )
+    ORDER BY s.expires_at
+    LIMIT @batch_size
+    ON CONFLICT (user_id, story_id) DO NOTHING
+    RETURNING id, story_id
+), archived_items AS (
+    INSERT INTO archived_story_items (archive_id, position, media_url, media_type, caption, duration_ms)
+    SELECT archived.id, si.position, si.media_url, si.media_type, si.caption, si.duration_ms
+    FROM archived
+    JOIN story_items si ON si.story_id = archived.story_id
+)
+SELECT story_id FROM archived;
+
+-- Notifies owners of stories expiring before the given time that would be lost:
+-- auto-archive off and not archived by hand. Each story is announced once.
+-- name: CreateStoryExpiryNotices :many
+INSERT INTO notifications (user_id, type, title, message, related_story_id)
+SELECT s.user_id, 'story_expiring', 'Your story expires soon',
+       'Archive it to keep it, or turn on auto-archive in privacy settings', s.id
+FROM stories s
+WHERE s.expires_at > now()
+  AND s.expires_at <= @expires_before
+  AND NOT EXISTS (
+    SELECT 1 FROM privacy_settings ps
+    WHERE ps.user_id = s.user_id AND ps.auto_archive = true
+  )
+  AND NOT EXISTS (
+    SELECT 1 FROM archived_stories a
+    WHERE a.user_id = s.user_id AND a.story_id = s.id
+  )
+  AND NOT EXISTS (SELECT 1 FROM scheduled_stories ss WHERE ss.story_id = s.id)
+  AND NOT EXISTS (
+    SELECT 1 FROM notifications n
+    WHERE n.type = 'story_expiring' AND n.related_story_id = s.id
+  )
+RETURNING related_story_id;
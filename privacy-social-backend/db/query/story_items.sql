@@ -0,0 +1,34 @@
+-- name: CreateStoryItem :one
+INSERT INTO story_items (
+  story_id,
+  position,
+  media_url,
+  media_type,
+  caption,
+  duration_ms
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: ListStoryItems :many
+SELECT * FROM story_items
+WHERE story_id = $1
+ORDER BY position;
+
+-- No row means the item isn't part of the story
+-- name: RecordStoryItemView :one
+INSERT INTO story_item_views (item_id, user_id)
+SELECT si.id, $3
+FROM story_items si
+WHERE si.id = $1 AND si.story_id = $2
+ON CONFLICT (item_id, user_id) DO UPDATE
+SET viewed_at = story_item_views.viewed_at
+RETURNING item_id;
+
+-- Viewers per item, in order, for the owner's drop-off view
+-- name: GetStoryItemStats :many
+SELECT si.id, si.position, si.media_type,
+       (SELECT COUNT(*) FROM story_item_views siv WHERE siv.item_id = si.id) AS view_count
+FROM story_items si
+WHERE si.story_id = $1
+ORDER BY si.position;
@@ -0,0 +1,31 @@
+-- name: CreateMessageStoryRef :one
+INSERT INTO message_story_refs (
+  message_id,
+  story_id,
+  story_owner_id,
+  media_type,
+  thumbnail_url,
+  caption,
+  story_created_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+) RETURNING *;
+
+-- name: ListMessageStoryRefs :many
+SELECT * FROM message_story_refs
+WHERE message_id = ANY(@message_ids::uuid[]);
+
+-- A live story reply between two unblocked users lets them read the
+-- conversation without being connected
+-- name: HasStoryReplyThread :one
+SELECT EXISTS (
+  SELECT 1 FROM messages m
+  JOIN message_story_refs r ON r.message_id = m.id
+  WHERE ((m.sender_id = $1 AND m.receiver_id = $2)
+     OR (m.sender_id = $2 AND m.receiver_id = $1))
+    AND (m.expires_at IS NULL OR m.expires_at > NOW())
+) AND NOT EXISTS (
+  SELECT 1 FROM blocked_users bu
+  WHERE (bu.blocker_id = $1 AND bu.blocked_id = $2)
+     OR (bu.blocker_id = $2 AND bu.blocked_id = $1)
+) AS has_thread;
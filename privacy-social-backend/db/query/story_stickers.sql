@@ -0,0 +1,66 @@
+-- name: CreateStorySticker :one
+INSERT INTO story_stickers (
+  story_id,
+  kind,
+  prompt,
+  options,
+  emoji
+) VALUES (
+  $1, $2, $3, $4, $5
+) RETURNING *;
+
+-- name: ListStoryStickers :many
+SELECT * FROM story_stickers
+WHERE story_id = $1
+ORDER BY created_at, id;
+
+-- name: GetStorySticker :one
+SELECT * FROM story_stickers
+WHERE id = $1 AND story_id = $2;
+
+-- No row means the viewer already responded
+-- name: CreateStickerResponse :one
+INSERT INTO story_sticker_responses (
+  sticker_id,
+  user_id,
+  option_index,
+  slider_value,
+  answer
+) VALUES (
+  $1, $2, $3, $4, $5
+) ON CONFLICT (sticker_id, user_id) DO NOTHING
+RETURNING *;
+
+-- name: GetStickerResponse :one
+SELECT * FROM story_sticker_responses
+WHERE sticker_id = $1 AND user_id = $2;
+
+-- Aggregate results: response count and, for sliders, the average
+-- name: GetStickerTally :one
+SELECT COUNT(*) AS response_count,
+       COALESCE(AVG(slider_value), 0)::float8 AS slider_average
+FROM story_sticker_responses
+WHERE sticker_id = $1;
+
+-- Votes per poll option; options without votes are left out
+-- name: ListPollVotes :many
+SELECT option_index::int AS option_index, COUNT(*) AS votes
+FROM story_sticker_responses
+WHERE sticker_id = $1 AND option_index IS NOT NULL
+GROUP BY option_index;
+
+-- Responses for the story owner, newest first. Users blocked either way are left out.
+-- name: ListStickerResponses :many
+SELECT r.sticker_id, r.user_id, r.option_index, r.slider_value, r.answer, r.created_at,
+       u.username, u.avatar_url
+FROM story_sticker_responses r
+JOIN story_stickers st ON st.id = r.sticker_id
+JOIN stories s ON s.id = st.story_id
+JOIN users u ON u.id = r.user_id
+WHERE r.sticker_id = $1
+  AND NOT EXISTS (
+    SELECT 1 FROM blocked_users bu
+    WHERE (bu.blocker_id = s.user_id AND bu.blocked_id = r.user_id)
+       OR (bu.blocker_id = r.user_id AND bu.blocked_id = s.user_id)
+  )
+ORDER BY r.created_at DESC;
@@ -0,0 +1,91 @@
+-- Whether the viewer is in the story's audience. Mirrors the visibility rules of the feed queries.
+-- name: CanViewStory :one
+SELECT EXISTS (
+  SELECT 1 FROM stories s
+  WHERE s.id = $1
+    AND (
+      s.user_id = $2
+      OR (
+        NOT EXISTS (SELECT 1 FROM scheduled_stories ss WHERE ss.story_id = s.id)
+        AND NOT EXISTS (
+          SELECT 1 FROM blocked_users bu
+          WHERE (bu.blocker_id = $2 AND bu.blocked_id = s.user_id)
+             OR (bu.blocker_id = s.user_id AND bu.blocked_id = $2)
+        )
+        AND (
+          s.visibility = 'public'
+          OR (s.visibility = 'connections' AND EXISTS (
+            SELECT 1 FROM connections cn
+            WHERE (cn.requester_id = $2 AND cn.target_id = s.user_id OR cn.requester_id = s.user_id AND cn.target_id = $2)
+              AND cn.status = 'accepted'
+          ))
+          OR (s.visibility = 'close_friends' AND EXISTS (
+            SELECT 1 FROM close_friends clf
+            WHERE clf.user_id = s.user_id AND clf.friend_id = $2
+          ))
+        )
+        AND NOT EXISTS (
+          SELECT 1 FROM story_hidden_from shf
+          WHERE shf.user_id = s.user_id AND shf.hidden_user_id = $2
+        )
+        AND NOT EXISTS (
+          SELECT 1 FROM story_audience sa
+          WHERE sa.story_id = s.id AND sa.user_id = $2 AND sa.rule = 'exclude'
+        )
+        AND (
+          NOT EXISTS (SELECT 1 FROM story_audience sa WHERE sa.story_id = s.id AND sa.rule = 'allow')
+          OR EXISTS (SELECT 1 FROM story_audience sa WHERE sa.story_id = s.id AND sa.user_id = $2 AND sa.rule = 'allow')
+        )
+        -- The owner's who_can_see_stories setting; no settings row means everyone
+        AND (
+          NOT EXISTS (SELECT 1 FROM privacy_settings ps WHERE ps.user_id = s.user_id)
+          OR EXISTS (
+            SELECT 1 FROM privacy_settings ps
+            WHERE ps.user_id = s.user_id
+            AND (
+              ps.who_can_see_stories = 'everyone'
+              OR (ps.who_can_see_stories = 'connections' AND EXISTS (
+                SELECT 1 FROM connections c
+                WHERE (c.requester_id = $2 AND c.target_id = s.user_id OR c.requester_id = s.user_id AND c.target_id = $2)
+                  AND c.status = 'accepted'
+              ))
+            )
+          )
+        )
+      )
+    )
+);
+
+-- name: SetStoryAudienceRule :exec
+INSERT INTO story_audience (
+  story_id,
+  user_id,
+  rule
+) VALUES (
+  $1, $2, $3
+)
+ON CONFLICT (story_id, user_id) DO UPDATE SET rule = EXCLUDED.rule;
+
+-- name: ListStoryAudience :many
+SELECT user_id, rule FROM story_audience
+WHERE story_id = $1;
+
+-- name: HideStoriesFrom :exec
+INSERT INTO story_hidden_from (
+  user_id,
+  hidden_user_id
+) VALUES (
+  $1, $2
+)
+ON CONFLICT (user_id, hidden_user_id) DO NOTHING;
+
+-- name: UnhideStoriesFrom :exec
+DELETE FROM story_hidden_from
+WHERE user_id = $1 AND hidden_user_id = $2;
+
+-- name: ListStoriesHiddenFrom :many
+SELECT u.id, u.username, u.full_name, u.avatar_url, h.created_at AS hidden_at
+FROM story_hidden_from h
+JOIN users u ON u.id = h.hidden_user_id
+WHERE h.user_id = $1
+ORDER BY u.username;
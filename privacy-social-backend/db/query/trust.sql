@@ -0,0 +1,32 @@
+-- Signals for the trust engine
+-- name: GetTrustInputs :one
+SELECT
+  u.trust_level,
+  u.created_at,
+  u.is_verified,
+  (SELECT COUNT(*) FROM connections c
+   WHERE (c.requester_id = u.id OR c.target_id = u.id) AND c.status = 'accepted') AS accepted_connections,
+  (SELECT COUNT(DISTINCT r.reporter_id) FROM reports r
+   LEFT JOIN stories s ON r.target_story_id = s.id
+   WHERE (r.target_user_id = u.id OR s.user_id = u.id)
+   AND r.created_at > NOW() - INTERVAL '90 days') AS reports_received,
+  (SELECT COUNT(*) FROM reports r
+   LEFT JOIN stories s ON r.target_story_id = s.id
+   WHERE (r.target_user_id = u.id OR s.user_id = u.id)
+   AND (r.status = 'actioned' OR EXISTS (
+     SELECT 1 FROM report_escalation_reports er
+     JOIN report_escalations e ON er.escalation_id = e.id
+     WHERE er.report_id = r.id AND e.reverted_at IS NULL))) AS reports_upheld,
+  (SELECT COUNT(*) FROM location_strikes ls
+   WHERE ls.user_id = u.id AND ls.created_at > NOW() - INTERVAL '30 days') AS safety_strikes,
+  (SELECT COALESCE(SUM(e.trust_penalty), 0) FROM report_escalations e
+   WHERE e.user_id = u.id AND e.reverted_at IS NULL)::bigint AS report_penalty
+FROM users u
+WHERE u.id = $1;
+
+-- Users to recompute trust for
+-- name: ListRecentlyActiveUserIDs :many
+SELECT id FROM users
+WHERE last_active_at > $1
+ORDER BY id
+LIMIT $2 OFFSET $3;
@@ -1,14 +1,18 @@
 package api
 
 import (
+	"database/sql"
 	"encoding/json"
 	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
 
 	"privacy-social-backend/internal/repository/db"
+	"privacy-social-backend/internal/service/mediahash"
+	"privacy-social-backend/internal/token"
 )
 
 const (
@@ -53,7 +57,8 @@ func (server *Server) listUsers(ctx *gin.Context) {
 // Admin: Ban/Unban User
 type banUserRequest struct {
 	UserID string `json:"user_id" binding:"required,uuid"`
-	Ban    bool   `json:"ban" binding:"required"`
+	Ban    *bool  `json:"ban" binding:"required"`
+	Reason string `json:"reason" binding:"required,min=3,max=500"`
 }
 
 func (server *Server) banUser(ctx *gin.Context) {
@@ -68,11 +73,46 @@ func (server *Server) banUser(ctx *gin.Context) {
 		return
 	}
 
-	user, err := server.store.BanUser(ctx, db.BanUserParams{
-		ID:             userID,
-		IsShadowBanned: req.Ban,
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	actorID := uuid.NullUUID{UUID: authPayload.UserID, Valid: true}
+
+	var user db.User
+	err := server.store.ExecTx(ctx, func(q *db.Queries) error {
+		before, err := q.GetUserByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		action := auditUserBan
+		if *req.Ban {
+			err = applyShadowBan(ctx, q, userID, moderationSourceAdmin, req.Reason, nil, actorID)
+		} else {
+			action = auditUserUnban
+			err = liftShadowBan(ctx, q, userID, moderationSourceAdmin, req.Reason, actorID)
+		}
+		if err != nil {
+			return err
+		}
+
+		user, err = q.GetUserByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		return writeAudit(ctx, q, auditEntry{
+			Action:     action,
+			TargetType: auditTargetUser,
+			TargetID:   userID,
+			Reason:     req.Reason,
+			Before:     userAuditSnapshot(before),
+			After:      userAuditSnapshot(user),
+		})
 	})
 	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
@@ -92,13 +132,41 @@ func (server *Server) deleteUser(ctx *gin.Context) {
 		return
 	}
 
+	var reason adminReasonQuery
+	if err := ctx.ShouldBindQuery(&reason); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
 	userID, ok := parseUUIDParam(ctx, req.UserID, "user_id")
 	if !ok {
 		return
 	}
 
-	err := server.store.DeleteUser(ctx, userID)
+	err := server.store.ExecTx(ctx, func(q *db.Queries) error {
+		before, err := q.GetUserByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		err = writeAudit(ctx, q, auditEntry{
+			Action:     auditUserDelete,
+			TargetType: auditTargetUser,
+			TargetID:   userID,
+			Reason:     reason.Reason,
+			Before:     userAuditSnapshot(before),
+		})
+		if err != nil {
+			return err
+		}
+
+		return q.DeleteUser(ctx, userID)
+	})
 	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
@@ -106,6 +174,35 @@ func (server *Server) deleteUser(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, gin.H{"message": "user deleted"})
 }
 
+// Admin: List fake GPS strikes for a user
+type listLocationStrikesRequest struct {
+	UserID string `uri:"id" binding:"required,uuid"`
+}
+
+func (server *Server) listLocationStrikes(ctx *gin.Context) {
+	var req listLocationStrikesRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	userID, ok := parseUUIDParam(ctx, req.UserID, "user_id")
+	if !ok {
+		return
+	}
+
+	strikes, err := server.store.ListLocationStrikes(ctx, db.ListLocationStrikesParams{
+		UserID: userID,
+		Limit:  50,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"strikes": strikes})
+}
+
 // Admin: Get Statistics (with Redis caching)
 func (server *Server) getStats(ctx *gin.Context) {
 	cacheKey := "admin:stats"
@@ -169,9 +266,15 @@ func (server *Server) getStats(ctx *gin.Context) {
 
 // Admin: List Reports
 type listReportsRequest struct {
-	Resolved bool  `form:"resolved"`
-	PageID   int32 `form:"page" binding:"required,min=1"`
-	PageSize int32 `form:"page_size" binding:"required,min=5,max=100"`
+	Status     string        `form:"status" binding:"omitempty,oneof=open in_review actioned dismissed"`
+	Reason     string        `form:"reason" binding:"omitempty,oneof=spam abuse inappropriate other"`
+	TargetType string        `form:"target_type" binding:"omitempty,oneof=user story message"`
+	AssigneeID string        `form:"assignee_id" binding:"omitempty,uuid"`
+	Unassigned bool          `form:"unassigned"`
+	MinAge     time.Duration `form:"min_age"` // Only reports older than this, e.g. 24h
+	MaxAge     time.Duration `form:"max_age"` // Only reports newer than this
+	PageID     int32         `form:"page" binding:"required,min=1"`
+	PageSize   int32         `form:"page_size" binding:"required,min=5,max=100"`
 }
 
 func (server *Server) listReports(ctx *gin.Context) {
@@ -181,11 +284,28 @@ func (server *Server) listReports(ctx *gin.Context) {
 		return
 	}
 
-	reports, err := server.store.ListReports(ctx, db.ListReportsParams{
-		IsResolved: req.Resolved,
+	arg := db.ListReportsParams{
+		Status:     toNullString(req.Status),
+		TargetType: toNullString(req.TargetType),
+		Unassigned: req.Unassigned,
 		Limit:      req.PageSize,
 		Offset:     (req.PageID - 1) * req.PageSize,
-	})
+	}
+	if req.Reason != "" {
+		arg.Reason = db.NullReportReason{ReportReason: db.ReportReason(req.Reason), Valid: true}
+	}
+	if req.AssigneeID != "" {
+		arg.AssigneeID = uuid.NullUUID{UUID: uuid.MustParse(req.AssigneeID), Valid: true}
+	}
+	now := time.Now()
+	if req.MinAge > 0 {
+		arg.CreatedBefore = toNullTime(now.Add(-req.MinAge))
+	}
+	if req.MaxAge > 0 {
+		arg.CreatedAfter = toNullTime(now.Add(-req.MaxAge))
+	}
+
+	reports, err := server.store.ListReports(ctx, arg)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
@@ -199,6 +319,12 @@ type resolveReportRequest struct {
 	ReportID string `uri:"id" binding:"required,uuid"`
 }
 
+type resolveReportBody struct {
+	Status   string   `json:"status" binding:"required,oneof=actioned dismissed"`
+	Outcomes []string `json:"outcomes" binding:"max=3,dive,oneof=story_removed user_warned user_banned"`
+	Reason   string   `json:"reason" binding:"required,min=3,max=500"`
+}
+
 func (server *Server) resolveReport(ctx *gin.Context) {
 	var req resolveReportRequest
 	if err := ctx.ShouldBindUri(&req); err != nil {
@@ -206,18 +332,89 @@ func (server *Server) resolveReport(ctx *gin.Context) {
 		return
 	}
 
+	var body resolveReportBody
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	// Actioned cases record what was done; dismissed ones must not
+	if (body.Status == reportStatusActioned) != (len(body.Outcomes) > 0) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errReportOutcomeMismatch))
+		return
+	}
+
 	reportID, ok := parseUUIDParam(ctx, req.ReportID, "report_id")
 	if !ok {
 		return
 	}
 
-	report, err := server.store.ResolveReport(ctx, reportID)
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	actorID := uuid.NullUUID{UUID: authPayload.UserID, Valid: true}
+
+	var report db.Report
+	var outcomes []db.ReportOutcome
+	var target reportTarget
+	err := server.store.ExecTx(ctx, func(q *db.Queries) error {
+		before, err := q.GetReport(ctx, reportID)
+		if err != nil {
+			return err
+		}
+		if isReportClosed(before.Status) {
+			return errReportClosed
+		}
+
+		target, outcomes, err = applyReportOutcomes(ctx, q, before, body.Outcomes, body.Reason, actorID)
+		if err != nil {
+			return err
+		}
+
+		report, err = q.ResolveReport(ctx, db.ResolveReportParams{
+			ID:     reportID,
+			Status: body.Status,
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return errReportClosed
+			}
+			return err
+		}
+
+		return writeAudit(ctx, q, auditEntry{
+			Action:     auditReportResolve,
+			TargetType: auditTargetReport,
+			TargetID:   reportID,
+			Reason:     body.Reason,
+			Before:     before,
+			After:      gin.H{"report": report, "outcomes": body.Outcomes},
+		})
+	})
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		switch err {
+		case sql.ErrNoRows:
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "report not found"})
+		case errReportClosed:
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+		case errReportTargetGone:
+			ctx.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
 		return
 	}
 
-	ctx.JSON(http.StatusOK, report)
+	if hasOutcome(outcomes, reportOutcomeStoryRemoved) {
+		server.invalidateFeedCache(target.removedGeohash)
+	}
+	if report.Status == reportStatusActioned && target.userID.Valid {
+		server.recalculateTrust(ctx, target.userID.UUID)
+	}
+	server.notifyReporter(ctx, report)
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"report":   report,
+		"outcomes": outcomes,
+	})
 }
 
 // Admin: Delete Story
@@ -225,6 +422,11 @@ type deleteStoryRequest struct {
 	StoryID string `uri:"id" binding:"required,uuid"`
 }
 
+type deleteStoryOptions struct {
+	Blocklist bool   `form:"blocklist"` // Also add the story's media hash to the upload blocklist
+	Reason    string `form:"reason" binding:"required,min=3,max=500"`
+}
+
 func (server *Server) deleteStory(ctx *gin.Context) {
 	var req deleteStoryRequest
 	if err := ctx.ShouldBindUri(&req); err != nil {
@@ -232,24 +434,72 @@ func (server *Server) deleteStory(ctx *gin.Context) {
 		return
 	}
 
+	var opts deleteStoryOptions
+	if err := ctx.ShouldBindQuery(&opts); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
 	storyID, ok := parseUUIDParam(ctx, req.StoryID, "story_id")
 	if !ok {
 		return
 	}
 
-	err := server.store.DeleteStory(ctx, storyID)
+	story, err := server.store.GetStoryByID(ctx, storyID)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "story not found"})
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	// Invalidate feed cache when story is deleted
-	keys, err := server.redis.Keys(ctx, "feed:*").Result()
-	if err == nil && len(keys) > 0 {
-		server.redis.Del(ctx, keys...)
+	var hashes mediahash.Hashes
+	if opts.Blocklist {
+		hashes, err = server.storyMediaHashes(ctx, story.MediaUrl)
+		if err != nil {
+			if err == errMediaHashUnavailable {
+				ctx.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{"message": "story deleted"})
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	err = server.store.ExecTx(ctx, func(q *db.Queries) error {
+		if opts.Blocklist {
+			if err := blocklistStoryMedia(ctx, q, storyID, hashes, opts.Reason, authPayload.UserID); err != nil {
+				return err
+			}
+		}
+
+		err := writeAudit(ctx, q, auditEntry{
+			Action:     auditStoryDelete,
+			TargetType: auditTargetStory,
+			TargetID:   storyID,
+			Reason:     opts.Reason,
+			Before:     storyAuditSnapshot(story),
+			After:      gin.H{"blocklisted": opts.Blocklist},
+		})
+		if err != nil {
+			return err
+		}
+
+		return removeStory(ctx, q, storyID)
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// Invalidate feed cache when story is deleted
+	server.invalidateFeedCache(story.Geohash)
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "story deleted", "blocklisted": opts.Blocklist})
 }
 
 // Admin: List All Stories
@@ -0,0 +1,166 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sqlc-dev/pqtype"
+
+	"privacy-social-backend/internal/repository/db"
+	"privacy-social-backend/internal/token"
+)
+
+// Audited admin actions (moderation_audit.action)
+const (
+	auditUserBan          = "user.ban"
+	auditUserUnban        = "user.unban"
+	auditUserDelete       = "user.delete"
+	auditReportResolve    = "report.resolve"
+	auditReportUpdate     = "report.update"
+	auditReportNote       = "report.note"
+	auditStoryDelete      = "story.delete"
+	auditAppealDecide     = "appeal.decide"
+	auditFlagReview       = "flag.review"
+	auditEscalationRevert = "escalation.revert"
+	auditBlocklistDelete  = "media_blocklist.delete"
+)
+
+// Audit target types (moderation_audit.target_type)
+const (
+	auditTargetUser       = "user"
+	auditTargetReport     = "report"
+	auditTargetStory      = "story"
+	auditTargetAppeal     = "appeal"
+	auditTargetFlag       = "flag"
+	auditTargetEscalation = "escalation"
+	auditTargetBlocklist  = "media_blocklist"
+)
+
+// adminReasonQuery binds the mandatory reason for admin actions without a body
+type adminReasonQuery struct {
+	Reason string `form:"reason" binding:"required,min=3,max=500"`
+}
+
+// auditEntry is one admin action. Before/After are snapshots marshalled to JSON.
+type auditEntry struct {
+	Action     string
+	TargetType string
+	TargetID   uuid.UUID
+	Reason     string
+	Before     interface{}
+	After      interface{}
+}
+
+// writeAudit appends an entry inside the caller's transaction, so the
+// action and its record commit or roll back together
+func writeAudit(ctx *gin.Context, q *db.Queries, entry auditEntry) error {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	before, err := auditSnapshot(entry.Before)
+	if err != nil {
+		return err
+	}
+	after, err := auditSnapshot(entry.After)
+	if err != nil {
+		return err
+	}
+
+	return q.CreateModerationAudit(ctx, db.CreateModerationAuditParams{
+		ActorID:    authPayload.UserID,
+		ActorRole:  ctx.GetString(adminRoleKey),
+		Action:     entry.Action,
+		TargetType: entry.TargetType,
+		TargetID:   entry.TargetID,
+		Reason:     entry.Reason,
+		Before:     before,
+		After:      after,
+		RequestID:  ctx.GetString(requestIDKey),
+	})
+}
+
+func auditSnapshot(v interface{}) (pqtype.NullRawMessage, error) {
+	if v == nil {
+		return pqtype.NullRawMessage{}, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return pqtype.NullRawMessage{}, err
+	}
+	return pqtype.NullRawMessage{RawMessage: data, Valid: true}, nil
+}
+
+// userAuditSnapshot keeps the moderation-relevant fields, never credentials
+func userAuditSnapshot(u db.User) gin.H {
+	return gin.H{
+		"id":               u.ID,
+		"username":         u.Username,
+		"role":             u.Role,
+		"trust_level":      u.TrustLevel,
+		"is_verified":      u.IsVerified,
+		"is_shadow_banned": u.IsShadowBanned,
+		"created_at":       u.CreatedAt,
+	}
+}
+
+func storyAuditSnapshot(s db.GetStoryByIDRow) gin.H {
+	return gin.H{
+		"id":         s.ID,
+		"user_id":    s.UserID,
+		"media_url":  s.MediaUrl,
+		"media_type": s.MediaType,
+		"caption":    s.Caption.String,
+		"geohash":    s.Geohash,
+		"visibility": s.Visibility,
+		"created_at": s.CreatedAt,
+		"expires_at": s.ExpiresAt,
+	}
+}
+
+// Admin: List Moderation Audit
+type listModerationAuditRequest struct {
+	ActorID    string    `form:"actor_id" binding:"omitempty,uuid"`
+	Action     string    `form:"action"`
+	TargetType string    `form:"target_type"`
+	TargetID   string    `form:"target_id" binding:"omitempty,uuid"`
+	Since      time.Time `form:"since" time_format:"2006-01-02T15:04:05Z07:00"`
+	Until      time.Time `form:"until" time_format:"2006-01-02T15:04:05Z07:00"`
+	PageID     int32     `form:"page" binding:"required,min=1"`
+	PageSize   int32     `form:"page_size" binding:"required,min=5,max=100"`
+}
+
+func (server *Server) listModerationAudit(ctx *gin.Context) {
+	var req listModerationAuditRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ListModerationAuditParams{
+		Action:     toNullString(req.Action),
+		TargetType: toNullString(req.TargetType),
+		Since:      toNullTime(req.Since),
+		Until:      toNullTime(req.Until),
+		Limit:      req.PageSize,
+		Offset:     (req.PageID - 1) * req.PageSize,
+	}
+	if req.ActorID != "" {
+		arg.ActorID = uuid.NullUUID{UUID: uuid.MustParse(req.ActorID), Valid: true}
+	}
+	if req.TargetID != "" {
+		arg.TargetID = uuid.NullUUID{UUID: uuid.MustParse(req.TargetID), Valid: true}
+	}
+
+	entries, err := server.store.ListModerationAudit(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"entries": entries,
+		"page":    req.PageID,
+	})
+}
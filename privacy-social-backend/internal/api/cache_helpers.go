@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"privacy-social-backend/internal/service/feedcache"
 )
 
 // conversationCacheKey generates a consistent cache key for a conversation between two users
@@ -15,10 +17,18 @@ func conversationCacheKey(userID1, userID2 uuid.UUID) string {
 	return "messages:" + ids[0] + ":" + ids[1]
 }
 
+// conversationViewerCacheKey scopes the conversation cache to the viewing participant
+func conversationViewerCacheKey(userID1, userID2, viewerID uuid.UUID) string {
+	return conversationCacheKey(userID1, userID2) + ":" + viewerID.String()
+}
+
 // invalidateConversationCache removes the cached conversation between two users
 func (server *Server) invalidateConversationCache(userID1, userID2 uuid.UUID) {
-	cacheKey := conversationCacheKey(userID1, userID2)
-	server.redis.Del(context.Background(), cacheKey)
+	server.redis.Del(context.Background(),
+		conversationCacheKey(userID1, userID2),
+		conversationViewerCacheKey(userID1, userID2, userID1),
+		conversationViewerCacheKey(userID1, userID2, userID2),
+	)
 }
 
 // invalidateProfileCache removes the cached profile for a user
@@ -27,10 +37,48 @@ func (server *Server) invalidateProfileCache(userID uuid.UUID) {
 	server.redis.Del(context.Background(), cacheKey)
 }
 
-// invalidateFeedCache removes the cached feed for a geohash
-func (server *Server) invalidateFeedCache(geohash string) {
-	cacheKey := "feed:" + geohash
-	server.redis.Del(context.Background(), cacheKey)
+// invalidateFeedCache removes the cached feed candidates of every area that
+// includes a story, given the story's geohash
+func (server *Server) invalidateFeedCache(storyGeohash string) {
+	feedcache.Invalidate(context.Background(), server.redis, storyGeohash)
+}
+
+// mapVersionKey holds the version in a viewer's map cache keys. Bumping it
+// retires every map tile cached for them; the old ones expire on their own.
+func mapVersionKey(userID uuid.UUID) string {
+	return "map:version:" + userID.String()
+}
+
+// mapCacheVersion returns the viewer's current map cache version, 0 if unset
+func (server *Server) mapCacheVersion(ctx context.Context, userID uuid.UUID) string {
+	version, err := server.redis.Get(ctx, mapVersionKey(userID)).Result()
+	if err != nil {
+		return "0"
+	}
+	return version
+}
+
+// invalidateStoryCaches drops a viewer's per-user story caches (connection
+// stories and map tiles). The shared feed cache holds no per-viewer data.
+func (server *Server) invalidateStoryCaches(ctx context.Context, userID uuid.UUID) {
+	server.redis.Del(ctx, "stories:connections:"+userID.String())
+
+	// Outlives every tile cached under the old version, so a reset to 0 can't revive one
+	versionKey := mapVersionKey(userID)
+	server.redis.Incr(ctx, versionKey)
+	server.redis.Expire(ctx, versionKey, mapVersionTTL)
+}
+
+// invalidateBlockCaches drops everything that shows one user to the other,
+// for both sides of a block or unblock
+func (server *Server) invalidateBlockCaches(ctx context.Context, userID1, userID2 uuid.UUID) {
+	for _, id := range []uuid.UUID{userID1, userID2} {
+		server.invalidateProfileCache(id)
+		server.invalidateCrossingsCache(id)
+		server.invalidateStoryCaches(ctx, id)
+		server.redis.Del(ctx, "connections:"+id.String())
+	}
+	server.invalidateConversationCache(userID1, userID2)
 }
 
 // invalidateUnreadCountCache removes the cached unread count for a user
@@ -6,13 +6,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"sort"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 
 	"privacy-social-backend/internal/repository/db"
+	"privacy-social-backend/internal/service/moderation"
+	"privacy-social-backend/internal/service/spam"
+	"privacy-social-backend/internal/service/trust"
 	"privacy-social-backend/internal/token"
 )
 
@@ -88,6 +90,26 @@ func (server *Server) checkConnection(ctx context.Context, userID1, userID2 uuid
 	return nil
 }
 
+// checkChatAccess allows a conversation between connections, or within a live
+// story reply thread. Returns sql.ErrNoRows when neither applies.
+func (server *Server) checkChatAccess(ctx context.Context, userID, otherUserID uuid.UUID) error {
+	err := server.checkConnection(ctx, userID, otherUserID)
+	if err != sql.ErrNoRows {
+		return err
+	}
+	thread, err := server.store.HasStoryReplyThread(ctx, db.HasStoryReplyThreadParams{
+		UserID:      userID,
+		OtherUserID: otherUserID,
+	})
+	if err != nil {
+		return err
+	}
+	if !thread {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
 // API to get chat history
 func (server *Server) getChatHistory(ctx *gin.Context) {
 	targetIDStr := ctx.Query("user_id")
@@ -97,8 +119,8 @@ func (server *Server) getChatHistory(ctx *gin.Context) {
 	}
 	authPayload := getAuthPayload(ctx)
 
-	// Check for mutual connection
-	if err := server.checkConnection(ctx, authPayload.UserID, targetID); err != nil {
+	// Check for mutual connection, or a story reply from a non-connection
+	if err := server.checkChatAccess(ctx, authPayload.UserID, targetID); err != nil {
 		if err == sql.ErrNoRows {
 			ctx.JSON(http.StatusForbidden, gin.H{"error": "You must be connected to this user to chat."})
 			return
@@ -107,10 +129,8 @@ func (server *Server) getChatHistory(ctx *gin.Context) {
 		return
 	}
 
-	// Create cache key with sorted IDs for consistency
-	ids := []string{authPayload.UserID.String(), targetID.String()}
-	sort.Strings(ids)
-	cacheKey := "messages:" + ids[0] + ":" + ids[1]
+	// Cache per viewer: messages held for review differ between the two sides
+	cacheKey := conversationViewerCacheKey(authPayload.UserID, targetID, authPayload.UserID)
 
 	// Try Redis cache first
 	cachedData, err := server.redis.Get(context.Background(), cacheKey).Result()
@@ -131,17 +151,33 @@ func (server *Server) getChatHistory(ctx *gin.Context) {
 
 	// Map to response struct to ensure Reactions are valid JSON, not Base64
 	type MessageResponse struct {
-		ID         uuid.UUID       `json:"id"`
-		SenderID   uuid.UUID       `json:"sender_id"`
-		ReceiverID uuid.UUID       `json:"receiver_id"`
-		Content    string          `json:"content"`
-		IsRead     bool            `json:"is_read"`
-		CreatedAt  time.Time       `json:"created_at"`
-		ReadAt     sql.NullTime    `json:"read_at"`
-		ExpiresAt  sql.NullTime    `json:"expires_at"`
-		MediaUrl   *string         `json:"media_url"`
-		MediaType  *string         `json:"media_type"`
-		Reactions  json.RawMessage `json:"reactions"`
+		ID         uuid.UUID           `json:"id"`
+		SenderID   uuid.UUID           `json:"sender_id"`
+		ReceiverID uuid.UUID           `json:"receiver_id"`
+		Content    string              `json:"content"`
+		IsRead     bool                `json:"is_read"`
+		CreatedAt  time.Time           `json:"created_at"`
+		ReadAt     sql.NullTime        `json:"read_at"`
+		ExpiresAt  sql.NullTime        `json:"expires_at"`
+		MediaUrl   *string             `json:"media_url"`
+		MediaType  *string             `json:"media_type"`
+		Reactions  json.RawMessage     `json:"reactions"`
+		StoryRef   *db.MessageStoryRef `json:"story_ref,omitempty"` // Set on story replies
+	}
+
+	// Story replies carry a snapshot of the story they answer
+	messageIDs := make([]uuid.UUID, len(msgs))
+	for i, m := range msgs {
+		messageIDs[i] = m.ID
+	}
+	refs, err := server.store.ListMessageStoryRefs(ctx, messageIDs)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	storyRefs := make(map[uuid.UUID]*db.MessageStoryRef, len(refs))
+	for i := range refs {
+		storyRefs[refs[i].MessageID] = &refs[i]
 	}
 
 	responseMsgs := make([]MessageResponse, len(msgs))
@@ -172,6 +208,7 @@ func (server *Server) getChatHistory(ctx *gin.Context) {
 			MediaUrl:   nullStringToStrPtr(m.MediaUrl),
 			MediaType:  nullStringToStrPtr(m.MediaType),
 			Reactions:  reactionsJSON,
+			StoryRef:   storyRefs[m.ID],
 		}
 	}
 
@@ -203,8 +240,9 @@ func (server *Server) sendMessage(ctx *gin.Context) {
 
 	authPayload := getAuthPayload(ctx)
 
-	// Check for mutual connection before sending
-	if err := server.checkConnection(ctx, authPayload.UserID, req.ReceiverID); err != nil {
+	// Check for mutual connection before sending. A story reply thread lets
+	// both sides answer without one.
+	if err := server.checkChatAccess(ctx, authPayload.UserID, req.ReceiverID); err != nil {
 		if err == sql.ErrNoRows {
 			ctx.JSON(http.StatusForbidden, gin.H{"error": "You must be connected to this user to send messages."})
 			return
@@ -213,6 +251,47 @@ func (server *Server) sendMessage(ctx *gin.Context) {
 		return
 	}
 
+	// Users restricted after repeated reports can't start or continue DMs
+	if server.isRestricted(ctx, authPayload.UserID, restrictionMessages) {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": accountRestrictedMessage})
+		return
+	}
+
+	// Senders caught spamming are throttled for a while
+	if server.isSenderThrottled(ctx, authPayload.UserID) {
+		ctx.JSON(http.StatusTooManyRequests, errorResponse(errSenderThrottled))
+		return
+	}
+
+	sender, err := server.store.GetUserByID(ctx, authPayload.UserID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// Media in DMs requires a minimum trust level
+	if req.MediaUrl != "" && !trust.LimitsFor(sender.TrustLevel).DMMedia {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": "your account can't send media in messages yet"})
+		return
+	}
+
+	// Content moderation
+	moderationResult := server.moderateText(ctx, moderation.KindMessage, authPayload.UserID, req.Content)
+	if moderationResult.Verdict == moderation.VerdictReject {
+		ctx.JSON(http.StatusUnprocessableEntity, errorResponse(errContentRejected))
+		return
+	}
+
+	// Spam detection: suspicious messages are held like flagged content
+	spamVerdict := server.checkSpam(ctx, sender, spam.Message{
+		ReceiverID: req.ReceiverID,
+		Content:    req.Content,
+		MediaURL:   req.MediaUrl,
+	})
+	if spamVerdict.Hold && moderationResult.Verdict == moderation.VerdictAllow {
+		moderationResult = spamModerationResult(spamVerdict)
+	}
+
 	// Handle expiry - DEFAULT TO 24 HOURS (Snapchat-style)
 	var expiresAt sql.NullTime
 	if req.ExpiresInSeconds > 0 {
@@ -229,26 +308,43 @@ func (server *Server) sendMessage(ctx *gin.Context) {
 		}
 	}
 
-	msg, err := server.store.CreateMessage(ctx, db.CreateMessageParams{
-		SenderID:   authPayload.UserID,
-		ReceiverID: req.ReceiverID,
-		Content:    req.Content,
-		MediaUrl:   toNullString(req.MediaUrl),
-		MediaType:  toNullString(req.MediaType),
-		ExpiresAt:  expiresAt,
+	// Flagged messages are held: the receiver sees nothing until reviewed.
+	// Otherwise, messages matching the receiver's hidden words go to their hidden requests.
+	held := moderationResult.Verdict == moderation.VerdictFlag
+	hidden := false
+
+	var msg db.Message
+	err = server.store.ExecTx(ctx, func(q *db.Queries) error {
+		var err error
+		msg, err = q.CreateMessage(ctx, db.CreateMessageParams{
+			SenderID:   authPayload.UserID,
+			ReceiverID: req.ReceiverID,
+			Content:    req.Content,
+			MediaUrl:   toNullString(req.MediaUrl),
+			MediaType:  toNullString(req.MediaType),
+			ExpiresAt:  expiresAt,
+		})
+		if err != nil {
+			return err
+		}
+		if held {
+			return holdForReview(ctx, q, flagContentMessage, msg.ID, authPayload.UserID, moderation.KindMessage, req.Content, moderationResult)
+		}
+		hidden, err = hideIfFiltered(ctx, q, msg.ID, req.ReceiverID, req.Content)
+		return err
 	})
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
+	if spamVerdict.Hold {
+		server.reportSpam(ctx, authPayload.UserID, spamVerdict, req.Content)
+	}
+
 	// Invalidate cache for this conversation
 	server.invalidateConversationCache(authPayload.UserID, req.ReceiverID)
 
-	// Update Unread Count Cache for Receiver
-	server.incrementUnreadCount(req.ReceiverID)
-
-	// Send real-time notification to receiver via WebSocket
 	wsMsg := WSMessage{
 		Type:      "new_message",
 		Payload:   msg,
@@ -256,7 +352,16 @@ func (server *Server) sendMessage(ctx *gin.Context) {
 		CreatedAt: msg.CreatedAt,
 	}
 	wsMsgBytes, _ := json.Marshal(wsMsg)
-	server.hub.SendToUser(req.ReceiverID, wsMsgBytes)
+
+	if !held && !hidden {
+		// Update Unread Count Cache for Receiver
+		server.incrementUnreadCount(req.ReceiverID)
+
+		// Send real-time notification to receiver via WebSocket, unless they muted the sender
+		if !server.conversationMuted(ctx, req.ReceiverID, authPayload.UserID) {
+			server.hub.SendToUser(req.ReceiverID, wsMsgBytes)
+		}
+	}
 
 	// Also send to SENDER so their client can update the messages list
 	server.hub.SendToUser(authPayload.UserID, wsMsgBytes)
@@ -348,13 +453,35 @@ func (server *Server) editMessage(ctx *gin.Context) {
 		return
 	}
 
-	// Update the message
-	updatedMsg, err := server.store.UpdateMessage(ctx, db.UpdateMessageParams{
-		ID:        messageID,
-		SenderID:  authPayload.UserID,
-		Content:   req.Content,
-		MediaUrl:  originalMsg.MediaUrl,  // Keep original media
-		MediaType: originalMsg.MediaType, // Keep original type
+	// Content moderation
+	moderationResult := server.moderateText(ctx, moderation.KindMessage, authPayload.UserID, req.Content)
+	if moderationResult.Verdict == moderation.VerdictReject {
+		ctx.JSON(http.StatusUnprocessableEntity, errorResponse(errContentRejected))
+		return
+	}
+
+	// Update the message; a flagged edit is hidden from the receiver until reviewed
+	held := moderationResult.Verdict == moderation.VerdictFlag
+	hidden := false
+
+	var updatedMsg db.Message
+	err = server.store.ExecTx(ctx, func(q *db.Queries) error {
+		var err error
+		updatedMsg, err = q.UpdateMessage(ctx, db.UpdateMessageParams{
+			ID:        messageID,
+			SenderID:  authPayload.UserID,
+			Content:   req.Content,
+			MediaUrl:  originalMsg.MediaUrl,  // Keep original media
+			MediaType: originalMsg.MediaType, // Keep original type
+		})
+		if err != nil {
+			return err
+		}
+		if held {
+			return holdForReview(ctx, q, flagContentMessage, updatedMsg.ID, authPayload.UserID, moderation.KindMessage, req.Content, moderationResult)
+		}
+		hidden, err = hideIfFiltered(ctx, q, updatedMsg.ID, originalMsg.ReceiverID, req.Content)
+		return err
 	})
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
@@ -364,8 +491,15 @@ func (server *Server) editMessage(ctx *gin.Context) {
 	// Invalidate cache
 	server.invalidateConversationCache(originalMsg.SenderID, originalMsg.ReceiverID)
 
-	// Notify receiver via WebSocket
-	server.sendWSNotification(originalMsg.ReceiverID, "message_edited", updatedMsg)
+	if held {
+		server.sendWSNotification(originalMsg.ReceiverID, "message_deleted", gin.H{"message_id": updatedMsg.ID})
+	} else if hidden {
+		// Edited into a hidden word: it leaves the receiver's conversation
+		server.sendWSNotification(originalMsg.ReceiverID, "message_deleted", gin.H{"message_id": updatedMsg.ID})
+	} else if !server.conversationMuted(ctx, originalMsg.ReceiverID, authPayload.UserID) {
+		// Notify receiver via WebSocket
+		server.sendWSNotification(originalMsg.ReceiverID, "message_edited", updatedMsg)
+	}
 
 	ctx.JSON(http.StatusOK, updatedMsg)
 }
@@ -503,10 +637,7 @@ func (server *Server) addReaction(ctx *gin.Context) {
 	}
 
 	// Invalidate cache
-	ids := []string{msg.SenderID.String(), msg.ReceiverID.String()}
-	sort.Strings(ids)
-	cacheKey := "messages:" + ids[0] + ":" + ids[1]
-	server.redis.Del(context.Background(), cacheKey)
+	server.invalidateConversationCache(msg.SenderID, msg.ReceiverID)
 
 	// Notify the other user
 	otherUserID := msg.SenderID
@@ -523,7 +654,9 @@ func (server *Server) addReaction(ctx *gin.Context) {
 		},
 	}
 	wsMsgBytes, _ := json.Marshal(wsMsg)
-	server.hub.SendToUser(otherUserID, wsMsgBytes)
+	if !server.conversationMuted(ctx, otherUserID, authPayload.UserID) {
+		server.hub.SendToUser(otherUserID, wsMsgBytes)
+	}
 
 	ctx.JSON(http.StatusCreated, reaction)
 }
@@ -581,7 +714,9 @@ func (server *Server) removeReaction(ctx *gin.Context) {
 		},
 	}
 	wsMsgBytes, _ := json.Marshal(wsMsg)
-	server.hub.SendToUser(otherUserID, wsMsgBytes)
+	if !server.conversationMuted(ctx, otherUserID, authPayload.UserID) {
+		server.hub.SendToUser(otherUserID, wsMsgBytes)
+	}
 
 	ctx.JSON(http.StatusOK, gin.H{"message": "Reaction removed"})
 }
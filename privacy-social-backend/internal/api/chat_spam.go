@@ -0,0 +1,136 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"privacy-social-backend/internal/config"
+	"privacy-social-backend/internal/repository/db"
+	"privacy-social-backend/internal/service/moderation"
+	"privacy-social-backend/internal/service/spam"
+)
+
+// Rule name recorded on content flags for held spam
+const spamFlagRule = "chat_spam"
+
+// Most of a held message quoted in its spam report
+const spamSampleBytes = 200
+
+var errSenderThrottled = errors.New("you're sending messages too quickly, try again later")
+
+// newSpamConfig builds the detector config, falling back to defaults for unset values
+func newSpamConfig(config config.Config) spam.Config {
+	c := spam.Config{
+		Window:           config.SpamWindow,
+		FanoutReceivers:  config.SpamFanoutReceivers,
+		BurstWindow:      config.SpamBurstWindow,
+		BurstMessages:    config.SpamBurstMessages,
+		NewAccountAge:    config.SpamNewAccountAge,
+		HoldScore:        config.SpamHoldScore,
+		ThrottleDuration: config.SpamThrottleDuration,
+	}
+
+	if c.Window <= 0 {
+		c.Window = 10 * time.Minute
+	}
+	if c.FanoutReceivers <= 0 {
+		c.FanoutReceivers = 5
+	}
+	if c.BurstWindow <= 0 {
+		c.BurstWindow = time.Minute
+	}
+	if c.BurstMessages <= 0 {
+		c.BurstMessages = 20
+	}
+	if c.NewAccountAge <= 0 {
+		c.NewAccountAge = 48 * time.Hour
+	}
+	if c.HoldScore <= 0 {
+		c.HoldScore = 1.0
+	}
+	if c.ThrottleDuration <= 0 {
+		c.ThrottleDuration = 30 * time.Minute
+	}
+
+	return c
+}
+
+// isSenderThrottled reports whether the sender was throttled for spam. Redis errors fail open.
+func (server *Server) isSenderThrottled(ctx context.Context, senderID uuid.UUID) bool {
+	throttled, err := server.spam.IsThrottled(ctx, senderID)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to check spam throttle")
+		return false
+	}
+	return throttled
+}
+
+// checkSpam scores an outgoing message from sender. Detector failures fail open.
+func (server *Server) checkSpam(ctx context.Context, sender db.User, msg spam.Message) spam.Verdict {
+	msg.SenderID = sender.ID
+	msg.SenderCreatedAt = sender.CreatedAt
+	verdict, err := server.spam.Check(ctx, msg)
+	if err != nil {
+		log.Error().Err(err).Msg("spam check failed")
+		return spam.Verdict{}
+	}
+	return verdict
+}
+
+// spamModerationResult lets held spam go through the content flag review queue
+func spamModerationResult(verdict spam.Verdict) moderation.Result {
+	return moderation.Result{
+		Verdict: moderation.VerdictFlag,
+		Rule:    spamFlagRule,
+		Score:   verdict.Score,
+	}
+}
+
+// truncateUTF8 cuts s to at most n bytes without splitting a rune
+func truncateUTF8(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+// reportSpam throttles the sender and files a system report for moderators
+func (server *Server) reportSpam(ctx context.Context, senderID uuid.UUID, verdict spam.Verdict, sample string) {
+	if err := server.spam.Throttle(ctx, senderID); err != nil {
+		log.Error().Err(err).Msg("failed to throttle spam sender")
+	}
+
+	names := make([]string, len(verdict.Signals))
+	for i, s := range verdict.Signals {
+		names[i] = s.Name
+	}
+	sample = truncateUTF8(sample, spamSampleBytes)
+	description := fmt.Sprintf("Automatic chat spam detection: score %.2f (%s), %d receivers in window. Sample: %q",
+		verdict.Score, strings.Join(names, ", "), verdict.Receivers, sample)
+
+	_, err := server.store.CreateSystemReport(ctx, db.CreateSystemReportParams{
+		TargetUserID: uuid.NullUUID{UUID: senderID, Valid: true},
+		Reason:       db.ReportReasonSpam,
+		Description:  toNullString(description),
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("failed to create spam report")
+	}
+
+	log.Warn().
+		Str("user_id", senderID.String()).
+		Float64("score", verdict.Score).
+		Strs("signals", names).
+		Int64("receivers", verdict.Receivers).
+		Msg("Chat spam held, sender throttled")
+}
@@ -0,0 +1,27 @@
+package api
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTruncateUTF8(t *testing.T) {
+	require.Equal(t, "short", truncateUTF8("short", spamSampleBytes))
+
+	ascii := strings.Repeat("a", 300)
+	require.Equal(t, ascii[:spamSampleBytes], truncateUTF8(ascii, spamSampleBytes))
+
+	// 199 bytes then a 4-byte emoji straddling the cut
+	straddling := strings.Repeat("a", 199) + strings.Repeat("😀", 10)
+	sample := truncateUTF8(straddling, spamSampleBytes)
+	require.True(t, utf8.ValidString(sample))
+	require.Equal(t, strings.Repeat("a", 199), sample)
+
+	multiByte := strings.Repeat("é", 150)
+	sample = truncateUTF8(multiByte, spamSampleBytes)
+	require.True(t, utf8.ValidString(sample))
+	require.Equal(t, strings.Repeat("é", 100), sample)
+}
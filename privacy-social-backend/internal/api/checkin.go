@@ -0,0 +1,235 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"privacy-social-backend/internal/repository/db"
+)
+
+const (
+	maxEmergencyContacts = 5
+
+	// Meetup check-in statuses (meetup_checkins.status)
+	checkinConfirmed = "confirmed"
+	checkinCancelled = "cancelled"
+)
+
+var (
+	errContactNotConnection = errors.New("in-app emergency contacts must be accepted connections")
+	errContactTarget        = errors.New("provide either contact_user_id, or a name with email or phone")
+	errTooManyContacts      = errors.New("emergency contact limit reached")
+	errNoEmergencyContacts  = errors.New("add an emergency contact before starting a check-in")
+	errCheckinActive        = errors.New("a check-in is already active")
+	errNoActiveCheckin      = errors.New("no active check-in")
+)
+
+// List Emergency Contacts
+func (server *Server) listEmergencyContacts(ctx *gin.Context) {
+	authPayload := getAuthPayload(ctx)
+
+	contacts, err := server.store.ListEmergencyContacts(ctx, authPayload.UserID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, contacts)
+}
+
+// Add Emergency Contact: an accepted connection (alerted in-app) or an external email/phone
+type addEmergencyContactRequest struct {
+	ContactUserID string `json:"contact_user_id" binding:"omitempty,uuid"`
+	Name          string `json:"name" binding:"max=100"`
+	Email         string `json:"email" binding:"omitempty,email,max=255"`
+	Phone         string `json:"phone" binding:"omitempty,e164"`
+}
+
+func (server *Server) addEmergencyContact(ctx *gin.Context) {
+	var req addEmergencyContactRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	external := req.Email != "" || req.Phone != ""
+	if (req.ContactUserID != "") == external || (external && req.Name == "") {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errContactTarget))
+		return
+	}
+
+	authPayload := getAuthPayload(ctx)
+
+	count, err := server.store.CountEmergencyContacts(ctx, authPayload.UserID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if count >= maxEmergencyContacts {
+		ctx.JSON(http.StatusUnprocessableEntity, errorResponse(errTooManyContacts))
+		return
+	}
+
+	arg := db.CreateEmergencyContactParams{
+		UserID: authPayload.UserID,
+		Name:   req.Name,
+		Email:  toNullString(req.Email),
+		Phone:  toNullString(req.Phone),
+	}
+
+	if req.ContactUserID != "" {
+		contactID := uuid.MustParse(req.ContactUserID)
+		conn, err := server.store.GetConnection(ctx, db.GetConnectionParams{
+			RequesterID: authPayload.UserID,
+			TargetID:    contactID,
+		})
+		if err != nil && err != sql.ErrNoRows {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		if err == sql.ErrNoRows || conn.Status != db.ConnectionStatusAccepted {
+			ctx.JSON(http.StatusUnprocessableEntity, errorResponse(errContactNotConnection))
+			return
+		}
+
+		contact, err := server.store.GetUserByID(ctx, contactID)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		arg.ContactUserID = uuid.NullUUID{UUID: contactID, Valid: true}
+		if arg.Name == "" {
+			arg.Name = contact.Username
+		}
+	}
+
+	contact, err := server.store.CreateEmergencyContact(ctx, arg)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			ctx.JSON(http.StatusConflict, gin.H{"error": "already an emergency contact"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, contact)
+}
+
+// Delete Emergency Contact
+func (server *Server) deleteEmergencyContact(ctx *gin.Context) {
+	contactID, ok := parseUUIDParam(ctx, ctx.Param("id"), "contact_id")
+	if !ok {
+		return
+	}
+	authPayload := getAuthPayload(ctx)
+
+	_, err := server.store.DeleteEmergencyContact(ctx, db.DeleteEmergencyContactParams{
+		ID:     contactID,
+		UserID: authPayload.UserID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "contact not found"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "contact removed"})
+}
+
+// Start Meetup Check-in. Contacts are alerted if it isn't confirmed by the deadline.
+type startCheckinRequest struct {
+	DurationMinutes int    `json:"duration_minutes" binding:"required,min=5,max=720"`
+	Note            string `json:"note" binding:"max=280"` // Shared with contacts if alerted
+}
+
+func (server *Server) startCheckin(ctx *gin.Context) {
+	var req startCheckinRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := getAuthPayload(ctx)
+
+	count, err := server.store.CountEmergencyContacts(ctx, authPayload.UserID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if count == 0 {
+		ctx.JSON(http.StatusUnprocessableEntity, errorResponse(errNoEmergencyContacts))
+		return
+	}
+
+	checkin, err := server.store.CreateMeetupCheckin(ctx, db.CreateMeetupCheckinParams{
+		UserID:   authPayload.UserID,
+		Note:     toNullString(req.Note),
+		Deadline: time.Now().Add(time.Duration(req.DurationMinutes) * time.Minute),
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			ctx.JSON(http.StatusConflict, errorResponse(errCheckinActive))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, checkin)
+}
+
+// Get Active Check-in
+func (server *Server) getActiveCheckin(ctx *gin.Context) {
+	authPayload := getAuthPayload(ctx)
+
+	checkin, err := server.store.GetActiveMeetupCheckin(ctx, authPayload.UserID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(errNoActiveCheckin))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, checkin)
+}
+
+// Confirm (safe) or cancel the active check-in
+func (server *Server) confirmCheckin(ctx *gin.Context) {
+	server.closeCheckin(ctx, checkinConfirmed)
+}
+
+func (server *Server) cancelCheckin(ctx *gin.Context) {
+	server.closeCheckin(ctx, checkinCancelled)
+}
+
+func (server *Server) closeCheckin(ctx *gin.Context, status string) {
+	authPayload := getAuthPayload(ctx)
+
+	checkin, err := server.store.CloseMeetupCheckin(ctx, db.CloseMeetupCheckinParams{
+		UserID: authPayload.UserID,
+		Status: status,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			// Either never started, or the deadline passed and contacts were alerted
+			ctx.JSON(http.StatusNotFound, errorResponse(errNoActiveCheckin))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, checkin)
+}
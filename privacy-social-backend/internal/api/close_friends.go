@@ -0,0 +1,94 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"privacy-social-backend/internal/repository/db"
+)
+
+var errCloseFriendNotConnection = errors.New("close friends must be accepted connections")
+
+// List Close Friends. Only the owner can see their list.
+func (server *Server) listCloseFriends(ctx *gin.Context) {
+	authPayload := getAuthPayload(ctx)
+
+	friends, err := server.store.ListCloseFriends(ctx, authPayload.UserID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, friends)
+}
+
+// Add Close Friend. The friend isn't notified.
+type addCloseFriendRequest struct {
+	UserID string `json:"user_id" binding:"required,uuid"`
+}
+
+func (server *Server) addCloseFriend(ctx *gin.Context) {
+	var req addCloseFriendRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := getAuthPayload(ctx)
+	friendID, ok := parseUUIDParam(ctx, req.UserID, "user_id")
+	if !ok {
+		return
+	}
+
+	conn, err := server.store.GetConnection(ctx, db.GetConnectionParams{
+		RequesterID: authPayload.UserID,
+		TargetID:    friendID,
+	})
+	if err != nil && err != sql.ErrNoRows {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if err == sql.ErrNoRows || conn.Status != db.ConnectionStatusAccepted {
+		ctx.JSON(http.StatusUnprocessableEntity, errorResponse(errCloseFriendNotConnection))
+		return
+	}
+
+	err = server.store.AddCloseFriend(ctx, db.AddCloseFriendParams{
+		UserID:   authPayload.UserID,
+		FriendID: friendID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// The friend's cached stories now include close_friends stories
+	server.invalidateStoryCaches(ctx, friendID)
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "added to close friends"})
+}
+
+// Remove Close Friend
+func (server *Server) removeCloseFriend(ctx *gin.Context) {
+	friendID, ok := parseUUIDParam(ctx, ctx.Param("id"), "user_id")
+	if !ok {
+		return
+	}
+	authPayload := getAuthPayload(ctx)
+
+	err := server.store.RemoveCloseFriend(ctx, db.RemoveCloseFriendParams{
+		UserID:   authPayload.UserID,
+		FriendID: friendID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.invalidateStoryCaches(ctx, friendID)
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "removed from close friends"})
+}
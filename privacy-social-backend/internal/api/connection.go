@@ -10,6 +10,7 @@ import (
 	"github.com/rs/zerolog/log"
 
 	"privacy-social-backend/internal/repository/db"
+	"privacy-social-backend/internal/service/trust"
 	"privacy-social-backend/internal/token"
 )
 
@@ -73,24 +74,36 @@ func (server *Server) sendConnectionRequest(ctx *gin.Context) {
 		return
 	}
 
-	// Spam prevention: limit to 20 connection requests per day
-	count, err := server.store.CountConnectionRequestsToday(ctx, authPayload.UserID)
+	// Blocked pairs can't reconnect; answer as if the user didn't exist
+	blocked, err := server.isBlockedEitherWay(ctx, authPayload.UserID, targetID)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
-	if count >= 20 {
-		ctx.JSON(http.StatusTooManyRequests, gin.H{"error": "daily connection request limit reached (20/day)"})
+	if blocked {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "target user not found"})
 		return
 	}
 
-	// Get requester info for notification
+	// Get requester info for notification and trust limits
 	requester, err := server.store.GetUserByID(ctx, authPayload.UserID)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
+	// Spam prevention: daily connection requests scale with trust
+	limit := trust.LimitsFor(requester.TrustLevel).DailyConnectionRequests
+	count, err := server.store.CountConnectionRequestsToday(ctx, authPayload.UserID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if count >= limit {
+		ctx.JSON(http.StatusTooManyRequests, gin.H{"error": fmt.Sprintf("daily connection request limit reached (%d/day)", limit)})
+		return
+	}
+
 	conn, err := server.store.CreateConnectionRequest(ctx, db.CreateConnectionRequestParams{
 		RequesterID: authPayload.UserID,
 		TargetID:    targetID,
@@ -169,6 +182,7 @@ func (server *Server) updateConnection(ctx *gin.Context) {
 				log.Error().Err(err).Msg("failed to create connection accepted notification")
 			}
 		}
+		server.recalculateTrust(ctx, authPayload.UserID, requesterID)
 	}
 
 	ctx.JSON(http.StatusOK, conn)
@@ -184,9 +198,18 @@ func (server *Server) deleteConnection(ctx *gin.Context) {
 
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
 
-	err = server.store.DeleteConnection(ctx, db.DeleteConnectionParams{
-		RequesterID: authPayload.UserID,
-		TargetID:    targetUserID,
+	// Close friends must be connections, so the lists go with the connection
+	err = server.store.ExecTx(ctx, func(q *db.Queries) error {
+		if err := q.DeleteConnection(ctx, db.DeleteConnectionParams{
+			RequesterID: authPayload.UserID,
+			TargetID:    targetUserID,
+		}); err != nil {
+			return err
+		}
+		return q.DeleteCloseFriendPair(ctx, db.DeleteCloseFriendPairParams{
+			UserID:   authPayload.UserID,
+			FriendID: targetUserID,
+		})
 	})
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
@@ -210,6 +233,7 @@ func (server *Server) getSuggestedConnections(ctx *gin.Context) {
 	suggestions, err := server.store.GetSuggestedConnections(ctx, db.GetSuggestedConnectionsParams{
 		RequesterID: authPayload.UserID,
 		Limit:       10,
+		TrustLevel:  trust.SuggestionMinLevel,
 	})
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
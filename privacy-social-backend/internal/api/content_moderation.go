@@ -0,0 +1,271 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"privacy-social-backend/internal/config"
+	"privacy-social-backend/internal/repository/db"
+	"privacy-social-backend/internal/service/moderation"
+	"privacy-social-backend/internal/token"
+)
+
+// Content types held for review (content_flags.content_type)
+const (
+	flagContentStory   = "story"
+	flagContentMessage = "message"
+	flagContentProfile = "profile"
+)
+
+// errContentRejected is returned for rejected text without naming the rule
+var errContentRejected = errors.New("content violates community guidelines")
+
+// newContentModerator builds the moderation pipeline from config:
+// the keyword/regex rule engine, plus the local model when configured.
+func newContentModerator(config config.Config) (moderation.ContentModerator, error) {
+	var rules []moderation.Rule
+	if config.ModerationRulesFile != "" {
+		var err error
+		rules, err = moderation.LoadRules(config.ModerationRulesFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	engine, err := moderation.NewRuleEngine(rules)
+	if err != nil {
+		return nil, err
+	}
+	moderators := []moderation.ContentModerator{engine}
+
+	if config.ModerationModelURL != "" {
+		flagAt, rejectAt := config.ModerationFlagScore, config.ModerationRejectScore
+		if flagAt <= 0 {
+			flagAt = 0.7
+		}
+		if rejectAt <= 0 {
+			rejectAt = 0.95
+		}
+		scorer := moderation.NewHTTPScorer(config.ModerationModelURL)
+		moderators = append(moderators, moderation.NewScoringModerator(scorer, flagAt, rejectAt))
+	}
+
+	return moderation.NewPipeline(moderators...), nil
+}
+
+// moderateText screens a piece of text. Moderator failures fail open.
+func (server *Server) moderateText(ctx context.Context, kind string, authorID uuid.UUID, text string) moderation.Result {
+	result, err := server.moderator.Moderate(ctx, moderation.Content{
+		Kind:     kind,
+		Text:     text,
+		AuthorID: authorID,
+	})
+	if err != nil {
+		log.Error().Err(err).Str("kind", kind).Msg("content moderation failed")
+		return moderation.Result{Verdict: moderation.VerdictAllow}
+	}
+	if result.Verdict != moderation.VerdictAllow {
+		log.Info().
+			Str("user_id", authorID.String()).
+			Str("kind", kind).
+			Str("verdict", string(result.Verdict)).
+			Str("rule", result.Rule).
+			Msg("Content moderation hit")
+	}
+	return result
+}
+
+// holdForReview records a flag so the content stays hidden from others until
+// reviewed. It runs in the transaction that writes the content, so flagged
+// content is never visible unflagged.
+func holdForReview(ctx context.Context, q *db.Queries, contentType string, contentID, authorID uuid.UUID, field, text string, result moderation.Result) error {
+	_, err := q.CreateContentFlag(ctx, db.CreateContentFlagParams{
+		ContentType: contentType,
+		ContentID:   contentID,
+		UserID:      authorID,
+		Field:       field,
+		Content:     text,
+		Rule:        result.Rule,
+		Score:       float32(result.Score),
+	})
+	return err
+}
+
+// Admin: List Content Flags
+type listContentFlagsRequest struct {
+	Status   string `form:"status" binding:"omitempty,oneof=pending approved removed"`
+	PageID   int32  `form:"page" binding:"required,min=1"`
+	PageSize int32  `form:"page_size" binding:"required,min=5,max=100"`
+}
+
+func (server *Server) listContentFlags(ctx *gin.Context) {
+	var req listContentFlagsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if req.Status == "" {
+		req.Status = "pending"
+	}
+
+	flags, err := server.store.ListContentFlags(ctx, db.ListContentFlagsParams{
+		Status: req.Status,
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"flags": flags,
+		"page":  req.PageID,
+	})
+}
+
+// Admin: Review Content Flag
+type reviewContentFlagRequest struct {
+	Action string `json:"action" binding:"required,oneof=approve remove"`
+	Reason string `json:"reason" binding:"required,min=3,max=500"`
+}
+
+var errFlagNotPending = errors.New("flag not found or already reviewed")
+
+func (server *Server) reviewContentFlag(ctx *gin.Context) {
+	flagID, ok := parseUUIDParam(ctx, ctx.Param("id"), "flag_id")
+	if !ok {
+		return
+	}
+
+	var req reviewContentFlagRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	status := "approved"
+	if req.Action == "remove" {
+		status = "removed"
+	}
+
+	var flag db.ContentFlag
+	var msg db.Message
+	err := server.store.ExecTx(ctx, func(q *db.Queries) error {
+		before, err := q.GetContentFlag(ctx, flagID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return errFlagNotPending
+			}
+			return err
+		}
+
+		flag, err = q.ReviewContentFlag(ctx, db.ReviewContentFlagParams{
+			ID:         flagID,
+			Status:     status,
+			ReviewerID: uuid.NullUUID{UUID: authPayload.UserID, Valid: true},
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return errFlagNotPending
+			}
+			return err
+		}
+
+		err = writeAudit(ctx, q, auditEntry{
+			Action:     auditFlagReview,
+			TargetType: auditTargetFlag,
+			TargetID:   flag.ID,
+			Reason:     req.Reason,
+			Before:     before,
+			After:      flag,
+		})
+		if err != nil {
+			return err
+		}
+
+		switch flag.ContentType {
+		case flagContentStory:
+			if status == "removed" {
+				return removeStory(ctx, q, flag.ContentID)
+			}
+		case flagContentMessage:
+			msg, err = q.GetMessage(ctx, flag.ContentID)
+			if err == sql.ErrNoRows {
+				return nil // Already deleted by the sender
+			}
+			if err != nil {
+				return err
+			}
+			if status == "removed" {
+				return q.DeleteMessage(ctx, db.DeleteMessageParams{ID: msg.ID, SenderID: msg.SenderID})
+			}
+		case flagContentProfile:
+			// Profile changes were withheld; apply them only on approval
+			if status == "approved" {
+				return applyProfileField(ctx, q, flag.UserID, flag.Field, flag.Content)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if err == errFlagNotPending {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	switch flag.ContentType {
+	case flagContentStory:
+		// An approved story joins the feed
+		if status == "approved" {
+			if story, err := server.store.GetStoryByID(ctx, flag.ContentID); err == nil {
+				server.invalidateFeedCache(story.Geohash)
+			}
+		}
+	case flagContentMessage:
+		if msg.ID != uuid.Nil {
+			server.invalidateConversationCache(msg.SenderID, msg.ReceiverID)
+		}
+	case flagContentProfile:
+		server.invalidateProfileCache(flag.UserID)
+	}
+
+	ctx.JSON(http.StatusOK, flag)
+}
+
+// removeStory deletes a story taken down by moderators, with its archive copies,
+// so it can't live on in the author's archive or highlights
+func removeStory(ctx context.Context, q *db.Queries, storyID uuid.UUID) error {
+	if err := q.DeleteStoryArchives(ctx, storyID); err != nil {
+		return err
+	}
+	return q.DeleteStory(ctx, storyID)
+}
+
+// applyProfileField writes a single reviewed profile field
+func applyProfileField(ctx context.Context, q *db.Queries, userID uuid.UUID, field, value string) error {
+	arg := db.UpdateUserProfileParams{ID: userID}
+	switch field {
+	case moderation.KindBio:
+		arg.Bio = sql.NullString{String: value, Valid: true}
+	case moderation.KindUsername:
+		arg.Username = sql.NullString{String: value, Valid: true}
+	case moderation.KindFullName:
+		arg.FullName = sql.NullString{String: value, Valid: true}
+	default:
+		return nil
+	}
+	_, err := q.UpdateUserProfile(ctx, arg)
+	return err
+}
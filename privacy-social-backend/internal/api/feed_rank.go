@@ -0,0 +1,165 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"privacy-social-backend/internal/repository/db"
+	"privacy-social-backend/internal/service/feedrank"
+)
+
+const defaultFeedPageSize = 20
+
+// feedPageRequest is the paging part of a feed request
+type feedPageRequest struct {
+	Cursor string `form:"cursor"` // next_cursor from the previous page
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=50"`
+}
+
+// feedPage is a parsed feedPageRequest
+type feedPage struct {
+	cursor *feedrank.Cursor // nil for the first page
+	asOf   time.Time        // Time to rank at
+	limit  int
+}
+
+func (req feedPageRequest) parse() (feedPage, error) {
+	// Wall clock only, as the cursor carries it: a monotonic reading would make
+	// the first page's scores differ from the next page's by a rounding error
+	page := feedPage{asOf: time.Now().Round(0), limit: req.Limit}
+	if page.limit == 0 {
+		page.limit = defaultFeedPageSize
+	}
+	if req.Cursor != "" {
+		cursor, err := feedrank.DecodeCursor(req.Cursor)
+		if err != nil {
+			return page, err
+		}
+		page.cursor, page.asOf = &cursor, cursor.AsOf
+	}
+	return page, nil
+}
+
+// rankFeed scores the viewer's already filtered stories as of asOf, best first.
+// Stories deleted since they were cached, or posted after asOf, drop out here.
+func (server *Server) rankFeed(ctx context.Context, viewerID uuid.UUID, lat, lng float64, stories []StoryResponse, asOf time.Time) ([]feedrank.Ranked, error) {
+	if len(stories) == 0 {
+		return nil, nil
+	}
+	ids := make([]uuid.UUID, len(stories))
+	for i, story := range stories {
+		ids[i] = story.ID
+	}
+
+	signals, err := server.store.ListFeedRankingSignals(ctx, db.ListFeedRankingSignalsParams{
+		Lng:      lng,
+		Lat:      lat,
+		AsOf:     asOf,
+		ViewerID: viewerID,
+		StoryIds: ids,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]feedrank.Candidate, len(signals))
+	for i, s := range signals {
+		candidates[i] = feedrank.Candidate{
+			StoryID:            s.StoryID,
+			AuthorID:           s.AuthorID,
+			CreatedAt:          s.CreatedAt,
+			DistanceMeters:     s.DistanceMeters,
+			IsConnection:       s.IsConnection,
+			ViewerInteractions: s.ViewerInteractions,
+			ViewCount:          s.ViewCount,
+			ReactionCount:      s.ReactionCount,
+			Boosted:            s.IsBoosted,
+			Premium:            s.IsPremium,
+		}
+	}
+	return feedrank.Rank(server.feedRanker, asOf, candidates), nil
+}
+
+// pageFeed ranks the viewer's feed and keeps one page of it
+func (server *Server) pageFeed(ctx context.Context, viewerID uuid.UUID, lat, lng float64, page feedPage, feed *feedResponse) ([]feedrank.Ranked, error) {
+	ranked, err := server.rankFeed(ctx, viewerID, lat, lng, feed.Stories, page.asOf)
+	if err != nil {
+		return nil, err
+	}
+	ranked, next := feedrank.Page(ranked, page.asOf, page.cursor, page.limit)
+
+	byID := make(map[uuid.UUID]StoryResponse, len(feed.Stories))
+	for _, story := range feed.Stories {
+		byID[story.ID] = story
+	}
+	stories := make([]StoryResponse, len(ranked))
+	for i, r := range ranked {
+		stories[i] = byID[r.StoryID]
+	}
+	feed.Stories = stories
+	feed.Count = len(stories)
+	feed.NextCursor = ""
+	if next != nil {
+		feed.NextCursor = next.Encode()
+	}
+	return ranked, nil
+}
+
+// Admin: explain a user's feed ranking at a location. Shows the page the
+// user would get, with every story's score broken down.
+type explainFeedRequest struct {
+	UserID    string  `form:"user_id" binding:"required,uuid"`
+	Latitude  float64 `form:"latitude" binding:"required,min=-90,max=90"`
+	Longitude float64 `form:"longitude" binding:"required,min=-180,max=180"`
+	feedPageRequest
+}
+
+type explainedStory struct {
+	Story StoryResponse `json:"story"`
+	feedrank.Ranked
+}
+
+func (server *Server) explainFeed(ctx *gin.Context) {
+	var req explainFeedRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	userID, ok := parseUUIDParam(ctx, req.UserID, "user_id")
+	if !ok {
+		return
+	}
+	page, err := req.parse()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	feed, _, err := server.loadFeed(ctx, userID, req.Latitude, req.Longitude)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	server.filterFeedForViewer(ctx, userID, &feed)
+	ranked, err := server.pageFeed(ctx, userID, req.Latitude, req.Longitude, page, &feed)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	items := make([]explainedStory, len(ranked))
+	for i, r := range ranked {
+		items[i] = explainedStory{Story: feed.Stories[i], Ranked: r}
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"stories":       items,
+		"count":         len(items),
+		"search_radius": feed.SearchRadius,
+		"next_cursor":   feed.NextCursor,
+	})
+}
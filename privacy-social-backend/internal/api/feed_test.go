@@ -0,0 +1,286 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"privacy-social-backend/internal/repository/db"
+	mockdb "privacy-social-backend/internal/repository/mock"
+	"privacy-social-backend/internal/service/feedcache"
+)
+
+func feedStory(authorID uuid.UUID, visibility db.StoryAvailability, caption string) db.ListFeedStoriesRow {
+	return db.ListFeedStoriesRow{
+		ID:             uuid.New(),
+		UserID:         authorID,
+		MediaUrl:       "/uploads/story.jpg",
+		MediaType:      "image",
+		Caption:        toNullString(caption),
+		Visibility:     visibility,
+		ExpiresAt:      time.Now().Add(time.Hour),
+		CreatedAt:      time.Now().Add(-time.Minute),
+		Username:       "author-" + authorID.String()[:8],
+		DistanceMeters: 500,
+	}
+}
+
+// Everyone in a cell shares its cached candidates, so those must not depend
+// on who asked first, and each viewer must only get what their own blocks,
+// audiences, privacy settings and mutes allow
+func TestFeedNoLeaksBetweenViewers(t *testing.T) {
+	lat, lng := 51.5074, -0.1278
+	centreLat, centreLng := feedcache.Centre(feedcache.Cell(lat, lng))
+
+	alice, bob := uuid.New(), uuid.New()
+	public := feedStory(uuid.New(), db.StoryAvailabilityPublic, "public story")
+	connectionsOnly := feedStory(uuid.New(), db.StoryAvailabilityConnections, "only for alice's connection")
+	alicesOwn := feedStory(alice, db.StoryAvailabilityCloseFriends, "alice close friends only")
+	blockedByBob := feedStory(uuid.New(), db.StoryAvailabilityPublic, "author bob blocked")
+	mutedByBob := feedStory(uuid.New(), db.StoryAvailabilityPublic, "author bob muted")
+	// Left out of the capped shared candidates, but always in for Bob
+	bobsConnection := feedStory(uuid.New(), db.StoryAvailabilityConnections, "beyond the cap, bob's connection")
+	candidates := []uuid.UUID{public.ID, connectionsOnly.ID, alicesOwn.ID, blockedByBob.ID, mutedByBob.ID}
+
+	// Each viewer's own, connections' and close friends' stories, queried per viewer
+	personal := map[uuid.UUID][]uuid.UUID{
+		alice: {alicesOwn.ID},
+		bob:   {bobsConnection.ID},
+	}
+
+	// What the database lets each viewer see of the candidates
+	visible := map[uuid.UUID][]db.ListFeedStoriesRow{
+		alice: {public, connectionsOnly, alicesOwn, blockedByBob, mutedByBob},
+		bob:   {public, bobsConnection},
+	}
+	want := map[uuid.UUID][]uuid.UUID{
+		alice: {public.ID, connectionsOnly.ID, alicesOwn.ID, blockedByBob.ID, mutedByBob.ID},
+		bob:   {public.ID, bobsConnection.ID},
+	}
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+
+	// The shared layer takes no viewer. It's cached after the first request
+	// when Redis is up, and queried again when it isn't.
+	store.EXPECT().
+		ListFeedCandidates(gomock.Any(), db.ListFeedCandidatesParams{
+			Lng:           centreLng,
+			Lat:           centreLat,
+			RadiusMeters:  feedcache.CandidateRadiusMeters,
+			BandMeters:    feedcache.BandMeters,
+			MaxCandidates: feedcache.MaxCandidates,
+		}).
+		MinTimes(1).MaxTimes(3).
+		Return(candidates, nil)
+	store.EXPECT().
+		ListFeedViewerCandidates(gomock.Any(), gomock.Any()).
+		Times(3).
+		DoAndReturn(func(_ context.Context, arg db.ListFeedViewerCandidatesParams) ([]uuid.UUID, error) {
+			require.Equal(t, lat, arg.Lat)
+			require.Equal(t, lng, arg.Lng)
+			return personal[arg.ViewerID], nil
+		})
+	// The viewer rules are SQL, so ListFeedStories is stubbed to return what the
+	// database would let each viewer see. This checks what it's asked for and
+	// what the API does with the answer: the shared candidates plus the
+	// viewer's own, each ID once, for the viewer who asked.
+	var viewers []uuid.UUID
+	store.EXPECT().
+		ListFeedStories(gomock.Any(), gomock.Any()).
+		Times(3).
+		DoAndReturn(func(_ context.Context, arg db.ListFeedStoriesParams) ([]db.ListFeedStoriesRow, error) {
+			require.ElementsMatch(t, mergeStoryIDs(candidates, personal[arg.ViewerID]), arg.StoryIds)
+			require.Equal(t, lat, arg.Lat)
+			require.Equal(t, lng, arg.Lng)
+			viewers = append(viewers, arg.ViewerID)
+			return visible[arg.ViewerID], nil
+		})
+	store.EXPECT().ListHiddenPhrases(gomock.Any(), gomock.Any()).AnyTimes().Return(nil, nil)
+	store.EXPECT().
+		ListFeedRankingSignals(gomock.Any(), gomock.Any()).
+		Times(3).
+		DoAndReturn(func(_ context.Context, arg db.ListFeedRankingSignalsParams) ([]db.ListFeedRankingSignalsRow, error) {
+			allowed := make(map[uuid.UUID]bool)
+			for _, story := range visible[arg.ViewerID] {
+				allowed[story.ID] = true
+			}
+			rows := make([]db.ListFeedRankingSignalsRow, len(arg.StoryIds))
+			for i, id := range arg.StoryIds {
+				require.True(t, allowed[id], "ranking saw a story the viewer can't see")
+				rows[i] = db.ListFeedRankingSignalsRow{StoryID: id, CreatedAt: time.Now(), DistanceMeters: 500}
+			}
+			return rows, nil
+		})
+
+	server := newTestServer(t, store)
+
+	// Alice first, so a shared response cache would hand her view to Bob
+	order := []uuid.UUID{alice, bob, alice}
+	for _, viewerID := range order {
+		recorder := httptest.NewRecorder()
+		url := fmt.Sprintf("/feed?latitude=%f&longitude=%f", lat, lng)
+		request, err := http.NewRequest(http.MethodGet, url, nil)
+		require.NoError(t, err)
+
+		accessToken, _, err := server.tokenMaker.CreateToken("viewer", viewerID, time.Minute)
+		require.NoError(t, err)
+		request.Header.Set(authorizationHeaderKey, authorizationTypeBearer+" "+accessToken)
+
+		server.router.ServeHTTP(recorder, request)
+		require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+
+		var feed feedResponse
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &feed))
+		got := make([]uuid.UUID, len(feed.Stories))
+		for i, story := range feed.Stories {
+			got[i] = story.ID
+		}
+		require.ElementsMatch(t, want[viewerID], got)
+		require.Equal(t, len(got), feed.Count)
+
+		if viewerID == bob {
+			body := recorder.Body.String()
+			for _, hidden := range []db.ListFeedStoriesRow{connectionsOnly, alicesOwn, blockedByBob, mutedByBob} {
+				require.NotContains(t, body, hidden.ID.String())
+				require.NotContains(t, body, hidden.Caption.String)
+			}
+		}
+	}
+	require.Equal(t, order, viewers)
+}
+
+// Later pages rank on the signals the first page was ranked on. Here the
+// story left for page 2 goes viral and a new story is posted in between: with
+// live signals the viral story would jump above the cursor and be skipped.
+func TestFeedPagesStableWhileEngagementChanges(t *testing.T) {
+	lat, lng := 51.5074, -0.1278
+	viewerID := uuid.New()
+
+	stories := []db.ListFeedStoriesRow{
+		feedStory(uuid.New(), db.StoryAvailabilityPublic, "first"),
+		feedStory(uuid.New(), db.StoryAvailabilityPublic, "second"),
+		feedStory(uuid.New(), db.StoryAvailabilityPublic, "third"),
+	}
+	// Best first on the first page: closer stories score higher
+	for i := range stories {
+		stories[i].DistanceMeters = float64(500 * (i + 1))
+	}
+	posted := feedStory(uuid.New(), db.StoryAvailabilityPublic, "posted between pages")
+
+	// What the database holds: views with their time, and the stories
+	views := make(map[uuid.UUID][]time.Time)
+	byID := map[uuid.UUID]db.ListFeedStoriesRow{}
+	live := []db.ListFeedStoriesRow{stories[0], stories[1], stories[2]}
+	for _, story := range append(live, posted) {
+		byID[story.ID] = story
+	}
+
+	ctrl := gomock.NewController(t)
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().
+		ListFeedCandidates(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		DoAndReturn(func(_ context.Context, _ db.ListFeedCandidatesParams) ([]uuid.UUID, error) {
+			ids := make([]uuid.UUID, len(live))
+			for i, story := range live {
+				ids[i] = story.ID
+			}
+			return ids, nil
+		})
+	// Stubbed: every candidate is visible to the viewer
+	store.EXPECT().
+		ListFeedStories(gomock.Any(), gomock.Any()).
+		Times(2).
+		DoAndReturn(func(_ context.Context, arg db.ListFeedStoriesParams) ([]db.ListFeedStoriesRow, error) {
+			rows := make([]db.ListFeedStoriesRow, len(arg.StoryIds))
+			for i, id := range arg.StoryIds {
+				rows[i] = byID[id]
+			}
+			return rows, nil
+		})
+	store.EXPECT().ListFeedViewerCandidates(gomock.Any(), gomock.Any()).Times(2).Return(nil, nil)
+	store.EXPECT().ListHiddenPhrases(gomock.Any(), gomock.Any()).AnyTimes().Return(nil, nil)
+
+	var asOf []time.Time
+	store.EXPECT().
+		ListFeedRankingSignals(gomock.Any(), gomock.Any()).
+		Times(2).
+		DoAndReturn(func(_ context.Context, arg db.ListFeedRankingSignalsParams) ([]db.ListFeedRankingSignalsRow, error) {
+			asOf = append(asOf, arg.AsOf)
+			// As the query does: nothing after as_of counts
+			var rows []db.ListFeedRankingSignalsRow
+			for _, id := range arg.StoryIds {
+				story := byID[id]
+				if story.CreatedAt.After(arg.AsOf) {
+					continue
+				}
+				var viewCount int64
+				for _, viewedAt := range views[id] {
+					if !viewedAt.After(arg.AsOf) {
+						viewCount++
+					}
+				}
+				rows = append(rows, db.ListFeedRankingSignalsRow{
+					StoryID:        id,
+					AuthorID:       story.UserID,
+					CreatedAt:      story.CreatedAt,
+					DistanceMeters: story.DistanceMeters,
+					ViewCount:      viewCount,
+				})
+			}
+			return rows, nil
+		})
+
+	server := newTestServer(t, store)
+
+	getPage := func(cursor string) feedResponse {
+		recorder := httptest.NewRecorder()
+		url := fmt.Sprintf("/feed?latitude=%f&longitude=%f&limit=2&cursor=%s", lat, lng, cursor)
+		request, err := http.NewRequest(http.MethodGet, url, nil)
+		require.NoError(t, err)
+
+		accessToken, _, err := server.tokenMaker.CreateToken("viewer", viewerID, time.Minute)
+		require.NoError(t, err)
+		request.Header.Set(authorizationHeaderKey, authorizationTypeBearer+" "+accessToken)
+
+		server.router.ServeHTTP(recorder, request)
+		require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+
+		var feed feedResponse
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &feed))
+		return feed
+	}
+
+	first := getPage("")
+	require.Len(t, first.Stories, 2)
+	require.NotEmpty(t, first.NextCursor)
+
+	// Between pages: the third story goes viral and a new one is posted
+	now := time.Now().Add(time.Second)
+	for i := 0; i < 1000; i++ {
+		views[stories[2].ID] = append(views[stories[2].ID], now)
+	}
+	posted.CreatedAt = now
+	byID[posted.ID] = posted
+	live = append(live, posted)
+
+	second := getPage(first.NextCursor)
+	require.Len(t, asOf, 2)
+	require.True(t, asOf[0].Equal(asOf[1]), "second page ranked at a different time")
+
+	var got []uuid.UUID
+	for _, story := range append(first.Stories, second.Stories...) {
+		got = append(got, story.ID)
+	}
+	require.Equal(t, []uuid.UUID{stories[0].ID, stories[1].ID, stories[2].ID}, got)
+	require.Empty(t, second.NextCursor)
+}
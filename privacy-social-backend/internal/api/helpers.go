@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -27,6 +28,15 @@ func parseUUIDParam(ctx *gin.Context, value string, paramName string) (uuid.UUID
 	return id, true
 }
 
+// parseUUIDs converts ids already validated by the `uuid` binding
+func parseUUIDs(ids []string) []uuid.UUID {
+	out := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		out = append(out, uuid.MustParse(id))
+	}
+	return out
+}
+
 // toNullString converts a string to a sql.NullString
 func toNullString(s string) sql.NullString {
 	return sql.NullString{
@@ -35,6 +45,14 @@ func toNullString(s string) sql.NullString {
 	}
 }
 
+// toNullTime converts a time to a sql.NullTime, treating the zero time as NULL
+func toNullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{
+		Time:  t,
+		Valid: !t.IsZero(),
+	}
+}
+
 // nullStringToStrPtr converts a sql.NullString to a *string
 func nullStringToStrPtr(ns sql.NullString) *string {
 	if ns.Valid {
@@ -0,0 +1,187 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+
+	"privacy-social-backend/internal/repository/db"
+	"privacy-social-backend/internal/service/hiddenwords"
+)
+
+const maxHiddenWords = 200
+
+// List Hidden Words
+func (server *Server) listHiddenWords(ctx *gin.Context) {
+	authPayload := getAuthPayload(ctx)
+
+	words, err := server.store.ListHiddenWords(ctx, authPayload.UserID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, words)
+}
+
+// Add Hidden Word: a word or phrase, "*" matches the rest of a word (e.g. "idiot*")
+type addHiddenWordRequest struct {
+	Phrase string `json:"phrase" binding:"required,max=100"`
+}
+
+func (server *Server) addHiddenWord(ctx *gin.Context) {
+	var req addHiddenWordRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if hiddenwords.NewMatcher([]string{req.Phrase}) == nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "phrase must contain more than wildcards"})
+		return
+	}
+
+	authPayload := getAuthPayload(ctx)
+
+	phrases, err := server.store.ListHiddenPhrases(ctx, authPayload.UserID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if len(phrases) >= maxHiddenWords {
+		ctx.JSON(http.StatusUnprocessableEntity, gin.H{"error": "hidden words limit reached"})
+		return
+	}
+
+	word, err := server.store.CreateHiddenWord(ctx, db.CreateHiddenWordParams{
+		UserID: authPayload.UserID,
+		Phrase: req.Phrase,
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			ctx.JSON(http.StatusConflict, gin.H{"error": "phrase already hidden"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.invalidateStoryCaches(ctx, authPayload.UserID)
+
+	ctx.JSON(http.StatusCreated, word)
+}
+
+// Delete Hidden Word. Messages already moved to hidden requests stay there.
+func (server *Server) deleteHiddenWord(ctx *gin.Context) {
+	wordID, ok := parseUUIDParam(ctx, ctx.Param("id"), "hidden_word_id")
+	if !ok {
+		return
+	}
+	authPayload := getAuthPayload(ctx)
+
+	_, err := server.store.DeleteHiddenWord(ctx, db.DeleteHiddenWordParams{
+		ID:     wordID,
+		UserID: authPayload.UserID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "hidden word not found"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.invalidateStoryCaches(ctx, authPayload.UserID)
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "hidden word removed"})
+}
+
+// List Hidden Requests: incoming messages that matched a hidden word
+func (server *Server) listHiddenMessages(ctx *gin.Context) {
+	authPayload := getAuthPayload(ctx)
+
+	msgs, err := server.store.ListHiddenMessages(ctx, authPayload.UserID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, msgs)
+}
+
+// Restore Hidden Message back into its conversation
+func (server *Server) restoreHiddenMessage(ctx *gin.Context) {
+	messageID, ok := parseUUIDParam(ctx, ctx.Param("id"), "message_id")
+	if !ok {
+		return
+	}
+	authPayload := getAuthPayload(ctx)
+
+	_, err := server.store.UnhideMessage(ctx, db.UnhideMessageParams{
+		MessageID: messageID,
+		UserID:    authPayload.UserID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "hidden message not found"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	msg, err := server.store.GetMessage(ctx, messageID)
+	if err == nil {
+		server.invalidateConversationCache(msg.SenderID, msg.ReceiverID)
+	}
+	server.invalidateUnreadCountCache(authPayload.UserID)
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "message restored"})
+}
+
+// hiddenWordsMatcher loads a user's hidden words. On error nothing is hidden.
+func (server *Server) hiddenWordsMatcher(ctx context.Context, userID uuid.UUID) *hiddenwords.Matcher {
+	phrases, err := server.store.ListHiddenPhrases(ctx, userID)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID.String()).Msg("failed to load hidden words")
+		return nil
+	}
+	return hiddenwords.NewMatcher(phrases)
+}
+
+// hideIfFiltered moves a message to the receiver's hidden requests when it
+// matches their hidden words, and reports whether it did. It runs in the
+// transaction that writes the message, so a filtered message is never
+// delivered unhidden.
+func hideIfFiltered(ctx context.Context, q *db.Queries, messageID, receiverID uuid.UUID, content string) (bool, error) {
+	phrases, err := q.ListHiddenPhrases(ctx, receiverID)
+	if err != nil {
+		return false, err
+	}
+	if !hiddenwords.NewMatcher(phrases).Match(content) {
+		return false, nil
+	}
+	err = q.HideMessage(ctx, db.HideMessageParams{
+		MessageID: messageID,
+		UserID:    receiverID,
+	})
+	return err == nil, err
+}
+
+// collapseHiddenCaptions marks other people's stories whose caption matches
+// the viewer's hidden words, so clients show them collapsed
+func collapseHiddenCaptions(stories []StoryResponse, viewerID uuid.UUID, matcher *hiddenwords.Matcher) {
+	if matcher == nil {
+		return
+	}
+	for i := range stories {
+		if stories[i].UserID != viewerID && stories[i].Caption != nil && matcher.Match(*stories[i].Caption) {
+			stories[i].Collapsed = true
+		}
+	}
+}
@@ -0,0 +1,383 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"privacy-social-backend/internal/repository/db"
+	"privacy-social-backend/internal/service/moderation"
+)
+
+var (
+	errHighlightNotFound = errors.New("highlight not found")
+	errArchiveNotFound   = errors.New("archived story not found")
+)
+
+// highlightDetailResponse is a highlight with its items, in order
+type highlightDetailResponse struct {
+	db.Highlight
+	Items []highlightItemResponse `json:"items"`
+}
+
+// highlightItemResponse is an archived story in a highlight. Sequences carry their items.
+type highlightItemResponse struct {
+	db.ListHighlightItemsRow
+	Items []db.ArchivedStoryItem `json:"items,omitempty"`
+}
+
+// profileHighlights lists a user's highlights as the viewer may see them
+// (profile visibility, blocks and each highlight's audience). Errors hide them.
+func (server *Server) profileHighlights(ctx context.Context, userID, viewerID uuid.UUID) []db.ListProfileHighlightsRow {
+	highlights, err := server.store.ListProfileHighlights(ctx, db.ListProfileHighlightsParams{
+		UserID:   userID,
+		ViewerID: viewerID,
+	})
+	if err != nil || highlights == nil {
+		return []db.ListProfileHighlightsRow{}
+	}
+	return highlights
+}
+
+// ownArchiveItem checks that an archived story belongs to the user
+func (server *Server) ownArchiveItem(ctx context.Context, archiveID, userID uuid.UUID) error {
+	_, err := server.store.GetArchivedStory(ctx, db.GetArchivedStoryParams{
+		ID:     archiveID,
+		UserID: userID,
+	})
+	if err == sql.ErrNoRows {
+		return errArchiveNotFound
+	}
+	return err
+}
+
+// List my highlights
+func (server *Server) listHighlights(ctx *gin.Context) {
+	authPayload := getAuthPayload(ctx)
+
+	ctx.JSON(http.StatusOK, server.profileHighlights(ctx, authPayload.UserID, authPayload.UserID))
+}
+
+// Get a highlight and its items. Outside its audience it doesn't exist.
+func (server *Server) getHighlight(ctx *gin.Context) {
+	highlightID, ok := parseUUIDParam(ctx, ctx.Param("id"), "highlight_id")
+	if !ok {
+		return
+	}
+	authPayload := getAuthPayload(ctx)
+
+	highlight, err := server.store.GetHighlightForViewer(ctx, db.GetHighlightForViewerParams{
+		ID:       highlightID,
+		ViewerID: authPayload.UserID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(errHighlightNotFound))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	items, err := server.store.ListHighlightItems(ctx, highlightID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	archiveIDs := make([]uuid.UUID, len(items))
+	for i, item := range items {
+		archiveIDs[i] = item.ID
+	}
+	sequences, err := server.archivedStoryItems(ctx, archiveIDs)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := highlightDetailResponse{Highlight: highlight, Items: make([]highlightItemResponse, len(items))}
+	for i, item := range items {
+		rsp.Items[i] = highlightItemResponse{ListHighlightItemsRow: item, Items: sequences[item.ID]}
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+// Create a highlight, optionally with its first archived stories
+type createHighlightRequest struct {
+	Title          string   `json:"title" binding:"required,max=50"`
+	Audience       string   `json:"audience" binding:"omitempty,oneof=public connections close_friends"` // Defaults to public
+	CoverArchiveID string   `json:"cover_archive_id" binding:"omitempty,uuid"`
+	ArchiveIDs     []string `json:"archive_ids" binding:"omitempty,max=100,dive,uuid"`
+}
+
+func (server *Server) createHighlight(ctx *gin.Context) {
+	var req createHighlightRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := getAuthPayload(ctx)
+
+	if server.moderateText(ctx, moderation.KindCaption, authPayload.UserID, req.Title).Verdict == moderation.VerdictReject {
+		ctx.JSON(http.StatusUnprocessableEntity, errorResponse(errContentRejected))
+		return
+	}
+
+	arg := db.CreateHighlightParams{
+		UserID:   authPayload.UserID,
+		Title:    req.Title,
+		Audience: db.StoryAvailabilityPublic,
+	}
+	if req.Audience != "" {
+		arg.Audience = db.StoryAvailability(req.Audience)
+	}
+	if req.CoverArchiveID != "" {
+		coverID := uuid.MustParse(req.CoverArchiveID)
+		if err := server.ownArchiveItem(ctx, coverID, authPayload.UserID); err != nil {
+			server.respondArchiveError(ctx, err)
+			return
+		}
+		arg.CoverArchiveID = uuid.NullUUID{UUID: coverID, Valid: true}
+	}
+
+	var highlight db.Highlight
+	err := server.store.ExecTx(ctx, func(q *db.Queries) error {
+		var err error
+		highlight, err = q.CreateHighlight(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		for _, id := range req.ArchiveIDs {
+			_, err := q.AddHighlightItem(ctx, db.AddHighlightItemParams{
+				HighlightID: highlight.ID,
+				ArchiveID:   uuid.MustParse(id),
+				UserID:      authPayload.UserID,
+			})
+			if err == sql.ErrNoRows {
+				return errArchiveNotFound
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		server.respondArchiveError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, highlight)
+}
+
+// Update a highlight's title, cover or audience
+type updateHighlightRequest struct {
+	Title          *string `json:"title" binding:"omitempty,min=1,max=50"`
+	Audience       *string `json:"audience" binding:"omitempty,oneof=public connections close_friends"`
+	CoverArchiveID *string `json:"cover_archive_id" binding:"omitempty,uuid"`
+}
+
+func (server *Server) updateHighlight(ctx *gin.Context) {
+	highlightID, ok := parseUUIDParam(ctx, ctx.Param("id"), "highlight_id")
+	if !ok {
+		return
+	}
+
+	var req updateHighlightRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := getAuthPayload(ctx)
+
+	arg := db.UpdateHighlightParams{
+		ID:     highlightID,
+		UserID: authPayload.UserID,
+	}
+	if req.Title != nil {
+		if server.moderateText(ctx, moderation.KindCaption, authPayload.UserID, *req.Title).Verdict == moderation.VerdictReject {
+			ctx.JSON(http.StatusUnprocessableEntity, errorResponse(errContentRejected))
+			return
+		}
+		arg.Title = sql.NullString{String: *req.Title, Valid: true}
+	}
+	if req.Audience != nil {
+		arg.Audience = db.NullStoryAvailability{StoryAvailability: db.StoryAvailability(*req.Audience), Valid: true}
+	}
+	if req.CoverArchiveID != nil {
+		coverID := uuid.MustParse(*req.CoverArchiveID)
+		if err := server.ownArchiveItem(ctx, coverID, authPayload.UserID); err != nil {
+			server.respondArchiveError(ctx, err)
+			return
+		}
+		arg.CoverArchiveID = uuid.NullUUID{UUID: coverID, Valid: true}
+	}
+
+	highlight, err := server.store.UpdateHighlight(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(errHighlightNotFound))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, highlight)
+}
+
+// Delete a highlight. The archived stories stay in the archive.
+func (server *Server) deleteHighlight(ctx *gin.Context) {
+	highlightID, ok := parseUUIDParam(ctx, ctx.Param("id"), "highlight_id")
+	if !ok {
+		return
+	}
+	authPayload := getAuthPayload(ctx)
+
+	_, err := server.store.DeleteHighlight(ctx, db.DeleteHighlightParams{
+		ID:     highlightID,
+		UserID: authPayload.UserID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(errHighlightNotFound))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "highlight deleted"})
+}
+
+// Reorder my highlights: ids in display order
+type reorderHighlightsRequest struct {
+	HighlightIDs []string `json:"highlight_ids" binding:"required,min=1,max=100,dive,uuid"`
+}
+
+func (server *Server) reorderHighlights(ctx *gin.Context) {
+	var req reorderHighlightsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	authPayload := getAuthPayload(ctx)
+
+	err := server.store.ReorderHighlights(ctx, db.ReorderHighlightsParams{
+		UserID:       authPayload.UserID,
+		HighlightIds: parseUUIDs(req.HighlightIDs),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "highlights reordered"})
+}
+
+// Add an archived story to a highlight
+type addHighlightItemRequest struct {
+	ArchiveID string `json:"archive_id" binding:"required,uuid"`
+}
+
+func (server *Server) addHighlightItem(ctx *gin.Context) {
+	highlightID, ok := parseUUIDParam(ctx, ctx.Param("id"), "highlight_id")
+	if !ok {
+		return
+	}
+
+	var req addHighlightItemRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	authPayload := getAuthPayload(ctx)
+
+	_, err := server.store.AddHighlightItem(ctx, db.AddHighlightItemParams{
+		HighlightID: highlightID,
+		ArchiveID:   uuid.MustParse(req.ArchiveID),
+		UserID:      authPayload.UserID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "highlight or archived story not found"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "added to highlight"})
+}
+
+// Remove an archived story from a highlight
+func (server *Server) removeHighlightItem(ctx *gin.Context) {
+	highlightID, ok := parseUUIDParam(ctx, ctx.Param("id"), "highlight_id")
+	if !ok {
+		return
+	}
+	archiveID, ok := parseUUIDParam(ctx, ctx.Param("archive_id"), "archive_id")
+	if !ok {
+		return
+	}
+	authPayload := getAuthPayload(ctx)
+
+	err := server.store.RemoveHighlightItem(ctx, db.RemoveHighlightItemParams{
+		HighlightID: highlightID,
+		ArchiveID:   archiveID,
+		UserID:      authPayload.UserID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "removed from highlight"})
+}
+
+// Reorder a highlight's items: archive ids in display order
+type reorderHighlightItemsRequest struct {
+	ArchiveIDs []string `json:"archive_ids" binding:"required,min=1,max=100,dive,uuid"`
+}
+
+func (server *Server) reorderHighlightItems(ctx *gin.Context) {
+	highlightID, ok := parseUUIDParam(ctx, ctx.Param("id"), "highlight_id")
+	if !ok {
+		return
+	}
+
+	var req reorderHighlightItemsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	authPayload := getAuthPayload(ctx)
+
+	err := server.store.ReorderHighlightItems(ctx, db.ReorderHighlightItemsParams{
+		HighlightID: highlightID,
+		UserID:      authPayload.UserID,
+		ArchiveIds:  parseUUIDs(req.ArchiveIDs),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "highlight reordered"})
+}
+
+func (server *Server) respondArchiveError(ctx *gin.Context, err error) {
+	if err == errArchiveNotFound {
+		ctx.JSON(http.StatusNotFound, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+}
@@ -28,6 +28,9 @@ type Hub struct {
 	Register   chan *Client
 	Unregister chan *Client
 	mutex      sync.RWMutex
+
+	// IsMuted reports whether receiver muted sender; typing isn't forwarded if so
+	IsMuted func(receiverID, senderID uuid.UUID) bool
 }
 
 func NewHub() *Hub {
@@ -176,7 +179,9 @@ func (c *Client) ReadPump() {
 					},
 				}
 				typingBytes, _ := json.Marshal(typingMsg)
-				c.Hub.SendToUser(wsMsg.ReceiverID, typingBytes)
+				if c.Hub.IsMuted == nil || !c.Hub.IsMuted(wsMsg.ReceiverID, c.UserID) {
+					c.Hub.SendToUser(wsMsg.ReceiverID, typingBytes)
+				}
 			}
 		}
 	}
@@ -21,6 +21,7 @@ const (
 type updateLocationRequest struct {
 	Latitude  float64 `json:"latitude" binding:"required,min=-90,max=90"`
 	Longitude float64 `json:"longitude" binding:"required,min=-180,max=180"`
+	locationTelemetry
 }
 
 func (server *Server) updateLocation(ctx *gin.Context) {
@@ -39,15 +40,7 @@ func (server *Server) updateLocation(ctx *gin.Context) {
 	}
 
 	// Safety Check: Fake GPS
-	val := server.safety.ValidateUserMovement(ctx, authPayload.UserID.String(), req.Latitude, req.Longitude)
-	if !val.Allowed {
-		if val.ShouldBan {
-			server.store.BanUser(ctx, db.BanUserParams{
-				ID:             authPayload.UserID,
-				IsShadowBanned: true,
-			})
-			log.Warn().Str("user_id", authPayload.UserID.String()).Msg("User shadow-banned for fake GPS")
-		}
+	if !server.checkLocation(ctx, authPayload.UserID, req.sample(req.Latitude, req.Longitude)) {
 		// Return success to maintain illusion, but do NOT save the fake location
 		ctx.JSON(http.StatusOK, gin.H{"status": "updated"})
 		return
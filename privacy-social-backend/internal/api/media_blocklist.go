@@ -0,0 +1,157 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"privacy-social-backend/internal/repository/db"
+	"privacy-social-backend/internal/service/mediahash"
+)
+
+const (
+	defaultMediaHashMaxDistance = 8
+	uploadsURLPrefix            = "/api/uploads/"
+)
+
+var (
+	errMediaBlocked         = errors.New("this image cannot be uploaded")
+	errMediaHashUnavailable = errors.New("no media hash available for this story")
+)
+
+func (server *Server) mediaHashMaxDistance() int {
+	if server.config.MediaHashMaxDistance > 0 {
+		return server.config.MediaHashMaxDistance
+	}
+	return defaultMediaHashMaxDistance
+}
+
+// isMediaBlocked compares image hashes against the blocklist by Hamming distance
+func (server *Server) isMediaBlocked(ctx context.Context, hashes mediahash.Hashes) (bool, error) {
+	entries, err := server.store.ListMediaBlocklistHashes(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	maxDistance := server.mediaHashMaxDistance()
+	for _, e := range entries {
+		blocked := mediahash.Hashes{DHash: uint64(e.Dhash), PHash: uint64(e.Phash)}
+		if hashes.Matches(blocked, maxDistance) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// storyMediaHashes returns the hashes recorded at upload, falling back to
+// hashing the local file for uploads that predate hashing.
+func (server *Server) storyMediaHashes(ctx context.Context, mediaURL string) (mediahash.Hashes, error) {
+	h, err := server.store.GetMediaHash(ctx, mediaURL)
+	if err == nil {
+		return mediahash.Hashes{DHash: uint64(h.Dhash), PHash: uint64(h.Phash)}, nil
+	}
+	if err != sql.ErrNoRows {
+		return mediahash.Hashes{}, err
+	}
+
+	if !strings.HasPrefix(mediaURL, uploadsURLPrefix) {
+		return mediahash.Hashes{}, errMediaHashUnavailable
+	}
+	f, err := os.Open(filepath.Join("uploads", filepath.Base(mediaURL)))
+	if err != nil {
+		return mediahash.Hashes{}, errMediaHashUnavailable
+	}
+	defer f.Close()
+
+	hashes, err := mediahash.Compute(f)
+	if err != nil {
+		return mediahash.Hashes{}, errMediaHashUnavailable
+	}
+	return hashes, nil
+}
+
+// Admin: List Media Blocklist
+type listMediaBlocklistRequest struct {
+	PageID   int32 `form:"page" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=100"`
+}
+
+func (server *Server) listMediaBlocklist(ctx *gin.Context) {
+	var req listMediaBlocklistRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	entries, err := server.store.ListMediaBlocklist(ctx, db.ListMediaBlocklistParams{
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"entries": entries,
+		"page":    req.PageID,
+	})
+}
+
+// Admin: Remove Media Blocklist Entry
+func (server *Server) deleteMediaBlocklistEntry(ctx *gin.Context) {
+	entryID, ok := parseUUIDParam(ctx, ctx.Param("id"), "entry_id")
+	if !ok {
+		return
+	}
+
+	var reason adminReasonQuery
+	if err := ctx.ShouldBindQuery(&reason); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	err := server.store.ExecTx(ctx, func(q *db.Queries) error {
+		entry, err := q.DeleteMediaBlocklistEntry(ctx, entryID)
+		if err != nil {
+			return err
+		}
+
+		return writeAudit(ctx, q, auditEntry{
+			Action:     auditBlocklistDelete,
+			TargetType: auditTargetBlocklist,
+			TargetID:   entryID,
+			Reason:     reason.Reason,
+			Before:     entry,
+		})
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "blocklist entry not found"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "blocklist entry removed"})
+}
+
+// blocklistStoryMedia adds the story's media hash to the blocklist inside a transaction
+func blocklistStoryMedia(ctx context.Context, q *db.Queries, storyID uuid.UUID, hashes mediahash.Hashes, reason string, adminID uuid.UUID) error {
+	_, err := q.AddMediaBlocklistEntry(ctx, db.AddMediaBlocklistEntryParams{
+		Dhash:         int64(hashes.DHash),
+		Phash:         int64(hashes.PHash),
+		Reason:        reason,
+		SourceStoryID: uuid.NullUUID{UUID: storyID, Valid: true},
+		AddedBy:       uuid.NullUUID{UUID: adminID, Valid: true},
+	})
+	return err
+}
@@ -0,0 +1,308 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
+
+	"privacy-social-backend/internal/repository/db"
+	"privacy-social-backend/internal/token"
+)
+
+// Moderation action sources (moderation_actions.source)
+const (
+	moderationSourceGPS     = "auto_gps"
+	moderationSourceReports = "report_threshold"
+	moderationSourceAdmin   = "admin"
+)
+
+// Generic message shown to restricted users. Never reveal the reason or source.
+const accountRestrictedMessage = "Your account is restricted. Some features may be limited. You can submit an appeal for review."
+
+// shadowBanUser bans the user and records why, in one transaction
+func (server *Server) shadowBanUser(ctx context.Context, userID uuid.UUID, source, reason string, evidence interface{}, actorID uuid.NullUUID) error {
+	return server.store.ExecTx(ctx, func(q *db.Queries) error {
+		return applyShadowBan(ctx, q, userID, source, reason, evidence, actorID)
+	})
+}
+
+// applyShadowBan bans the user and records the action within a transaction
+func applyShadowBan(ctx context.Context, q *db.Queries, userID uuid.UUID, source, reason string, evidence interface{}, actorID uuid.NullUUID) error {
+	evidenceJSON, err := json.Marshal(evidence)
+	if err != nil || evidence == nil {
+		evidenceJSON = []byte("{}")
+	}
+
+	_, err = q.BanUser(ctx, db.BanUserParams{
+		ID:             userID,
+		IsShadowBanned: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = q.CreateModerationAction(ctx, db.CreateModerationActionParams{
+		UserID:   userID,
+		Action:   "shadow_ban",
+		Source:   source,
+		Reason:   reason,
+		Evidence: evidenceJSON,
+		ActorID:  actorID,
+	})
+	return err
+}
+
+// liftShadowBan unbans the user and records the decision
+func liftShadowBan(ctx context.Context, q *db.Queries, userID uuid.UUID, source, reason string, actorID uuid.NullUUID) error {
+	_, err := q.BanUser(ctx, db.BanUserParams{
+		ID:             userID,
+		IsShadowBanned: false,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = q.CreateModerationAction(ctx, db.CreateModerationActionParams{
+		UserID:   userID,
+		Action:   "unban",
+		Source:   source,
+		Reason:   reason,
+		Evidence: []byte("{}"),
+		ActorID:  actorID,
+	})
+	return err
+}
+
+// getAccountStatus returns the caller's own standing
+func (server *Server) getAccountStatus(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	user, err := server.store.GetUserByID(ctx, authPayload.UserID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if !user.IsShadowBanned {
+		ctx.JSON(http.StatusOK, gin.H{"status": "active"})
+		return
+	}
+
+	rsp := gin.H{
+		"status":     "restricted",
+		"message":    accountRestrictedMessage,
+		"can_appeal": true,
+	}
+
+	appeal, err := server.store.GetLatestBanAppeal(ctx, authPayload.UserID)
+	if err != nil && err != sql.ErrNoRows {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if err == nil {
+		rsp["appeal"] = gin.H{
+			"status":     appeal.Status,
+			"created_at": appeal.CreatedAt,
+		}
+		rsp["can_appeal"] = appeal.Status != "pending"
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+type createBanAppealRequest struct {
+	Message string `json:"message" binding:"required,max=1000"`
+}
+
+// createBanAppeal files an appeal against the caller's current restriction
+func (server *Server) createBanAppeal(ctx *gin.Context) {
+	var req createBanAppealRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	user, err := server.store.GetUserByID(ctx, authPayload.UserID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if !user.IsShadowBanned {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "account is not restricted"})
+		return
+	}
+
+	// Link the appeal to the ban it contests, if one was recorded
+	var actionID uuid.NullUUID
+	action, err := server.store.GetLatestModerationAction(ctx, db.GetLatestModerationActionParams{
+		UserID: authPayload.UserID,
+		Action: "shadow_ban",
+	})
+	if err == nil {
+		actionID = uuid.NullUUID{UUID: action.ID, Valid: true}
+	} else if err != sql.ErrNoRows {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	appeal, err := server.store.CreateBanAppeal(ctx, db.CreateBanAppealParams{
+		UserID:             authPayload.UserID,
+		ModerationActionID: actionID,
+		Message:            req.Message,
+	})
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "unique_violation" {
+			ctx.JSON(http.StatusConflict, gin.H{"error": "an appeal is already pending"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"status":     appeal.Status,
+		"created_at": appeal.CreatedAt,
+	})
+}
+
+// Admin: List Ban Appeals
+type listBanAppealsRequest struct {
+	Status   string `form:"status" binding:"omitempty,oneof=pending approved rejected"`
+	PageID   int32  `form:"page" binding:"required,min=1"`
+	PageSize int32  `form:"page_size" binding:"required,min=5,max=100"`
+}
+
+func (server *Server) listBanAppeals(ctx *gin.Context) {
+	var req listBanAppealsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if req.Status == "" {
+		req.Status = "pending"
+	}
+
+	appeals, err := server.store.ListBanAppeals(ctx, db.ListBanAppealsParams{
+		Status: req.Status,
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"appeals": appeals,
+		"page":    req.PageID,
+	})
+}
+
+// Admin: Decide Ban Appeal
+type decideBanAppealRequest struct {
+	Approve bool   `json:"approve"`
+	Note    string `json:"note" binding:"max=1000"`
+	Reason  string `json:"reason" binding:"required,min=3,max=500"`
+}
+
+var errAppealNotPending = errors.New("appeal not found or already decided")
+
+func (server *Server) decideBanAppeal(ctx *gin.Context) {
+	appealID, ok := parseUUIDParam(ctx, ctx.Param("id"), "appeal_id")
+	if !ok {
+		return
+	}
+
+	var req decideBanAppealRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	reviewerID := uuid.NullUUID{UUID: authPayload.UserID, Valid: true}
+
+	status := "rejected"
+	if req.Approve {
+		status = "approved"
+	}
+
+	var appeal db.BanAppeal
+	err := server.store.ExecTx(ctx, func(q *db.Queries) error {
+		before, err := q.GetBanAppeal(ctx, appealID)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return errAppealNotPending
+			}
+			return err
+		}
+
+		appeal, err = q.DecideBanAppeal(ctx, db.DecideBanAppealParams{
+			ID:           appealID,
+			Status:       status,
+			ReviewerID:   reviewerID,
+			DecisionNote: toNullString(req.Note),
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return errAppealNotPending
+			}
+			return err
+		}
+
+		err = writeAudit(ctx, q, auditEntry{
+			Action:     auditAppealDecide,
+			TargetType: auditTargetAppeal,
+			TargetID:   appeal.ID,
+			Reason:     req.Reason,
+			Before:     before,
+			After:      appeal,
+		})
+		if err != nil || !req.Approve {
+			return err
+		}
+
+		return liftShadowBan(ctx, q, appeal.UserID, moderationSourceAdmin, "Appeal approved: "+req.Reason, reviewerID)
+	})
+	if err != nil {
+		if err == errAppealNotPending {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	log.Info().
+		Str("appeal_id", appeal.ID.String()).
+		Str("reviewer_id", authPayload.UserID.String()).
+		Str("status", appeal.Status).
+		Msg("Ban appeal decided")
+
+	ctx.JSON(http.StatusOK, appeal)
+}
+
+// Admin: Moderation history for a user (bans, unbans and their evidence)
+func (server *Server) listUserModerationActions(ctx *gin.Context) {
+	userID, ok := parseUUIDParam(ctx, ctx.Param("id"), "user_id")
+	if !ok {
+		return
+	}
+
+	actions, err := server.store.ListModerationActions(ctx, userID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"actions": actions})
+}
@@ -0,0 +1,141 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"privacy-social-backend/internal/repository/db"
+)
+
+// Mute scopes: "user" mutes both stories and messages
+const (
+	muteScopeUser     = "user"
+	muteScopeStories  = "stories"
+	muteScopeMessages = "messages"
+)
+
+// feedResponse is one viewer's nearby feed
+type feedResponse struct {
+	Stories      []StoryResponse `json:"stories"`
+	Count        int             `json:"count"`
+	Message      string          `json:"message"`
+	SearchRadius float64         `json:"search_radius"`
+	NextCursor   string          `json:"next_cursor,omitempty"` // Set per viewer, never cached
+}
+
+// Mute User: hides their stories and/or silences their messages.
+// Nothing changes on the muted user's side, so they can't tell.
+type muteUserRequest struct {
+	UserID        string `json:"user_id" binding:"required,uuid"`
+	Scope         string `json:"scope" binding:"required,oneof=user stories messages"`
+	DurationHours int    `json:"duration_hours" binding:"omitempty,min=1,max=8760"` // Omit to mute until removed
+}
+
+func (server *Server) muteUser(ctx *gin.Context) {
+	var req muteUserRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := getAuthPayload(ctx)
+	mutedID, ok := parseUUIDParam(ctx, req.UserID, "user_id")
+	if !ok {
+		return
+	}
+
+	if mutedID == authPayload.UserID {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "cannot mute yourself"})
+		return
+	}
+
+	if _, err := server.store.GetUserByID(ctx, mutedID); err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	arg := db.UpsertUserMuteParams{
+		MuterID:      authPayload.UserID,
+		MutedID:      mutedID,
+		MuteStories:  req.Scope != muteScopeMessages,
+		MuteMessages: req.Scope != muteScopeStories,
+	}
+	if req.DurationHours > 0 {
+		arg.ExpiresAt = sql.NullTime{Time: time.Now().Add(time.Duration(req.DurationHours) * time.Hour), Valid: true}
+	}
+
+	mute, err := server.store.UpsertUserMute(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.invalidateStoryCaches(ctx, authPayload.UserID)
+
+	ctx.JSON(http.StatusOK, mute)
+}
+
+// Unmute User
+func (server *Server) unmuteUser(ctx *gin.Context) {
+	mutedID, ok := parseUUIDParam(ctx, ctx.Param("id"), "user_id")
+	if !ok {
+		return
+	}
+	authPayload := getAuthPayload(ctx)
+
+	err := server.store.DeleteUserMute(ctx, db.DeleteUserMuteParams{
+		MuterID: authPayload.UserID,
+		MutedID: mutedID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.invalidateStoryCaches(ctx, authPayload.UserID)
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "user unmuted"})
+}
+
+// Get Muted Users (active mutes only)
+func (server *Server) getMutedUsers(ctx *gin.Context) {
+	authPayload := getAuthPayload(ctx)
+
+	mutes, err := server.store.ListUserMutes(ctx, authPayload.UserID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, mutes)
+}
+
+// filterFeedForViewer collapses stories matching the viewer's hidden words.
+// Muted authors are already left out by ListFeedStories.
+func (server *Server) filterFeedForViewer(ctx context.Context, viewerID uuid.UUID, feed *feedResponse) {
+	collapseHiddenCaptions(feed.Stories, viewerID, server.hiddenWordsMatcher(ctx, viewerID))
+}
+
+// conversationMuted reports whether receiver silenced messages from sender.
+// Messages are still delivered; only pushes and notifications are skipped.
+func (server *Server) conversationMuted(ctx context.Context, receiverID, senderID uuid.UUID) bool {
+	muted, err := server.store.IsConversationMuted(ctx, db.IsConversationMutedParams{
+		MuterID: receiverID,
+		MutedID: senderID,
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("failed to check conversation mute")
+		return false
+	}
+	return muted
+}
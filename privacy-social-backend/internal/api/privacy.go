@@ -8,8 +8,11 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog/log"
 
 	"privacy-social-backend/internal/repository/db"
+	"privacy-social-backend/internal/service/safetyalert"
 	"privacy-social-backend/internal/token"
 )
 
@@ -20,6 +23,8 @@ type PrivacySettingResponse struct {
 	WhoCanMessage    string    `json:"who_can_message"`
 	WhoCanSeeStories string    `json:"who_can_see_stories"`
 	ShowLocation     bool      `json:"show_location"`
+	AutoArchive      bool      `json:"auto_archive"`
+	StoryReplies     string    `json:"story_replies"`
 }
 
 func newPrivacySettingResponse(p db.PrivacySetting) PrivacySettingResponse {
@@ -28,6 +33,8 @@ func newPrivacySettingResponse(p db.PrivacySetting) PrivacySettingResponse {
 		WhoCanMessage:    p.WhoCanMessage.String,
 		WhoCanSeeStories: p.WhoCanSeeStories.String,
 		ShowLocation:     p.ShowLocation.Bool,
+		AutoArchive:      p.AutoArchive,
+		StoryReplies:     p.StoryReplies,
 	}
 }
 
@@ -35,6 +42,9 @@ type updatePrivacySettingsRequest struct {
 	WhoCanMessage    string `json:"who_can_message" binding:"oneof=everyone connections nobody"`
 	WhoCanSeeStories string `json:"who_can_see_stories" binding:"oneof=everyone connections nobody"`
 	ShowLocation     *bool  `json:"show_location" binding:"required"`
+	// Omit to keep the current settings
+	AutoArchive  *bool  `json:"auto_archive"`
+	StoryReplies string `json:"story_replies" binding:"omitempty,oneof=everyone connections off"`
 }
 
 func (server *Server) updatePrivacySettings(ctx *gin.Context) {
@@ -46,12 +56,20 @@ func (server *Server) updatePrivacySettings(ctx *gin.Context) {
 
 	payload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
 
-	settings, err := server.store.UpsertPrivacySettings(ctx, db.UpsertPrivacySettingsParams{
+	arg := db.UpsertPrivacySettingsParams{
 		UserID:           payload.UserID,
 		WhoCanMessage:    sql.NullString{String: req.WhoCanMessage, Valid: true},
 		WhoCanSeeStories: sql.NullString{String: req.WhoCanSeeStories, Valid: true},
 		ShowLocation:     sql.NullBool{Bool: *req.ShowLocation, Valid: true},
-	})
+	}
+	if req.AutoArchive != nil {
+		arg.AutoArchive = sql.NullBool{Bool: *req.AutoArchive, Valid: true}
+	}
+	if req.StoryReplies != "" {
+		arg.StoryReplies = sql.NullString{String: req.StoryReplies, Valid: true}
+	}
+
+	settings, err := server.store.UpsertPrivacySettings(ctx, arg)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
@@ -72,6 +90,7 @@ func (server *Server) getPrivacySettings(ctx *gin.Context) {
 				WhoCanMessage:    "connections",
 				WhoCanSeeStories: "connections",
 				ShowLocation:     true,
+				StoryReplies:     "connections",
 			})
 			return
 		}
@@ -107,19 +126,55 @@ func (server *Server) blockUser(ctx *gin.Context) {
 		return
 	}
 
-	_, err := server.store.BlockUser(ctx, db.BlockUserParams{
-		BlockerID: payload.UserID,
-		BlockedID: blockID,
+	// Block and sever the connection (accepted or pending, either direction),
+	// close friends and emergency contacts together
+	err := server.store.ExecTx(ctx, func(q *db.Queries) error {
+		if _, err := q.BlockUser(ctx, db.BlockUserParams{
+			BlockerID: payload.UserID,
+			BlockedID: blockID,
+		}); err != nil {
+			return err
+		}
+		if err := q.DeleteConnection(ctx, db.DeleteConnectionParams{
+			RequesterID: payload.UserID,
+			TargetID:    blockID,
+		}); err != nil {
+			return err
+		}
+		if err := q.DeleteCloseFriendPair(ctx, db.DeleteCloseFriendPairParams{
+			UserID:   payload.UserID,
+			FriendID: blockID,
+		}); err != nil {
+			return err
+		}
+		// Neither side keeps getting the other's safety alerts and last location
+		return q.DeleteEmergencyContactPair(ctx, db.DeleteEmergencyContactPairParams{
+			UserID:      payload.UserID,
+			OtherUserID: blockID,
+		})
 	})
 	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code.Name() {
+			case "unique_violation":
+				// Already blocked: the first block already applied its side effects
+				ctx.JSON(http.StatusOK, gin.H{"message": "user blocked"})
+				return
+			case "foreign_key_violation":
+				ctx.JSON(http.StatusNotFound, gin.H{"error": "user not found"})
+				return
+			}
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	// Invalidate caches
-	server.invalidateProfileCache(payload.UserID)
-	server.invalidateProfileCache(blockID)
-	server.redis.Del(context.Background(), "connections:"+payload.UserID.String())
+	server.invalidateBlockCaches(ctx, payload.UserID, blockID)
+
+	// Both clients drop the other party; the event is the same one a removed
+	// connection would send, so it doesn't tell the blocked user they were blocked
+	server.sendWSNotification(payload.UserID, "connection_removed", gin.H{"user_id": blockID})
+	server.sendWSNotification(blockID, "connection_removed", gin.H{"user_id": payload.UserID})
 
 	ctx.JSON(http.StatusOK, gin.H{"message": "user blocked"})
 }
@@ -142,9 +197,28 @@ func (server *Server) unblockUser(ctx *gin.Context) {
 		return
 	}
 
+	// Crossings, conversations and notifications are filtered by the block at
+	// query time, so dropping the caches makes them visible again. The severed
+	// connection is not restored.
+	server.invalidateBlockCaches(ctx, payload.UserID, targetID)
+
 	ctx.JSON(http.StatusOK, gin.H{"message": "user unblocked"})
 }
 
+// isBlockedEitherWay reports whether either user has blocked the other
+func (server *Server) isBlockedEitherWay(ctx context.Context, userID1, userID2 uuid.UUID) (bool, error) {
+	for _, pair := range [][2]uuid.UUID{{userID1, userID2}, {userID2, userID1}} {
+		blocked, err := server.store.IsUserBlocked(ctx, db.IsUserBlockedParams{
+			BlockerID: pair[0],
+			BlockedID: pair[1],
+		})
+		if err != nil || blocked {
+			return blocked, err
+		}
+	}
+	return false, nil
+}
+
 type BlockedUserResponse struct {
 	ID        uuid.UUID `json:"id"`
 	Username  string    `json:"username"`
@@ -205,9 +279,31 @@ func (server *Server) toggleGhostMode(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, newUserResponse(user))
 }
 
+type panicModeRequest struct {
+	AlertContacts bool `json:"alert_contacts"` // Alert emergency contacts before deleting
+}
+
 func (server *Server) panicMode(ctx *gin.Context) {
 	payload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
 
+	var req panicModeRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+	}
+
+	// Contacts are deleted with the account, so alert them first
+	alerted := 0
+	if req.AlertContacts {
+		sent, err := server.alerts.Send(ctx, payload.UserID, safetyalert.ReasonPanic, "")
+		if err != nil {
+			log.Error().Err(err).Msg("failed to send panic alert")
+		}
+		alerted = sent
+	}
+
 	// Delete all user data
 	err := server.store.DeleteAllUserData(ctx, payload.UserID)
 	if err != nil {
@@ -217,5 +313,5 @@ func (server *Server) panicMode(ctx *gin.Context) {
 
 	// Invalidate token/session would be good here but handled by expiry usually
 
-	ctx.JSON(http.StatusOK, gin.H{"message": "all data deleted"})
+	ctx.JSON(http.StatusOK, gin.H{"message": "all data deleted", "contacts_alerted": alerted})
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/sqlc-dev/pqtype"
 
 	"privacy-social-backend/internal/repository/db"
+	"privacy-social-backend/internal/service/moderation"
 	"privacy-social-backend/internal/token"
 )
 
@@ -44,6 +45,8 @@ type ProfileResponse struct {
 	VisibilityStatus  string     `json:"visibility_status"`
 	WebsiteURL        string     `json:"website_url"`
 	Links             []UserLink `json:"links"`
+	// Per viewer, so never part of the cached profile
+	Highlights []db.ListProfileHighlightsRow `json:"highlights,omitempty"`
 }
 
 func mapProfileResponse(p db.GetUserProfileRow) ProfileResponse {
@@ -92,9 +95,11 @@ func (server *Server) getUserProfile(ctx *gin.Context) {
 	}
 
 	// Track profile view if user is authenticated
+	var viewerID uuid.UUID
 	authPayload, exists := ctx.Get(authorizationPayloadKey)
 	if exists && authPayload != nil {
 		payload := authPayload.(*token.Payload)
+		viewerID = payload.UserID
 		// Don't track self-views
 		if payload.UserID != userID {
 			// Track asynchronously to not block response
@@ -111,9 +116,13 @@ func (server *Server) getUserProfile(ctx *gin.Context) {
 	cacheKey := "profile:" + userID.String()
 	cachedData, err := server.redis.Get(context.Background(), cacheKey).Result()
 	if err == nil && cachedData != "" {
-		ctx.Header("X-Cache", "HIT")
-		ctx.Data(http.StatusOK, "application/json", []byte(cachedData))
-		return
+		var cached ProfileResponse
+		if json.Unmarshal([]byte(cachedData), &cached) == nil {
+			cached.Highlights = server.profileHighlights(ctx, userID, viewerID)
+			ctx.Header("X-Cache", "HIT")
+			ctx.JSON(http.StatusOK, cached)
+			return
+		}
 	}
 
 	profile, err := server.store.GetUserProfile(ctx, userID)
@@ -132,6 +141,8 @@ func (server *Server) getUserProfile(ctx *gin.Context) {
 	responseJSON, _ := json.Marshal(rsp)
 	server.redis.Set(context.Background(), cacheKey, responseJSON, profileCacheTTL)
 
+	rsp.Highlights = server.profileHighlights(ctx, userID, viewerID)
+
 	ctx.Header("X-Cache", "MISS")
 	ctx.JSON(http.StatusOK, rsp)
 }
@@ -185,6 +196,30 @@ func (server *Server) updateProfile(ctx *gin.Context) {
 	}
 
 	payload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	// Content moderation: rejected text fails the whole update,
+	// flagged text is withheld until a moderator approves it
+	type heldField struct {
+		field  string
+		value  string
+		result moderation.Result
+	}
+	var held []heldField
+	for _, f := range []struct{ kind, value string }{
+		{moderation.KindFullName, req.FullName},
+		{moderation.KindUsername, req.Username},
+		{moderation.KindBio, req.Bio},
+	} {
+		result := server.moderateText(ctx, f.kind, payload.UserID, f.value)
+		switch result.Verdict {
+		case moderation.VerdictReject:
+			ctx.JSON(http.StatusUnprocessableEntity, errorResponse(errContentRejected))
+			return
+		case moderation.VerdictFlag:
+			held = append(held, heldField{field: f.kind, value: f.value, result: result})
+		}
+	}
+
 	arg := db.UpdateUserProfileParams{
 		ID:                payload.UserID,
 		FullName:          sql.NullString{String: req.FullName, Valid: req.FullName != ""},
@@ -202,7 +237,33 @@ func (server *Server) updateProfile(ctx *gin.Context) {
 		arg.Links = pqtype.NullRawMessage{RawMessage: linksJSON, Valid: true}
 	}
 
-	user, err := server.store.UpdateUserProfile(ctx, arg)
+	pendingReview := make([]string, len(held))
+	for i, h := range held {
+		switch h.field {
+		case moderation.KindFullName:
+			arg.FullName = sql.NullString{}
+		case moderation.KindUsername:
+			arg.Username = sql.NullString{}
+		case moderation.KindBio:
+			arg.Bio = sql.NullString{}
+		}
+		pendingReview[i] = h.field
+	}
+
+	var user db.UpdateUserProfileRow
+	err := server.store.ExecTx(ctx, func(q *db.Queries) error {
+		var err error
+		user, err = q.UpdateUserProfile(ctx, arg)
+		if err != nil {
+			return err
+		}
+		for _, h := range held {
+			if err := holdForReview(ctx, q, flagContentProfile, payload.UserID, payload.UserID, h.field, h.value, h.result); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
@@ -225,6 +286,7 @@ func (server *Server) updateProfile(ctx *gin.Context) {
 		WebsiteUrl        string     `json:"website_url"`
 		Links             []UserLink `json:"links"`
 		CreatedAt         time.Time  `json:"created_at"`
+		PendingReview     []string   `json:"pending_review,omitempty"`
 	}{
 		ID:                user.ID,
 		Username:          user.Username,
@@ -236,6 +298,7 @@ func (server *Server) updateProfile(ctx *gin.Context) {
 		ProfileVisibility: user.ProfileVisibility.String,
 		WebsiteUrl:        user.WebsiteUrl.String,
 		CreatedAt:         user.CreatedAt,
+		PendingReview:     pendingReview,
 	}
 
 	if len(user.Links) > 0 {
@@ -10,11 +10,19 @@ import (
 	"privacy-social-backend/internal/repository/db"
 )
 
+// Report target types (reports.target_type)
+const (
+	reportTargetUser    = "user"
+	reportTargetStory   = "story"
+	reportTargetMessage = "message"
+)
+
 type createReportRequest struct {
-	TargetUserID  string `json:"target_user_id"`  // Optional
-	TargetStoryID string `json:"target_story_id"` // Optional
-	Reason        string `json:"reason" binding:"required,oneof=spam abuse inappropriate other"`
-	Description   string `json:"description"`
+	TargetUserID    string `json:"target_user_id"`    // Optional
+	TargetStoryID   string `json:"target_story_id"`   // Optional
+	TargetMessageID string `json:"target_message_id"` // Optional, must be a message you received
+	Reason          string `json:"reason" binding:"required,oneof=spam abuse inappropriate other"`
+	Description     string `json:"description"`
 }
 
 func (server *Server) createReport(ctx *gin.Context) {
@@ -46,23 +54,66 @@ func (server *Server) createReport(ctx *gin.Context) {
 		targetStoryID = uuid.NullUUID{UUID: id, Valid: true}
 	}
 
+	var msg db.Message
+	if req.TargetMessageID != "" {
+		id, err := uuid.Parse(req.TargetMessageID)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid target_message_id"})
+			return
+		}
+		if targetStoryID.Valid {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "report a story or a message, not both"})
+			return
+		}
+
+		msg, err = server.store.GetMessage(ctx, id)
+		if err != nil && err != sql.ErrNoRows {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		// Only the receiver can report a message
+		if err == sql.ErrNoRows || msg.ReceiverID != authPayload.UserID {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "message not found"})
+			return
+		}
+		targetUserID = uuid.NullUUID{UUID: msg.SenderID, Valid: true}
+	}
+
 	// Validate that at least one target is present
 	if !targetUserID.Valid && !targetStoryID.Valid {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "must target user or story"})
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "must target user, story or message"})
 		return
 	}
 
-	report, err := server.store.CreateReport(ctx, db.CreateReportParams{
-		ReporterID:    authPayload.UserID,
+	arg := db.CreateReportParams{
+		ReporterID:    uuid.NullUUID{UUID: authPayload.UserID, Valid: true},
 		TargetUserID:  targetUserID,
 		TargetStoryID: targetStoryID,
 		Reason:        db.ReportReason(req.Reason),
 		Description:   sql.NullString{String: req.Description, Valid: req.Description != ""},
-	})
+		TargetType:    reportTargetUser,
+	}
+
+	var report db.Report
+	var err error
+	switch {
+	case msg.ID != uuid.Nil:
+		arg.TargetType = reportTargetMessage
+		arg.TargetMessageID = uuid.NullUUID{UUID: msg.ID, Valid: true}
+		report, err = server.createMessageReport(ctx, arg, msg)
+	case targetStoryID.Valid:
+		arg.TargetType = reportTargetStory
+		report, err = server.store.CreateReport(ctx, arg)
+	default:
+		report, err = server.store.CreateReport(ctx, arg)
+	}
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
+	// Automatic thresholds (hide story, restrict user)
+	server.escalateReport(ctx, report)
+
 	ctx.JSON(http.StatusCreated, report)
 }
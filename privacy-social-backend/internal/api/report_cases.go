@@ -0,0 +1,353 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"privacy-social-backend/internal/repository/db"
+	"privacy-social-backend/internal/token"
+)
+
+// Report case statuses (reports.status)
+const (
+	reportStatusOpen      = "open"
+	reportStatusInReview  = "in_review"
+	reportStatusActioned  = "actioned"
+	reportStatusDismissed = "dismissed"
+)
+
+// Outcome actions linked to a report (report_outcomes.action)
+const (
+	reportOutcomeStoryRemoved = "story_removed"
+	reportOutcomeUserWarned   = "user_warned"
+	reportOutcomeUserBanned   = "user_banned"
+)
+
+// reportOutcomeOrder applies account actions before the story is removed,
+// while its owner can still be looked up
+var reportOutcomeOrder = []string{reportOutcomeUserWarned, reportOutcomeUserBanned, reportOutcomeStoryRemoved}
+
+var (
+	errReportClosed          = errors.New("report is already closed")
+	errReportOutcomeMismatch = errors.New("actioned reports need at least one outcome, dismissed reports none")
+	errReportTargetGone      = errors.New("report target no longer exists")
+	errAssigneeNotModerator  = errors.New("assignee must be a moderator or admin")
+)
+
+func isReportClosed(status string) bool {
+	return status == reportStatusActioned || status == reportStatusDismissed
+}
+
+func hasOutcome(outcomes []db.ReportOutcome, action string) bool {
+	for _, o := range outcomes {
+		if o.Action == action {
+			return true
+		}
+	}
+	return false
+}
+
+// reportTarget is what a report's outcomes were applied to
+type reportTarget struct {
+	userID         uuid.NullUUID // Affected user
+	removedGeohash string        // Geohash of the story taken down, if one was
+}
+
+// applyReportOutcomes carries out the requested actions against the report's
+// target and links each one to the report
+func applyReportOutcomes(ctx context.Context, q *db.Queries, report db.Report, actions []string, reason string, actorID uuid.NullUUID) (reportTarget, []db.ReportOutcome, error) {
+	target := reportTarget{userID: report.TargetUserID}
+	var story db.GetStoryByIDRow
+	storyExists := false
+	if report.TargetStoryID.Valid {
+		var err error
+		story, err = q.GetStoryByID(ctx, report.TargetStoryID.UUID)
+		if err != nil && err != sql.ErrNoRows {
+			return target, nil, err
+		}
+		if err == nil {
+			storyExists = true
+			if !target.userID.Valid {
+				target.userID = uuid.NullUUID{UUID: story.UserID, Valid: true}
+			}
+		}
+	}
+
+	requested := make(map[string]bool, len(actions))
+	for _, a := range actions {
+		requested[a] = true
+	}
+
+	var outcomes []db.ReportOutcome
+	for _, action := range reportOutcomeOrder {
+		if !requested[action] {
+			continue
+		}
+
+		switch action {
+		case reportOutcomeStoryRemoved:
+			if !storyExists {
+				return target, nil, errReportTargetGone
+			}
+			if err := removeStory(ctx, q, report.TargetStoryID.UUID); err != nil {
+				return target, nil, err
+			}
+			target.removedGeohash = story.Geohash
+		case reportOutcomeUserWarned:
+			if !target.userID.Valid {
+				return target, nil, errReportTargetGone
+			}
+			_, err := q.CreateNotification(ctx, db.CreateNotificationParams{
+				UserID:  target.userID.UUID,
+				Type:    db.NotificationTypeSafetyWarning,
+				Title:   "Community guidelines",
+				Message: "Something you shared was reported and found to break our community guidelines. Repeated violations can lead to restrictions on your account.",
+			})
+			if err != nil {
+				return target, nil, err
+			}
+		case reportOutcomeUserBanned:
+			if !target.userID.Valid {
+				return target, nil, errReportTargetGone
+			}
+			evidence := gin.H{"report_id": report.ID}
+			if err := applyShadowBan(ctx, q, target.userID.UUID, moderationSourceAdmin, reason, evidence, actorID); err != nil {
+				return target, nil, err
+			}
+		}
+
+		outcome, err := q.CreateReportOutcome(ctx, db.CreateReportOutcomeParams{
+			ReportID: report.ID,
+			Action:   action,
+			ActorID:  actorID,
+		})
+		if err != nil {
+			return target, nil, err
+		}
+		outcomes = append(outcomes, outcome)
+	}
+
+	return target, outcomes, nil
+}
+
+// notifyReporter lets the reporter know their report was looked at,
+// without revealing what happened to the other account
+func (server *Server) notifyReporter(ctx context.Context, report db.Report) {
+	if !report.ReporterID.Valid {
+		return // System report
+	}
+
+	_, err := server.store.CreateNotification(ctx, db.CreateNotificationParams{
+		UserID:  report.ReporterID.UUID,
+		Type:    db.NotificationTypeReportReviewed,
+		Title:   "Report reviewed",
+		Message: "Thanks for letting us know. We've reviewed your report.",
+	})
+	if err != nil {
+		log.Error().Err(err).Msg("failed to create report reviewed notification")
+	}
+}
+
+// Admin: Get Report with notes, outcomes and message evidence
+func (server *Server) getReportCase(ctx *gin.Context) {
+	reportID, ok := parseUUIDParam(ctx, ctx.Param("id"), "report_id")
+	if !ok {
+		return
+	}
+
+	report, err := server.store.GetReport(ctx, reportID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "report not found"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	notes, err := server.store.ListReportNotes(ctx, reportID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	outcomes, err := server.store.ListReportOutcomes(ctx, reportID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	evidence, err := server.store.ListReportEvidence(ctx, reportID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"report":   report,
+		"notes":    notes,
+		"outcomes": outcomes,
+		"evidence": evidence,
+	})
+}
+
+// Admin: Update Report status, priority or assignee
+type updateReportRequest struct {
+	Status     string `json:"status" binding:"omitempty,oneof=open in_review"`
+	Priority   string `json:"priority" binding:"omitempty,oneof=low normal high urgent"`
+	AssigneeID string `json:"assignee_id" binding:"omitempty,uuid"`
+	Unassign   bool   `json:"unassign"`
+	Reason     string `json:"reason" binding:"required,min=3,max=500"`
+}
+
+func (server *Server) updateReport(ctx *gin.Context) {
+	reportID, ok := parseUUIDParam(ctx, ctx.Param("id"), "report_id")
+	if !ok {
+		return
+	}
+
+	var req updateReportRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.UpdateReportTriageParams{
+		ID:            reportID,
+		Status:        toNullString(req.Status),
+		Priority:      toNullString(req.Priority),
+		ClearAssignee: req.Unassign,
+	}
+
+	if req.AssigneeID != "" && !req.Unassign {
+		assignee, err := server.store.GetUserByID(ctx, uuid.MustParse(req.AssigneeID))
+		if err != nil {
+			if err == sql.ErrNoRows {
+				ctx.JSON(http.StatusUnprocessableEntity, errorResponse(errAssigneeNotModerator))
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		if assignee.Role != db.UserRoleAdmin && assignee.Role != db.UserRoleModerator {
+			ctx.JSON(http.StatusUnprocessableEntity, errorResponse(errAssigneeNotModerator))
+			return
+		}
+		arg.AssigneeID = uuid.NullUUID{UUID: assignee.ID, Valid: true}
+	}
+
+	var report db.Report
+	err := server.store.ExecTx(ctx, func(q *db.Queries) error {
+		before, err := q.GetReport(ctx, reportID)
+		if err != nil {
+			return err
+		}
+		if isReportClosed(before.Status) {
+			return errReportClosed
+		}
+
+		// Picking up an open case starts the review
+		if arg.AssigneeID.Valid && !arg.Status.Valid && before.Status == reportStatusOpen {
+			arg.Status = toNullString(reportStatusInReview)
+		}
+
+		report, err = q.UpdateReportTriage(ctx, arg)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return errReportClosed
+			}
+			return err
+		}
+
+		return writeAudit(ctx, q, auditEntry{
+			Action:     auditReportUpdate,
+			TargetType: auditTargetReport,
+			TargetID:   reportID,
+			Reason:     req.Reason,
+			Before:     before,
+			After:      report,
+		})
+	})
+	if err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "report not found"})
+		case errReportClosed:
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+// Admin: Add internal note to a Report
+type addReportNoteRequest struct {
+	Body string `json:"body" binding:"required,min=1,max=2000"`
+}
+
+func (server *Server) addReportNote(ctx *gin.Context) {
+	reportID, ok := parseUUIDParam(ctx, ctx.Param("id"), "report_id")
+	if !ok {
+		return
+	}
+
+	var req addReportNoteRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+
+	var note db.ReportNote
+	err := server.store.ExecTx(ctx, func(q *db.Queries) error {
+		report, err := q.GetReport(ctx, reportID)
+		if err != nil {
+			return err
+		}
+		// Closed cases are final; reopen by filing a new report
+		if isReportClosed(report.Status) {
+			return errReportClosed
+		}
+
+		note, err = q.CreateReportNote(ctx, db.CreateReportNoteParams{
+			ReportID: reportID,
+			AuthorID: uuid.NullUUID{UUID: authPayload.UserID, Valid: true},
+			Body:     req.Body,
+		})
+		if err != nil {
+			return err
+		}
+
+		// The note is its own justification
+		return writeAudit(ctx, q, auditEntry{
+			Action:     auditReportNote,
+			TargetType: auditTargetReport,
+			TargetID:   reportID,
+			Reason:     req.Body,
+			After:      note,
+		})
+	})
+	if err != nil {
+		switch err {
+		case sql.ErrNoRows:
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "report not found"})
+		case errReportClosed:
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, note)
+}
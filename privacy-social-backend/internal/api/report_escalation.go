@@ -0,0 +1,498 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"privacy-social-backend/internal/config"
+	"privacy-social-backend/internal/repository/db"
+	"privacy-social-backend/internal/token"
+)
+
+// Escalation targets and actions (report_escalations.target_type / action)
+const (
+	escalationTargetStory = "story"
+	escalationTargetUser  = "user"
+
+	escalationHideStory    = "hide_story"
+	escalationRestrictUser = "restrict_user"
+)
+
+// Reporter weighting. A default-trust, established reporter counts as 1.
+const (
+	defaultTrustLevel  = 10
+	minReporterWeight  = 0.25
+	maxReporterWeight  = 1.5
+	newReporterAge     = 24 * time.Hour
+	newReporterPenalty = 0.5
+	escalationFlagRule = "report_threshold"
+)
+
+// ReportEscalationPolicy holds the thresholds for automatic report handling.
+// Thresholds are weighted report counts, not raw counts.
+type ReportEscalationPolicy struct {
+	StoryThreshold   float64
+	StoryWindow      time.Duration
+	UserThreshold    float64
+	UserWindow       time.Duration
+	TrustPenalty     int32
+	RestrictDuration time.Duration
+}
+
+func NewReportEscalationPolicy(config config.Config) ReportEscalationPolicy {
+	policy := ReportEscalationPolicy{
+		StoryThreshold:   config.ReportStoryThreshold,
+		StoryWindow:      config.ReportStoryWindow,
+		UserThreshold:    config.ReportUserThreshold,
+		UserWindow:       config.ReportUserWindow,
+		TrustPenalty:     config.ReportTrustPenalty,
+		RestrictDuration: config.ReportRestrictDuration,
+	}
+
+	if policy.StoryThreshold <= 0 {
+		policy.StoryThreshold = 3
+	}
+	if policy.StoryWindow <= 0 {
+		policy.StoryWindow = time.Hour
+	}
+	if policy.UserThreshold <= 0 {
+		policy.UserThreshold = 5
+	}
+	if policy.UserWindow <= 0 {
+		policy.UserWindow = 7 * 24 * time.Hour
+	}
+	if policy.TrustPenalty <= 0 {
+		policy.TrustPenalty = 3
+	}
+	if policy.RestrictDuration <= 0 {
+		policy.RestrictDuration = 72 * time.Hour
+	}
+
+	return policy
+}
+
+// reporterReport is one open report with what is needed to weight its reporter
+type reporterReport struct {
+	ReportID          uuid.UUID
+	ReporterID        uuid.UUID
+	TrustLevel        int32
+	ReporterCreatedAt time.Time
+	FalseReports      int32
+}
+
+// reporterWeight scales a report by the reporter's trust, account age, and
+// how many of their past reports fed escalations that admins reverted.
+func reporterWeight(r reporterReport, now time.Time) float64 {
+	w := float64(r.TrustLevel) / defaultTrustLevel
+	if w < minReporterWeight {
+		w = minReporterWeight
+	}
+	if w > maxReporterWeight {
+		w = maxReporterWeight
+	}
+	if now.Sub(r.ReporterCreatedAt) < newReporterAge {
+		w *= newReporterPenalty
+	}
+	return w / float64(1+r.FalseReports)
+}
+
+// weighReports counts each distinct reporter once. Reports by the owner are ignored.
+func weighReports(reports []reporterReport, ownerID uuid.UUID, now time.Time) (total float64, reporters int, reportIDs []uuid.UUID) {
+	seen := make(map[uuid.UUID]bool)
+	for _, r := range reports {
+		if r.ReporterID == ownerID {
+			continue
+		}
+		reportIDs = append(reportIDs, r.ReportID)
+		if seen[r.ReporterID] {
+			continue
+		}
+		seen[r.ReporterID] = true
+		reporters++
+		total += reporterWeight(r, now)
+	}
+	return total, reporters, reportIDs
+}
+
+// escalationSince returns the start of the counting window. An escalation is
+// active until an admin reverts it or, for restrictions, until they expire.
+// Reports that fed an earlier escalation don't count again.
+func (server *Server) escalationSince(ctx context.Context, targetType string, targetID uuid.UUID, window time.Duration) (time.Time, bool, error) {
+	now := time.Now().UTC()
+	since := now.Add(-window)
+
+	latest, err := server.store.GetLatestReportEscalation(ctx, db.GetLatestReportEscalationParams{
+		TargetType: targetType,
+		TargetID:   targetID,
+	})
+	if err == sql.ErrNoRows {
+		return since, false, nil
+	}
+	if err != nil {
+		return since, false, err
+	}
+
+	var ended time.Time
+	switch {
+	case latest.RevertedAt.Valid:
+		ended = latest.RevertedAt.Time
+	case latest.ExpiresAt.Valid && !latest.ExpiresAt.Time.After(now):
+		ended = latest.CreatedAt
+	default:
+		return since, true, nil // Still in force
+	}
+	if ended.After(since) {
+		since = ended
+	}
+	return since, false, nil
+}
+
+// escalateReport applies the escalation rules after a new report.
+// Failures are logged; the report itself has already been stored.
+func (server *Server) escalateReport(ctx context.Context, report db.Report) {
+	ownerID := report.TargetUserID.UUID
+
+	if report.TargetStoryID.Valid {
+		story, err := server.store.GetStoryByID(ctx, report.TargetStoryID.UUID)
+		if err != nil {
+			if err != sql.ErrNoRows {
+				log.Error().Err(err).Msg("report escalation: failed to load story")
+			}
+			return
+		}
+		ownerID = story.UserID
+
+		if err := server.escalateStory(ctx, story); err != nil {
+			log.Error().Err(err).Str("story_id", story.ID.String()).Msg("report escalation failed for story")
+		}
+	}
+
+	if ownerID == uuid.Nil {
+		return
+	}
+	if err := server.escalateUser(ctx, ownerID); err != nil {
+		log.Error().Err(err).Str("user_id", ownerID.String()).Msg("report escalation failed for user")
+	}
+}
+
+// escalateStory hides a story pending review once enough weighted reporters flag it
+func (server *Server) escalateStory(ctx context.Context, story db.GetStoryByIDRow) error {
+	since, active, err := server.escalationSince(ctx, escalationTargetStory, story.ID, server.escalation.StoryWindow)
+	if err != nil || active {
+		return err
+	}
+
+	rows, err := server.store.ListStoryReportWeights(ctx, db.ListStoryReportWeightsParams{
+		TargetStoryID: uuid.NullUUID{UUID: story.ID, Valid: true},
+		CreatedAt:     since,
+	})
+	if err != nil {
+		return err
+	}
+	reports := make([]reporterReport, len(rows))
+	for i, r := range rows {
+		reports[i] = reporterReport{
+			ReportID:          r.ReportID,
+			ReporterID:        r.ReporterID.UUID,
+			TrustLevel:        r.TrustLevel,
+			ReporterCreatedAt: r.ReporterCreatedAt,
+			FalseReports:      r.FalseReports,
+		}
+	}
+
+	total, reporters, reportIDs := weighReports(reports, story.UserID, time.Now().UTC())
+	if total < server.escalation.StoryThreshold {
+		return nil
+	}
+
+	err = server.store.ExecTx(ctx, func(q *db.Queries) error {
+		// A pending flag hides the story from everyone but its author
+		flag, err := q.CreateContentFlag(ctx, db.CreateContentFlagParams{
+			ContentType: flagContentStory,
+			ContentID:   story.ID,
+			UserID:      story.UserID,
+			Field:       "caption",
+			Content:     story.Caption.String,
+			Rule:        escalationFlagRule,
+			Score:       float32(total),
+		})
+		if err != nil {
+			return err
+		}
+
+		escalation, err := q.CreateReportEscalation(ctx, db.CreateReportEscalationParams{
+			TargetType:      escalationTargetStory,
+			TargetID:        story.ID,
+			UserID:          story.UserID,
+			Action:          escalationHideStory,
+			WeightedReports: float32(total),
+			Reporters:       int32(reporters),
+			ContentFlagID:   uuid.NullUUID{UUID: flag.ID, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+		return linkEscalationReports(ctx, q, escalation.ID, reportIDs)
+	})
+	if err != nil {
+		return err
+	}
+
+	server.invalidateFeedCache(story.Geohash)
+
+	log.Warn().
+		Str("story_id", story.ID.String()).
+		Float64("weighted_reports", total).
+		Int("reporters", reporters).
+		Msg("Story hidden pending review after reports")
+	return nil
+}
+
+// escalateUser lowers trust and restricts crossings and DMs after repeated reports
+func (server *Server) escalateUser(ctx context.Context, userID uuid.UUID) error {
+	since, active, err := server.escalationSince(ctx, escalationTargetUser, userID, server.escalation.UserWindow)
+	if err != nil || active {
+		return err
+	}
+
+	rows, err := server.store.ListUserReportWeights(ctx, db.ListUserReportWeightsParams{
+		TargetUserID: uuid.NullUUID{UUID: userID, Valid: true},
+		CreatedAt:    since,
+	})
+	if err != nil {
+		return err
+	}
+	reports := make([]reporterReport, len(rows))
+	for i, r := range rows {
+		reports[i] = reporterReport{
+			ReportID:          r.ReportID,
+			ReporterID:        r.ReporterID.UUID,
+			TrustLevel:        r.TrustLevel,
+			ReporterCreatedAt: r.ReporterCreatedAt,
+			FalseReports:      r.FalseReports,
+		}
+	}
+
+	total, reporters, reportIDs := weighReports(reports, userID, time.Now().UTC())
+	if total < server.escalation.UserThreshold {
+		return nil
+	}
+
+	expiresAt := time.Now().UTC().Add(server.escalation.RestrictDuration)
+	err = server.store.ExecTx(ctx, func(q *db.Queries) error {
+		user, err := q.GetUserByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		// Record what was actually taken so a revert restores it exactly
+		penalty := server.escalation.TrustPenalty
+		if user.TrustLevel < penalty {
+			penalty = user.TrustLevel
+		}
+		if _, err := q.AdjustUserTrust(ctx, db.AdjustUserTrustParams{ID: userID, TrustLevel: -penalty}); err != nil {
+			return err
+		}
+
+		for _, kind := range []string{restrictionCrossings, restrictionMessages} {
+			_, err := q.CreateUserRestriction(ctx, db.CreateUserRestrictionParams{
+				UserID:    userID,
+				Kind:      kind,
+				Source:    moderationSourceReports,
+				Reason:    "Repeated reports from other users",
+				ExpiresAt: expiresAt,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		escalation, err := q.CreateReportEscalation(ctx, db.CreateReportEscalationParams{
+			TargetType:      escalationTargetUser,
+			TargetID:        userID,
+			UserID:          userID,
+			Action:          escalationRestrictUser,
+			WeightedReports: float32(total),
+			Reporters:       int32(reporters),
+			TrustPenalty:    penalty,
+			ExpiresAt:       sql.NullTime{Time: expiresAt, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+		return linkEscalationReports(ctx, q, escalation.ID, reportIDs)
+	})
+	if err != nil {
+		return err
+	}
+
+	server.recalculateTrust(ctx, userID)
+	server.invalidateCrossingsCache(userID)
+
+	log.Warn().
+		Str("user_id", userID.String()).
+		Float64("weighted_reports", total).
+		Int("reporters", reporters).
+		Msg("User restricted after repeated reports")
+	return nil
+}
+
+func linkEscalationReports(ctx context.Context, q *db.Queries, escalationID uuid.UUID, reportIDs []uuid.UUID) error {
+	for _, id := range reportIDs {
+		err := q.AddReportToEscalation(ctx, db.AddReportToEscalationParams{
+			EscalationID: escalationID,
+			ReportID:     id,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Admin: List Report Escalations
+type listReportEscalationsRequest struct {
+	PageID   int32 `form:"page" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=100"`
+}
+
+func (server *Server) listReportEscalations(ctx *gin.Context) {
+	var req listReportEscalationsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	escalations, err := server.store.ListReportEscalations(ctx, db.ListReportEscalationsParams{
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"escalations": escalations,
+		"page":        req.PageID,
+	})
+}
+
+var errEscalationNotActive = errors.New("escalation not found or already reverted")
+
+type revertReportEscalationRequest struct {
+	Reason string `json:"reason" binding:"required,min=3,max=500"`
+}
+
+// Admin: Revert Report Escalation
+// Unhides the story, or restores trust and lifts report-based restrictions.
+// The reports involved then count against their reporters' weight.
+func (server *Server) revertReportEscalation(ctx *gin.Context) {
+	escalationID, ok := parseUUIDParam(ctx, ctx.Param("id"), "escalation_id")
+	if !ok {
+		return
+	}
+
+	var req revertReportEscalationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	adminID := uuid.NullUUID{UUID: authPayload.UserID, Valid: true}
+
+	var escalation db.ReportEscalation
+	var storyGeohash string // Of the story shown again, if it's still there
+	err := server.store.ExecTx(ctx, func(q *db.Queries) error {
+		var err error
+		escalation, err = q.RevertReportEscalation(ctx, db.RevertReportEscalationParams{
+			ID:         escalationID,
+			RevertedBy: adminID,
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				return errEscalationNotActive
+			}
+			return err
+		}
+
+		err = writeAudit(ctx, q, auditEntry{
+			Action:     auditEscalationRevert,
+			TargetType: auditTargetEscalation,
+			TargetID:   escalation.ID,
+			Reason:     req.Reason,
+			After:      escalation,
+		})
+		if err != nil {
+			return err
+		}
+
+		switch escalation.Action {
+		case escalationHideStory:
+			story, err := q.GetStoryByID(ctx, escalation.TargetID)
+			if err == sql.ErrNoRows {
+				return nil // Deleted since: nothing to show again
+			}
+			if err != nil {
+				return err
+			}
+			storyGeohash = story.Geohash
+			if !escalation.ContentFlagID.Valid {
+				return nil
+			}
+			_, err = q.ReviewContentFlag(ctx, db.ReviewContentFlagParams{
+				ID:         escalation.ContentFlagID.UUID,
+				Status:     "approved",
+				ReviewerID: adminID,
+			})
+			if err == sql.ErrNoRows {
+				return nil // Already reviewed
+			}
+			return err
+		case escalationRestrictUser:
+			if escalation.TrustPenalty > 0 {
+				_, err := q.AdjustUserTrust(ctx, db.AdjustUserTrustParams{
+					ID:         escalation.UserID,
+					TrustLevel: escalation.TrustPenalty,
+				})
+				if err != nil {
+					return err
+				}
+			}
+			return q.LiftUserRestrictionsBySource(ctx, db.LiftUserRestrictionsBySourceParams{
+				UserID: escalation.UserID,
+				Source: moderationSourceReports,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		if err == errEscalationNotActive {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	switch escalation.Action {
+	case escalationHideStory:
+		if storyGeohash != "" {
+			server.invalidateFeedCache(storyGeohash)
+		}
+	case escalationRestrictUser:
+		server.invalidateCrossingsCache(escalation.UserID)
+	}
+	// Reverted reports no longer count as upheld
+	server.recalculateTrust(ctx, escalation.UserID)
+
+	ctx.JSON(http.StatusOK, escalation)
+}
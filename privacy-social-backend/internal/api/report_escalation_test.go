@@ -0,0 +1,142 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"privacy-social-backend/internal/config"
+	"privacy-social-backend/internal/repository/db"
+	mockdb "privacy-social-backend/internal/repository/mock"
+)
+
+func TestWeighReports(t *testing.T) {
+	now := time.Now()
+	established := now.Add(-30 * 24 * time.Hour)
+	owner := uuid.New()
+
+	reporter := func(trust, falseReports int32, createdAt time.Time) reporterReport {
+		return reporterReport{
+			ReportID:          uuid.New(),
+			ReporterID:        uuid.New(),
+			TrustLevel:        trust,
+			ReporterCreatedAt: createdAt,
+			FalseReports:      falseReports,
+		}
+	}
+
+	t.Run("DistinctEstablishedReporters", func(t *testing.T) {
+		a := reporter(defaultTrustLevel, 0, established)
+		again := a
+		again.ReportID = uuid.New()
+		b := reporter(defaultTrustLevel, 0, established)
+
+		total, reporters, ids := weighReports([]reporterReport{a, again, b}, owner, now)
+		require.InDelta(t, 2.0, total, 0.001)
+		require.Equal(t, 2, reporters)
+		require.Len(t, ids, 3)
+	})
+
+	t.Run("OwnerReportsIgnored", func(t *testing.T) {
+		self := reporter(defaultTrustLevel, 0, established)
+		self.ReporterID = owner
+
+		total, reporters, ids := weighReports([]reporterReport{self}, owner, now)
+		require.Zero(t, total)
+		require.Zero(t, reporters)
+		require.Empty(t, ids)
+	})
+
+	t.Run("MassFalseReportingDiscounted", func(t *testing.T) {
+		var reports []reporterReport
+		for i := 0; i < 5; i++ {
+			// Fresh, low-trust accounts with reverted reports behind them
+			reports = append(reports, reporter(0, 2, now.Add(-time.Hour)))
+		}
+
+		total, reporters, _ := weighReports(reports, owner, now)
+		require.Equal(t, 5, reporters)
+		require.Less(t, total, NewReportEscalationPolicy(config.Config{}).StoryThreshold)
+	})
+}
+
+func TestEscalationSince(t *testing.T) {
+	userID := uuid.New()
+	now := time.Now().UTC()
+	window := 7 * 24 * time.Hour
+	created := now.Add(-4 * 24 * time.Hour)
+
+	testCases := []struct {
+		name       string
+		escalation db.ReportEscalation
+		err        error
+		wantActive bool
+		wantSince  time.Time
+	}{
+		{
+			name:      "NeverEscalated",
+			err:       sql.ErrNoRows,
+			wantSince: now.Add(-window),
+		},
+		{
+			name: "RestrictionInForce",
+			escalation: db.ReportEscalation{
+				CreatedAt: now.Add(-time.Hour),
+				ExpiresAt: sql.NullTime{Time: now.Add(71 * time.Hour), Valid: true},
+			},
+			wantActive: true,
+		},
+		{
+			// Can escalate again, on reports made after the last escalation
+			name: "RestrictionExpired",
+			escalation: db.ReportEscalation{
+				CreatedAt: created,
+				ExpiresAt: sql.NullTime{Time: created.Add(72 * time.Hour), Valid: true},
+			},
+			wantSince: created,
+		},
+		{
+			name: "Reverted",
+			escalation: db.ReportEscalation{
+				CreatedAt:  created,
+				ExpiresAt:  sql.NullTime{Time: now.Add(time.Hour), Valid: true},
+				RevertedAt: sql.NullTime{Time: now.Add(-time.Hour), Valid: true},
+			},
+			wantSince: now.Add(-time.Hour),
+		},
+		{
+			name:       "HiddenStoryUntilReverted",
+			escalation: db.ReportEscalation{CreatedAt: created},
+			wantActive: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				GetLatestReportEscalation(gomock.Any(), db.GetLatestReportEscalationParams{
+					TargetType: escalationTargetUser,
+					TargetID:   userID,
+				}).
+				Times(1).
+				Return(tc.escalation, tc.err)
+
+			server := newTestServer(t, store)
+			since, active, err := server.escalationSince(context.Background(), escalationTargetUser, userID, window)
+			require.NoError(t, err)
+			require.Equal(t, tc.wantActive, active)
+			if !tc.wantActive {
+				require.WithinDuration(t, tc.wantSince, since, time.Second)
+			}
+		})
+	}
+}
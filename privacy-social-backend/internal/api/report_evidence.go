@@ -0,0 +1,200 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"privacy-social-backend/internal/config"
+	"privacy-social-backend/internal/repository/db"
+)
+
+const (
+	defaultEvidenceDir             = "evidence"
+	defaultEvidenceRetention       = 180 * 24 * time.Hour
+	defaultEvidenceContextMessages = 5
+)
+
+// EvidencePolicy controls snapshots taken when a message is reported
+type EvidencePolicy struct {
+	Dir             string        // Moderator-only media store, never served statically
+	Retention       time.Duration // Kept longer while the case is still open
+	ContextMessages int32         // Messages kept on each side of the reported one
+}
+
+// NewEvidencePolicy builds the policy from config, falling back to defaults
+func NewEvidencePolicy(config config.Config) EvidencePolicy {
+	p := EvidencePolicy{
+		Dir:             config.EvidenceDir,
+		Retention:       config.EvidenceRetention,
+		ContextMessages: config.EvidenceContextMessages,
+	}
+	if p.Dir == "" {
+		p.Dir = defaultEvidenceDir
+	}
+	if p.Retention <= 0 {
+		p.Retention = defaultEvidenceRetention
+	}
+	if p.ContextMessages <= 0 {
+		p.ContextMessages = defaultEvidenceContextMessages
+	}
+	return p
+}
+
+// createMessageReport files the report and snapshots the message with its
+// surrounding conversation, so the evidence outlives expiry and deletion
+func (server *Server) createMessageReport(ctx context.Context, arg db.CreateReportParams, msg db.Message) (db.Report, error) {
+	messages, err := server.store.ListMessageContext(ctx, db.ListMessageContextParams{
+		UserA:       msg.SenderID,
+		UserB:       msg.ReceiverID,
+		Around:      msg.CreatedAt,
+		ContextSize: server.evidence.ContextMessages,
+	})
+	if err != nil {
+		return db.Report{}, err
+	}
+	if !containsMessage(messages, msg.ID) {
+		messages = append([]db.Message{msg}, messages...)
+	}
+
+	// Media is copied before the transaction; copies are removed if it fails
+	copies := make(map[uuid.UUID]string)
+	for _, m := range messages {
+		if !m.MediaUrl.Valid {
+			continue
+		}
+		path, err := server.copyEvidenceMedia(m.MediaUrl.String)
+		if err != nil {
+			log.Error().Err(err).Str("message_id", m.ID.String()).Msg("failed to copy message media to evidence store")
+			continue
+		}
+		if path != "" {
+			copies[m.ID] = path
+		}
+	}
+
+	expiresAt := time.Now().Add(server.evidence.Retention)
+
+	var report db.Report
+	err = server.store.ExecTx(ctx, func(q *db.Queries) error {
+		var err error
+		report, err = q.CreateReport(ctx, arg)
+		if err != nil {
+			return err
+		}
+
+		for _, m := range messages {
+			path, copied := copies[m.ID]
+			_, err := q.CreateReportEvidence(ctx, db.CreateReportEvidenceParams{
+				ReportID:         report.ID,
+				MessageID:        m.ID,
+				SenderID:         m.SenderID,
+				ReceiverID:       m.ReceiverID,
+				Content:          m.Content,
+				MediaType:        m.MediaType,
+				OriginalMediaUrl: m.MediaUrl,
+				MediaPath:        sql.NullString{String: path, Valid: copied},
+				IsReported:       m.ID == msg.ID,
+				SentAt:           m.CreatedAt,
+				ExpiresAt:        expiresAt,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		for _, path := range copies {
+			os.Remove(path)
+		}
+		return db.Report{}, err
+	}
+
+	return report, nil
+}
+
+func containsMessage(messages []db.Message, id uuid.UUID) bool {
+	for _, m := range messages {
+		if m.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// copyEvidenceMedia copies a local upload into the evidence store and returns its path.
+// Media hosted elsewhere can't be copied; only its URL is kept.
+func (server *Server) copyEvidenceMedia(mediaURL string) (string, error) {
+	if !strings.HasPrefix(mediaURL, uploadsURLPrefix) {
+		return "", nil
+	}
+
+	src, err := os.Open(filepath.Join("uploads", filepath.Base(mediaURL)))
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	if err := os.MkdirAll(server.evidence.Dir, 0700); err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(server.evidence.Dir, uuid.New().String()+filepath.Ext(mediaURL))
+	dst, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		os.Remove(path)
+		return "", err
+	}
+	if err := dst.Close(); err != nil {
+		os.Remove(path)
+		return "", err
+	}
+	return path, nil
+}
+
+// Admin: Serve evidence media for a report
+func (server *Server) getReportEvidenceMedia(ctx *gin.Context) {
+	reportID, ok := parseUUIDParam(ctx, ctx.Param("id"), "report_id")
+	if !ok {
+		return
+	}
+	evidenceID, ok := parseUUIDParam(ctx, ctx.Param("evidence_id"), "evidence_id")
+	if !ok {
+		return
+	}
+
+	evidence, err := server.store.GetReportEvidence(ctx, db.GetReportEvidenceParams{
+		ID:       evidenceID,
+		ReportID: reportID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "evidence not found"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if !evidence.MediaPath.Valid {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "no media stored for this evidence"})
+		return
+	}
+
+	ctx.Header("Cache-Control", "no-store")
+	ctx.File(evidence.MediaPath.String)
+}
@@ -8,6 +8,9 @@ import (
 func (server *Server) setupRouter() {
 	router := gin.Default()
 
+	// Request IDs for logs and the moderation audit
+	router.Use(requestIDMiddleware())
+
 	// CORS Middleware
 	router.Use(corsMiddleware())
 
@@ -34,7 +37,7 @@ func (server *Server) setupRouter() {
 	authRoutes.POST("/location/ping", server.locationRateLimiter(), server.updateLocation)
 	// Stories
 	authRoutes.GET("/feed", server.getFeed)
-	authRoutes.POST("/stories", server.storyRateLimiter(), server.createStory)
+	authRoutes.POST("/stories", server.storyRateLimiter(), server.storyTrustLimiter(), server.createStory)
 	authRoutes.GET("/stories/:id", server.getStory)
 	authRoutes.PUT("/stories/:id", server.updateStory)
 	authRoutes.DELETE("/stories/:id", server.deleteUserStory)
@@ -46,6 +49,22 @@ func (server *Server) setupRouter() {
 	authRoutes.GET("/stories/archived", server.getArchivedStories)
 	authRoutes.DELETE("/stories/archived/:id", server.deleteArchivedStory)
 
+	// Scheduled Stories
+	authRoutes.GET("/stories/scheduled", server.listScheduledStories)
+	authRoutes.PUT("/stories/scheduled/:id", server.updateScheduledStory)
+	authRoutes.DELETE("/stories/scheduled/:id", server.cancelScheduledStory)
+
+	// Highlights (collections of archived stories shown on the profile)
+	authRoutes.GET("/highlights", server.listHighlights)
+	authRoutes.POST("/highlights", server.createHighlight)
+	authRoutes.PUT("/highlights/order", server.reorderHighlights)
+	authRoutes.GET("/highlights/:id", server.getHighlight)
+	authRoutes.PUT("/highlights/:id", server.updateHighlight)
+	authRoutes.DELETE("/highlights/:id", server.deleteHighlight)
+	authRoutes.POST("/highlights/:id/items", server.addHighlightItem)
+	authRoutes.PUT("/highlights/:id/items/order", server.reorderHighlightItems)
+	authRoutes.DELETE("/highlights/:id/items/:archive_id", server.removeHighlightItem)
+
 	authRoutes.GET("/connections", server.listConnections)
 	authRoutes.GET("/connections/suggested", server.getSuggestedConnections)
 	authRoutes.GET("/connections/requests", server.listPendingRequests)
@@ -65,6 +84,8 @@ func (server *Server) setupRouter() {
 	authRoutes.GET("/messages", server.messageRateLimiter(), server.getChatHistory)
 	authRoutes.POST("/messages", server.messageRateLimiter(), server.sendMessage)
 	authRoutes.GET("/messages/unread-count", server.getUnreadMessageCount)
+	authRoutes.GET("/messages/hidden", server.listHiddenMessages)
+	authRoutes.PUT("/messages/hidden/:id/restore", server.restoreHiddenMessage)
 	authRoutes.PUT("/messages/read/:userId", server.markConversationRead)
 	authRoutes.DELETE("/messages/:id", server.deleteMessage)
 	authRoutes.PUT("/messages/:id", server.editMessage)
@@ -81,23 +102,52 @@ func (server *Server) setupRouter() {
 	authRoutes.POST("/profile/boost", server.boostProfile)
 	authRoutes.PUT("/account/email", server.updateUserEmail)
 	authRoutes.PUT("/account/password", server.updateUserPassword)
+	authRoutes.GET("/account/status", server.getAccountStatus)
+	authRoutes.POST("/account/appeal", server.createBanAppeal)
 
 	// Privacy features
 	authRoutes.GET("/privacy", server.getPrivacySettings)
 	authRoutes.PUT("/privacy", server.updatePrivacySettings)
+	authRoutes.GET("/privacy/hidden-words", server.listHiddenWords)
+	authRoutes.POST("/privacy/hidden-words", server.addHiddenWord)
+	authRoutes.DELETE("/privacy/hidden-words/:id", server.deleteHiddenWord)
 	authRoutes.POST("/users/block", server.blockUser)
 	authRoutes.DELETE("/users/block/:id", server.unblockUser)
 	authRoutes.GET("/users/blocked", server.getBlockedUsers)
+	authRoutes.POST("/users/mute", server.muteUser)
+	authRoutes.DELETE("/users/mute/:id", server.unmuteUser)
+	authRoutes.GET("/users/muted", server.getMutedUsers)
+	authRoutes.GET("/close-friends", server.listCloseFriends)
+	authRoutes.POST("/close-friends", server.addCloseFriend)
+	authRoutes.DELETE("/close-friends/:id", server.removeCloseFriend)
+	authRoutes.GET("/stories/hidden-from", server.listStoriesHiddenFrom)
+	authRoutes.POST("/stories/hidden-from", server.hideStoriesFrom)
+	authRoutes.DELETE("/stories/hidden-from/:id", server.unhideStoriesFrom)
 	authRoutes.PUT("/location/ghost-mode", server.toggleGhostMode)
 	authRoutes.POST("/location/panic", server.panicMode)
 
+	// Meetup safety
+	authRoutes.GET("/safety/contacts", server.listEmergencyContacts)
+	authRoutes.POST("/safety/contacts", server.addEmergencyContact)
+	authRoutes.DELETE("/safety/contacts/:id", server.deleteEmergencyContact)
+	authRoutes.POST("/safety/checkins", server.startCheckin)
+	authRoutes.GET("/safety/checkins/active", server.getActiveCheckin)
+	authRoutes.POST("/safety/checkins/confirm", server.confirmCheckin)
+	authRoutes.POST("/safety/checkins/cancel", server.cancelCheckin)
+
 	// Story engagement
 	authRoutes.POST("/stories/:id/view", server.viewStory)
 	authRoutes.GET("/stories/:id/viewers", server.getStoryViewers)
+	authRoutes.POST("/stories/:id/items/:item_id/view", server.viewStoryItem)
+	authRoutes.GET("/stories/:id/items/stats", server.getStoryItemStats)
 	authRoutes.POST("/stories/:id/react", server.reactToStory)
 	authRoutes.DELETE("/stories/:id/react", server.deleteStoryReaction)
 	authRoutes.GET("/stories/:id/reactions", server.getStoryReactions)
 	authRoutes.POST("/stories/share", server.shareStory)
+	authRoutes.POST("/stories/:id/reply", server.messageRateLimiter(), server.replyToStory)
+	authRoutes.POST("/stories/:id/stickers/:sticker_id/respond", server.respondToSticker)
+	authRoutes.GET("/stories/:id/stickers/:sticker_id/results", server.getStickerResults)
+	authRoutes.GET("/stories/:id/stickers/:sticker_id/responses", server.listStickerResponses)
 
 	// Activity & Visibility
 	authRoutes.GET("/activity/status", server.getActivityStatus)
@@ -116,10 +166,26 @@ func (server *Server) setupRouter() {
 	adminRoutes.GET("/users", server.listUsers)
 	adminRoutes.POST("/users/ban", server.banUser)
 	adminRoutes.DELETE("/users/:id", server.deleteUser)
+	adminRoutes.GET("/users/:id/location-strikes", server.listLocationStrikes)
+	adminRoutes.GET("/users/:id/moderation", server.listUserModerationActions)
+	adminRoutes.GET("/appeals", server.listBanAppeals)
+	adminRoutes.PUT("/appeals/:id/decide", server.decideBanAppeal)
+	adminRoutes.GET("/flags", server.listContentFlags)
+	adminRoutes.PUT("/flags/:id/review", server.reviewContentFlag)
+	adminRoutes.GET("/escalations", server.listReportEscalations)
+	adminRoutes.POST("/escalations/:id/revert", server.revertReportEscalation)
+	adminRoutes.GET("/media-blocklist", server.listMediaBlocklist)
+	adminRoutes.DELETE("/media-blocklist/:id", server.deleteMediaBlocklistEntry)
+	adminRoutes.GET("/audit", server.listModerationAudit)
 	adminRoutes.GET("/stats", server.getStats)
 	adminRoutes.GET("/reports", server.listReports)
+	adminRoutes.GET("/reports/:id", server.getReportCase)
+	adminRoutes.PUT("/reports/:id", server.updateReport)
+	adminRoutes.POST("/reports/:id/notes", server.addReportNote)
+	adminRoutes.GET("/reports/:id/evidence/:evidence_id/media", server.getReportEvidenceMedia)
 	adminRoutes.PUT("/reports/:id/resolve", server.resolveReport)
 	adminRoutes.GET("/stories", server.listAllStories)
+	adminRoutes.GET("/feed/explain", server.explainFeed)
 	adminRoutes.DELETE("/stories/:id", server.deleteStory)
 
 	server.router = router
@@ -2,87 +2,448 @@ package api
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"strconv"
 	"time"
 
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+
+	"privacy-social-backend/internal/config"
+	"privacy-social-backend/internal/repository"
+	"privacy-social-backend/internal/repository/db"
+	"privacy-social-backend/internal/service/safetyalert"
 )
 
 const (
 	// MaxSpeedKmH is 1000 km/h (approx jet speed). Anything faster is definitely fake.
 	MaxSpeedKmH = 1000.0
 	// Key prefix for last location
-	lastLocationKeyPrefix = "safety:last_loc:"
+	lastLocationKeyPrefix = safetyalert.LastLocationKeyPrefix
+	// Key prefix for the recent accepted points (Redis list, newest first)
+	locationHistoryKeyPrefix = "safety:history:"
+	locationHistorySize      = 5
+	locationHistoryTTL       = 24 * time.Hour
+
+	// Movement below this distance is treated as GPS jitter, not travel
+	minSpeedCheckDistanceKm = 1.0
+
+	// Restriction kinds (user_restrictions.kind)
+	restrictionCrossings = "crossings"
+	restrictionMessages  = "messages"
 )
 
-type ValidationResult struct {
-	Allowed   bool
-	Reason    string
-	ShouldBan bool
+// isRestricted reports whether a feature restriction is active. Lookup errors fail open.
+func (server *Server) isRestricted(ctx context.Context, userID uuid.UUID, kind string) bool {
+	restricted, err := server.store.HasActiveRestriction(ctx, db.HasActiveRestrictionParams{
+		UserID: userID,
+		Kind:   kind,
+	})
+	if err != nil {
+		log.Error().Err(err).Str("kind", kind).Msg("failed to check user restriction")
+		return false
+	}
+	return restricted
 }
 
-// SafetyMonitor handles safety checks like Fake GPS
-type SafetyMonitor struct {
-	redis *redis.Client
+// errUnverifiedLocation is deliberately generic so clients can't probe the scoring
+var errUnverifiedLocation = errors.New("unable to verify location")
+
+// Signal weights. A single strong signal (speed, teleport, mock provider)
+// reaches the default strike score on its own; weak ones need company.
+const (
+	speedSignalWeight     = 1.0
+	teleportSignalWeight  = 1.0
+	mockProviderWeight    = 1.0
+	clockSkewWeight       = 0.5
+	accuracySignalWeight  = 0.4
+	exactCoordinateWeight = 0.3
+)
+
+// GPSAction is the graduated response applied to a location sample
+type GPSAction string
+
+const (
+	GPSActionAllow     GPSAction = "allow"
+	GPSActionWarn      GPSAction = "warn"
+	GPSActionDrop      GPSAction = "drop"
+	GPSActionRestrict  GPSAction = "restrict"
+	GPSActionShadowBan GPSAction = "shadow_ban"
+)
+
+// GPSPolicy holds the thresholds of the fake GPS scoring engine
+type GPSPolicy struct {
+	MaxSpeedKmH        float64
+	TeleportDistanceKm float64
+	TeleportWindow     time.Duration
+	MaxClockSkew       time.Duration
+	StrikeScore        float64
+	StrikeWindow       time.Duration
+	DropStrikes        int64
+	RestrictStrikes    int64
+	BanStrikes         int64
+	RestrictDuration   time.Duration
 }
 
-func NewSafetyMonitor(rdb *redis.Client) *SafetyMonitor {
-	return &SafetyMonitor{redis: rdb}
+// NewGPSPolicy builds the policy from config, falling back to defaults for unset values
+func NewGPSPolicy(config config.Config) GPSPolicy {
+	policy := GPSPolicy{
+		MaxSpeedKmH:        config.GPSMaxSpeedKmH,
+		TeleportDistanceKm: config.GPSTeleportDistanceKm,
+		TeleportWindow:     config.GPSTeleportWindow,
+		MaxClockSkew:       config.GPSMaxClockSkew,
+		StrikeScore:        config.GPSStrikeScore,
+		StrikeWindow:       config.GPSStrikeWindow,
+		DropStrikes:        config.GPSDropStrikes,
+		RestrictStrikes:    config.GPSRestrictStrikes,
+		BanStrikes:         config.GPSBanStrikes,
+		RestrictDuration:   config.GPSRestrictDuration,
+	}
+
+	if policy.MaxSpeedKmH <= 0 {
+		policy.MaxSpeedKmH = MaxSpeedKmH
+	}
+	if policy.TeleportDistanceKm <= 0 {
+		policy.TeleportDistanceKm = 50
+	}
+	if policy.TeleportWindow <= 0 {
+		policy.TeleportWindow = 30 * time.Minute
+	}
+	if policy.MaxClockSkew <= 0 {
+		policy.MaxClockSkew = 5 * time.Minute
+	}
+	if policy.StrikeScore <= 0 {
+		policy.StrikeScore = 1.0
+	}
+	if policy.StrikeWindow <= 0 {
+		policy.StrikeWindow = 30 * 24 * time.Hour
+	}
+	if policy.DropStrikes <= 0 {
+		policy.DropStrikes = 2
+	}
+	if policy.RestrictStrikes <= 0 {
+		policy.RestrictStrikes = 4
+	}
+	if policy.BanStrikes <= 0 {
+		policy.BanStrikes = 6
+	}
+	if policy.RestrictDuration <= 0 {
+		policy.RestrictDuration = 7 * 24 * time.Hour
+	}
+
+	return policy
 }
 
-// ValidateUserMovement checks if the user moved typically fast
-func (s *SafetyMonitor) ValidateUserMovement(ctx context.Context, userID string, newLat, newLng float64) ValidationResult {
-	key := lastLocationKeyPrefix + userID
+// LocationSample is a coordinate claim plus the client telemetry sent with it
+type LocationSample struct {
+	Latitude   float64
+	Longitude  float64
+	Accuracy   *float64  // Client-reported accuracy in meters, if any
+	IsMock     bool      // Client OS reported a mock location provider
+	ClientTime time.Time // Client clock at capture time, zero if unknown
+}
 
-	// Get last location
-	res, err := s.redis.HGetAll(ctx, key).Result()
-	if err != nil || len(res) == 0 {
-		// First ping or expired, just save new location
-		s.saveLastLocation(ctx, key, newLat, newLng)
-		return ValidationResult{Allowed: true}
+// LocationSignal is one suspicious observation contributing to the score
+type LocationSignal struct {
+	Name   string  `json:"name"`
+	Weight float64 `json:"weight"`
+	Detail string  `json:"detail"`
+}
+
+// LocationVerdict is the outcome of evaluating a location sample
+type LocationVerdict struct {
+	Action  GPSAction
+	Score   float64
+	Signals []LocationSignal
+	// Escalated is set on the strike that first reaches Action's threshold
+	Escalated bool
+}
+
+// Accepted reports whether the sample may be used by the caller
+func (v LocationVerdict) Accepted() bool {
+	return v.Action == GPSActionAllow || v.Action == GPSActionWarn
+}
+
+type locationPoint struct {
+	Lat  float64   `json:"lat"`
+	Lng  float64   `json:"lng"`
+	Time time.Time `json:"time"`
+}
+
+// score combines all signals for a sample against the user's recent points (newest first)
+func (p GPSPolicy) score(sample LocationSample, history []locationPoint, now time.Time) (float64, []LocationSignal) {
+	var signals []LocationSignal
+
+	// Speed since the last accepted point
+	if len(history) > 0 {
+		last := history[0]
+		distKm := haversineKm(last.Lat, last.Lng, sample.Latitude, sample.Longitude)
+		hours := now.Sub(last.Time).Hours()
+		if distKm >= minSpeedCheckDistanceKm && hours > 0 {
+			if speed := distKm / hours; speed > p.MaxSpeedKmH {
+				signals = append(signals, LocationSignal{
+					Name:   "speed",
+					Weight: speedSignalWeight,
+					Detail: "Speed limit exceeded (" + formatFloat(speed) + " km/h)",
+				})
+			}
+		}
 	}
 
-	// Parse last location
-	lastLat := parseFloat(res["lat"])
-	lastLng := parseFloat(res["lng"])
-	lastTime, _ := time.Parse(time.RFC3339, res["time"])
+	// Teleport and return: a far excursion followed by a jump back to where the user was
+	for i := 1; i < len(history); i++ {
+		anchor := history[i]
+		if now.Sub(anchor.Time) > p.TeleportWindow {
+			break
+		}
+		if haversineKm(anchor.Lat, anchor.Lng, sample.Latitude, sample.Longitude) > p.TeleportDistanceKm/10 {
+			continue
+		}
+		for _, excursion := range history[:i] {
+			if haversineKm(anchor.Lat, anchor.Lng, excursion.Lat, excursion.Lng) > p.TeleportDistanceKm {
+				signals = append(signals, LocationSignal{
+					Name:   "teleport_return",
+					Weight: teleportSignalWeight,
+					Detail: "returned to a previous point after a " + formatFloat(haversineKm(anchor.Lat, anchor.Lng, excursion.Lat, excursion.Lng)) + " km jump",
+				})
+				break
+			}
+		}
+		break
+	}
+
+	// Hand-typed coordinates rarely carry more than 3 decimals; real fixes do
+	if isCoarseCoordinate(sample.Latitude) && isCoarseCoordinate(sample.Longitude) {
+		signals = append(signals, LocationSignal{
+			Name:   "exact_coordinates",
+			Weight: exactCoordinateWeight,
+			Detail: "coordinates have 3 or fewer decimals",
+		})
+	}
+
+	// Mock providers typically report perfect (0-1m) accuracy
+	if sample.Accuracy != nil && *sample.Accuracy < 1 {
+		signals = append(signals, LocationSignal{
+			Name:   "accuracy",
+			Weight: accuracySignalWeight,
+			Detail: "reported accuracy " + formatFloat(*sample.Accuracy) + " m",
+		})
+	}
 
+	if sample.IsMock {
+		signals = append(signals, LocationSignal{
+			Name:   "mock_provider",
+			Weight: mockProviderWeight,
+			Detail: "client reported a mock location provider",
+		})
+	}
+
+	if !sample.ClientTime.IsZero() {
+		if skew := now.Sub(sample.ClientTime); skew > p.MaxClockSkew || skew < -p.MaxClockSkew {
+			signals = append(signals, LocationSignal{
+				Name:   "clock_skew",
+				Weight: clockSkewWeight,
+				Detail: "client clock off by " + skew.Round(time.Second).String(),
+			})
+		}
+	}
+
+	var total float64
+	for _, s := range signals {
+		total += s.Weight
+	}
+	return total, signals
+}
+
+// actionFor maps the number of strikes in the window to a graduated response
+func (p GPSPolicy) actionFor(strikes int64) GPSAction {
+	switch {
+	case strikes >= p.BanStrikes:
+		return GPSActionShadowBan
+	case strikes >= p.RestrictStrikes:
+		return GPSActionRestrict
+	case strikes >= p.DropStrikes:
+		return GPSActionDrop
+	default:
+		return GPSActionWarn
+	}
+}
+
+// SafetyMonitor handles safety checks like Fake GPS
+type SafetyMonitor struct {
+	redis  *redis.Client
+	store  repository.Store
+	policy GPSPolicy
+}
+
+func NewSafetyMonitor(rdb *redis.Client, store repository.Store, policy GPSPolicy) *SafetyMonitor {
+	return &SafetyMonitor{redis: rdb, store: store, policy: policy}
+}
+
+// EvaluateLocation scores a location sample and records a strike when it looks fake
+func (s *SafetyMonitor) EvaluateLocation(ctx context.Context, userID uuid.UUID, sample LocationSample) LocationVerdict {
 	now := time.Now()
+	history := s.loadHistory(ctx, userID)
+
+	score, signals := s.policy.score(sample, history, now)
+	verdict := LocationVerdict{Action: GPSActionAllow, Score: score, Signals: signals}
+
+	if score >= s.policy.StrikeScore {
+		strikes, err := s.store.CountLocationStrikesSince(ctx, db.CountLocationStrikesSinceParams{
+			UserID: userID,
+			Since:  now.Add(-s.policy.StrikeWindow),
+		})
+		if err != nil {
+			log.Error().Err(err).Msg("failed to count location strikes")
+		}
+		verdict.Action = s.policy.actionFor(strikes + 1)
+		verdict.Escalated = verdict.Action != s.policy.actionFor(strikes)
 
-	// Calculate distance (Haversine)
-	distKm := haversineKm(lastLat, lastLng, newLat, newLng)
-	timeDiffHours := now.Sub(lastTime).Hours()
+		signalsJSON, _ := json.Marshal(signals)
+		_, err = s.store.CreateLocationStrike(ctx, db.CreateLocationStrikeParams{
+			UserID:  userID,
+			Score:   float32(score),
+			Signals: signalsJSON,
+			Action:  string(verdict.Action),
+			Geohash: truncatedGeohash(sample.Latitude, sample.Longitude, 5),
+		})
+		if err != nil {
+			log.Error().Err(err).Msg("failed to record location strike")
+		}
+	}
 
-	if timeDiffHours <= 0 {
-		// Same timestamp or clock skew? Allow but update
-		s.saveLastLocation(ctx, key, newLat, newLng)
-		return ValidationResult{Allowed: true}
+	if verdict.Accepted() {
+		s.saveLastLocation(ctx, userID, sample.Latitude, sample.Longitude, now)
 	}
 
-	speed := distKm / timeDiffHours
+	return verdict
+}
 
-	if speed > MaxSpeedKmH {
-		return ValidationResult{
-			Allowed:   false,
-			Reason:    "Speed limit exceeded (" + formatFloat(speed) + " km/h)",
-			ShouldBan: true,
+func (s *SafetyMonitor) loadHistory(ctx context.Context, userID uuid.UUID) []locationPoint {
+	raw, err := s.redis.LRange(ctx, locationHistoryKeyPrefix+userID.String(), 0, locationHistorySize-1).Result()
+	if err == nil && len(raw) > 0 {
+		history := make([]locationPoint, 0, len(raw))
+		for _, item := range raw {
+			var p locationPoint
+			if json.Unmarshal([]byte(item), &p) == nil {
+				history = append(history, p)
+			}
 		}
+		return history
 	}
 
-	// Valid, update last location
-	s.saveLastLocation(ctx, key, newLat, newLng)
-	return ValidationResult{Allowed: true}
+	// Fall back to the single last known point
+	res, err := s.redis.HGetAll(ctx, lastLocationKeyPrefix+userID.String()).Result()
+	if err != nil || len(res) == 0 {
+		return nil
+	}
+	lastTime, _ := time.Parse(time.RFC3339, res["time"])
+	return []locationPoint{{Lat: parseFloat(res["lat"]), Lng: parseFloat(res["lng"]), Time: lastTime}}
 }
 
-func (s *SafetyMonitor) saveLastLocation(ctx context.Context, key string, lat, lng float64) {
+func (s *SafetyMonitor) saveLastLocation(ctx context.Context, userID uuid.UUID, lat, lng float64, now time.Time) {
+	key := lastLocationKeyPrefix + userID.String()
 	s.redis.HSet(ctx, key, map[string]interface{}{
 		"lat":  lat,
 		"lng":  lng,
-		"time": time.Now().Format(time.RFC3339),
+		"time": now.Format(time.RFC3339),
 	})
 	s.redis.Expire(ctx, key, 24*time.Hour)
+
+	point, _ := json.Marshal(locationPoint{Lat: lat, Lng: lng, Time: now})
+	historyKey := locationHistoryKeyPrefix + userID.String()
+	s.redis.LPush(ctx, historyKey, point)
+	s.redis.LTrim(ctx, historyKey, 0, locationHistorySize-1)
+	s.redis.Expire(ctx, historyKey, locationHistoryTTL)
+}
+
+// checkLocation runs the fake GPS policy for a coordinate-bearing request and
+// applies the graduated response. It returns false if the point must not be used.
+func (server *Server) checkLocation(ctx context.Context, userID uuid.UUID, sample LocationSample) bool {
+	verdict := server.safety.EvaluateLocation(ctx, userID, sample)
+	if verdict.Action == GPSActionAllow {
+		return true
+	}
+
+	signalNames := make([]string, len(verdict.Signals))
+	for i, s := range verdict.Signals {
+		signalNames[i] = s.Name
+	}
+	log.Warn().
+		Str("user_id", userID.String()).
+		Str("action", string(verdict.Action)).
+		Float64("score", verdict.Score).
+		Strs("signals", signalNames).
+		Msg("Suspicious location detected")
+
+	switch verdict.Action {
+	case GPSActionWarn:
+		_, err := server.store.CreateNotification(ctx, db.CreateNotificationParams{
+			UserID:  userID,
+			Type:    db.NotificationTypeSafetyWarning,
+			Title:   "Location check",
+			Message: "We couldn't verify your recent location. Disable any location spoofing apps to keep using nearby features.",
+		})
+		if err != nil {
+			log.Error().Err(err).Msg("failed to create safety warning notification")
+		}
+	case GPSActionRestrict:
+		if !verdict.Escalated {
+			break
+		}
+		_, err := server.store.CreateUserRestriction(ctx, db.CreateUserRestrictionParams{
+			UserID:    userID,
+			Kind:      restrictionCrossings,
+			Source:    "gps",
+			Reason:    "Repeated fake GPS strikes",
+			ExpiresAt: time.Now().UTC().Add(server.safety.policy.RestrictDuration),
+		})
+		if err != nil {
+			log.Error().Err(err).Msg("failed to restrict crossings")
+		}
+	case GPSActionShadowBan:
+		// Later strikes in the same tier must not re-ban (or re-ban after an approved appeal)
+		if !verdict.Escalated {
+			break
+		}
+		evidence := gin.H{"score": verdict.Score, "signals": verdict.Signals}
+		err := server.shadowBanUser(ctx, userID, moderationSourceGPS, "Repeated fake GPS strikes", evidence, uuid.NullUUID{})
+		if err != nil {
+			log.Error().Err(err).Msg("failed to shadow-ban user")
+		} else {
+			log.Warn().Str("user_id", userID.String()).Msg("User shadow-banned for fake GPS")
+		}
+	}
+
+	// Strikes lower trust
+	server.recalculateTrust(ctx, userID)
+
+	return verdict.Accepted()
+}
+
+// locationTelemetry carries the client-side signals consumed by the fake GPS policy
+type locationTelemetry struct {
+	Accuracy        *float64 `json:"accuracy" form:"accuracy" binding:"omitempty,min=0"`
+	IsMock          bool     `json:"is_mock" form:"is_mock"`
+	ClientTimestamp int64    `json:"client_timestamp" form:"client_timestamp"` // Unix millis
+}
+
+func (t locationTelemetry) sample(lat, lng float64) LocationSample {
+	sample := LocationSample{
+		Latitude:  lat,
+		Longitude: lng,
+		Accuracy:  t.Accuracy,
+		IsMock:    t.IsMock,
+	}
+	if t.ClientTimestamp > 0 {
+		sample.ClientTime = time.UnixMilli(t.ClientTimestamp)
+	}
+	return sample
 }
 
 // -- Helpers --
@@ -100,6 +461,12 @@ func haversineKm(lat1, lon1, lat2, lon2 float64) float64 {
 	return R * c
 }
 
+// isCoarseCoordinate reports whether a coordinate has 3 or fewer decimals
+func isCoarseCoordinate(v float64) bool {
+	scaled := v * 1000
+	return math.Abs(scaled-math.Round(scaled)) < 1e-6
+}
+
 func parseFloat(s string) float64 {
 	f, _ := strconv.ParseFloat(s, 64)
 	return f
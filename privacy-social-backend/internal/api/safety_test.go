@@ -0,0 +1,187 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"privacy-social-backend/internal/config"
+	"privacy-social-backend/internal/repository/db"
+	mockdb "privacy-social-backend/internal/repository/mock"
+)
+
+func TestGPSPolicyScore(t *testing.T) {
+	policy := NewGPSPolicy(config.Config{})
+	now := time.Now()
+	perfect := 0.0
+
+	testCases := []struct {
+		name        string
+		sample      LocationSample
+		history     []locationPoint
+		wantSignals []string
+		wantStrike  bool
+	}{
+		{
+			name:   "FirstPing",
+			sample: LocationSample{Latitude: 12.971598, Longitude: 77.594562},
+		},
+		{
+			name:    "NormalWalk",
+			sample:  LocationSample{Latitude: 12.972598, Longitude: 77.594562},
+			history: []locationPoint{{Lat: 12.971598, Lng: 77.594562, Time: now.Add(-5 * time.Minute)}},
+		},
+		{
+			name:        "ImpossibleSpeed",
+			sample:      LocationSample{Latitude: 28.613939, Longitude: 77.209021},
+			history:     []locationPoint{{Lat: 12.971598, Lng: 77.594562, Time: now.Add(-10 * time.Minute)}},
+			wantSignals: []string{"speed"},
+			wantStrike:  true,
+		},
+		{
+			name:   "TeleportAndReturn",
+			sample: LocationSample{Latitude: 12.971598, Longitude: 77.594562},
+			history: []locationPoint{
+				{Lat: 13.971598, Lng: 77.594562, Time: now.Add(-20 * time.Minute)},
+				{Lat: 12.971598, Lng: 77.594562, Time: now.Add(-25 * time.Minute)},
+			},
+			wantSignals: []string{"teleport_return"},
+			wantStrike:  true,
+		},
+		{
+			name:        "WeakSignalsOnly",
+			sample:      LocationSample{Latitude: 12.971, Longitude: 77.594, Accuracy: &perfect},
+			wantSignals: []string{"exact_coordinates", "accuracy"},
+		},
+		{
+			name:        "MockProvider",
+			sample:      LocationSample{Latitude: 12.971598, Longitude: 77.594562, IsMock: true},
+			wantSignals: []string{"mock_provider"},
+			wantStrike:  true,
+		},
+		{
+			name:        "ClockSkewAndExactCoordinates",
+			sample:      LocationSample{Latitude: 12.971, Longitude: 77.594, ClientTime: now.Add(-time.Hour), Accuracy: &perfect},
+			wantSignals: []string{"exact_coordinates", "accuracy", "clock_skew"},
+			wantStrike:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			score, signals := policy.score(tc.sample, tc.history, now)
+
+			names := make([]string, len(signals))
+			for i, s := range signals {
+				names[i] = s.Name
+			}
+			if len(tc.wantSignals) == 0 {
+				require.Empty(t, names)
+			} else {
+				require.Equal(t, tc.wantSignals, names)
+			}
+			require.Equal(t, tc.wantStrike, score >= policy.StrikeScore)
+		})
+	}
+}
+
+func TestGPSPolicyActionFor(t *testing.T) {
+	policy := NewGPSPolicy(config.Config{})
+
+	require.Equal(t, GPSActionWarn, policy.actionFor(1))
+	require.Equal(t, GPSActionDrop, policy.actionFor(policy.DropStrikes))
+	require.Equal(t, GPSActionRestrict, policy.actionFor(policy.RestrictStrikes))
+	require.Equal(t, GPSActionShadowBan, policy.actionFor(policy.BanStrikes))
+}
+
+// Only the strike that crosses a threshold escalates: restriction and shadow
+// bans are applied once, not again on every later strike
+func TestEvaluateLocationEscalation(t *testing.T) {
+	policy := NewGPSPolicy(config.Config{})
+	fake := LocationSample{Latitude: 12.971598, Longitude: 77.594562, IsMock: true}
+
+	testCases := []struct {
+		name          string
+		sample        LocationSample
+		priorStrikes  int64
+		wantAction    GPSAction
+		wantEscalated bool
+	}{
+		{
+			name:       "NoStrike",
+			sample:     LocationSample{Latitude: 12.971598, Longitude: 77.594562},
+			wantAction: GPSActionAllow,
+		},
+		{
+			name:       "FirstStrike",
+			sample:     fake,
+			wantAction: GPSActionWarn,
+		},
+		{
+			name:          "CrossesDrop",
+			sample:        fake,
+			priorStrikes:  policy.DropStrikes - 1,
+			wantAction:    GPSActionDrop,
+			wantEscalated: true,
+		},
+		{
+			name:          "CrossesRestrict",
+			sample:        fake,
+			priorStrikes:  policy.RestrictStrikes - 1,
+			wantAction:    GPSActionRestrict,
+			wantEscalated: true,
+		},
+		{
+			name:          "CrossesBan",
+			sample:        fake,
+			priorStrikes:  policy.BanStrikes - 1,
+			wantAction:    GPSActionShadowBan,
+			wantEscalated: true,
+		},
+		{
+			name:         "AlreadyBanned",
+			sample:       fake,
+			priorStrikes: policy.BanStrikes,
+			wantAction:   GPSActionShadowBan,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			userID := uuid.New()
+			strikes := 0
+			if tc.wantAction != GPSActionAllow {
+				strikes = 1
+			}
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().
+				CountLocationStrikesSince(gomock.Any(), gomock.Any()).
+				Times(strikes).
+				Return(tc.priorStrikes, nil)
+			store.EXPECT().
+				CreateLocationStrike(gomock.Any(), gomock.Any()).
+				Times(strikes).
+				DoAndReturn(func(_ context.Context, arg db.CreateLocationStrikeParams) (db.LocationStrike, error) {
+					require.Equal(t, userID, arg.UserID)
+					require.Equal(t, string(tc.wantAction), arg.Action)
+					return db.LocationStrike{}, nil
+				})
+
+			// Redis is unreachable: no history, and nothing saved
+			rdb := redis.NewClient(&redis.Options{Addr: "localhost:0", MaxRetries: -1})
+			monitor := NewSafetyMonitor(rdb, store, policy)
+
+			verdict := monitor.EvaluateLocation(context.Background(), userID, tc.sample)
+			require.Equal(t, tc.wantAction, verdict.Action)
+			require.Equal(t, tc.wantEscalated, verdict.Escalated)
+		})
+	}
+}
@@ -1,15 +1,23 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"os"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 
 	"privacy-social-backend/internal/config"
 	"privacy-social-backend/internal/repository"
+	"privacy-social-backend/internal/service/feedrank"
+	"privacy-social-backend/internal/service/geocode"
 	"privacy-social-backend/internal/service/location"
+	"privacy-social-backend/internal/service/moderation"
+	"privacy-social-backend/internal/service/safetyalert"
+	"privacy-social-backend/internal/service/spam"
+	"privacy-social-backend/internal/service/trust"
 	"privacy-social-backend/internal/token"
 )
 
@@ -23,6 +31,14 @@ type Server struct {
 	hub        *Hub
 	safety     *SafetyMonitor
 	location   *location.RedisLocationService
+	moderator  moderation.ContentModerator
+	escalation ReportEscalationPolicy
+	trust      *trust.Engine
+	spam       *spam.Detector
+	evidence   EvidencePolicy
+	alerts     *safetyalert.Dispatcher
+	geocoder   *geocode.Geocoder // nil when no dataset is configured
+	feedRanker feedrank.FeedRanker
 }
 
 // NewServer creates a new HTTP server and setup routing
@@ -42,9 +58,22 @@ func NewServer(config config.Config, store repository.Store) (*Server, error) {
 	hub := NewHub()
 	go hub.Run() // Start the hub in a goroutine
 
-	safety := NewSafetyMonitor(rdb)
+	safety := NewSafetyMonitor(rdb, store, NewGPSPolicy(config))
 	locationService := location.NewRedisLocationService(rdb, store)
 
+	moderator, err := newContentModerator(config)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create content moderator: %w", err)
+	}
+
+	var geocoder *geocode.Geocoder
+	if config.GeoNamesFile != "" {
+		geocoder, err = geocode.Load(config.GeoNamesFile)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load geonames dataset: %w", err)
+		}
+	}
+
 	server := &Server{
 		config:     config,
 		store:      store,
@@ -53,6 +82,18 @@ func NewServer(config config.Config, store repository.Store) (*Server, error) {
 		safety:     safety,
 		hub:        hub,
 		location:   locationService,
+		moderator:  moderator,
+		escalation: NewReportEscalationPolicy(config),
+		evidence:   NewEvidencePolicy(config),
+		alerts:     safetyalert.NewDispatcher(store, rdb, safetyalert.NewNotifier(config.AlertWebhookURL)),
+		trust:      trust.NewEngine(store),
+		spam:       spam.NewDetector(rdb, newSpamConfig(config)),
+		geocoder:   geocoder,
+		feedRanker: feedrank.NewBlended(feedrank.DefaultWeights),
+	}
+
+	hub.IsMuted = func(receiverID, senderID uuid.UUID) bool {
+		return server.conversationMuted(context.Background(), receiverID, senderID)
 	}
 
 	server.setupRouter()
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"net/http"
@@ -8,10 +9,14 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/mmcloughlin/geohash"
 	"github.com/rs/zerolog/log"
 
 	"privacy-social-backend/internal/repository/db"
+	"privacy-social-backend/internal/service/feedcache"
+	"privacy-social-backend/internal/service/mentions"
+	"privacy-social-backend/internal/service/moderation"
 	"privacy-social-backend/internal/token"
 )
 
@@ -20,16 +25,36 @@ const (
 	maxRadiusMeters     = 20000 // 20km
 	radiusStepMeters    = 5000  // 5km step
 	feedCacheTTL        = 5 * time.Minute
+
+	defaultItemDurationMs = 5000
 )
 
 type createStoryRequest struct {
-	MediaURL     string  `json:"media_url" binding:"required"`
-	MediaType    string  `json:"media_type" binding:"required,oneof=image video text"`
+	MediaURL     string  `json:"media_url" binding:"required_without=Items"`
+	MediaType    string  `json:"media_type" binding:"required_without=Items,omitempty,oneof=image video text"`
 	Latitude     float64 `json:"latitude" binding:"required,min=-90,max=90"`
 	Longitude    float64 `json:"longitude" binding:"required,min=-180,max=180"`
 	Caption      string  `json:"caption"`
 	IsAnonymous  bool    `json:"is_anonymous"`
 	ShowLocation bool    `json:"show_location"`
+	Visibility   string  `json:"visibility" binding:"omitempty,oneof=public connections close_friends"` // Defaults to public
+	// Optional per-story audience: only these users, and/or everyone but these
+	AllowUserIDs   []string `json:"allow_user_ids" binding:"omitempty,max=200,dive,uuid"`
+	ExcludeUserIDs []string `json:"exclude_user_ids" binding:"omitempty,max=200,dive,uuid"`
+	// Optional: keep the story hidden until this time; expiry counts from it
+	PublishAt *time.Time `json:"publish_at"`
+	// Optional: post a sequence instead of a single media; the first item is the cover
+	Items []storyItemRequest `json:"items" binding:"omitempty,min=2,max=10,dive"`
+	// Optional: polls, sliders and questions for viewers to respond to
+	Stickers []storyStickerRequest `json:"stickers" binding:"omitempty,max=3,dive"`
+	locationTelemetry
+}
+
+type storyItemRequest struct {
+	MediaURL   string `json:"media_url" binding:"required"`
+	MediaType  string `json:"media_type" binding:"required,oneof=image video text"`
+	Caption    string `json:"caption"`
+	DurationMs int32  `json:"duration_ms" binding:"omitempty,min=1000,max=60000"` // Defaults to 5s
 }
 
 func (server *Server) createStory(ctx *gin.Context) {
@@ -41,29 +66,69 @@ func (server *Server) createStory(ctx *gin.Context) {
 
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
 
+	if req.PublishAt != nil {
+		if err := validatePublishAt(*req.PublishAt); err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+	}
+
+	for _, sticker := range req.Stickers {
+		if err := sticker.validate(); err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+	}
+
+	audience, err := storyAudienceRules(authPayload.UserID, req.AllowUserIDs, req.ExcludeUserIDs)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
 	hash := geohash.Encode(req.Latitude, req.Longitude)
 
 	// Safety Check: Fake GPS
-	val := server.safety.ValidateUserMovement(ctx, authPayload.UserID.String(), req.Latitude, req.Longitude)
-	if !val.Allowed {
-		if val.ShouldBan {
-			// In development (localhost), we just log the warning instead of banning
-			// to avoid issues when users switch between real and mock locations.
-			log.Warn().
-				Str("user_id", authPayload.UserID.String()).
-				Float64("lat", req.Latitude).
-				Float64("lng", req.Longitude).
-				Msg("Fake GPS detected (Dev Bypass: User not banned)")
-
-			/*
-				// Production logic: Shadow ban the user (silently)
-				server.store.BanUser(ctx, db.BanUserParams{
-					ID:             authPayload.UserID,
-					IsShadowBanned: true,
-				})
-				log.Warn().Str("user_id", authPayload.UserID.String()).Msg("User shadow-banned for fake GPS")
-			*/
+	if !server.checkLocation(ctx, authPayload.UserID, req.sample(req.Latitude, req.Longitude)) {
+		ctx.JSON(http.StatusUnprocessableEntity, errorResponse(errUnverifiedLocation))
+		return
+	}
+
+	// Content moderation: caption
+	moderationResult := server.moderateText(ctx, moderation.KindCaption, authPayload.UserID, req.Caption)
+	if moderationResult.Verdict == moderation.VerdictReject {
+		ctx.JSON(http.StatusUnprocessableEntity, errorResponse(errContentRejected))
+		return
+	}
+
+	// Item captions and sticker text: any rejection refuses the story, a flag holds all of it
+	var texts []string
+	for _, item := range req.Items {
+		texts = append(texts, item.Caption)
+	}
+	for _, sticker := range req.Stickers {
+		texts = append(texts, sticker.texts()...)
+	}
+	flaggedText := req.Caption
+	for _, text := range texts {
+		if text == "" {
+			continue
 		}
+		result := server.moderateText(ctx, moderation.KindCaption, authPayload.UserID, text)
+		if result.Verdict == moderation.VerdictReject {
+			ctx.JSON(http.StatusUnprocessableEntity, errorResponse(errContentRejected))
+			return
+		}
+		if result.Verdict == moderation.VerdictFlag && moderationResult.Verdict == moderation.VerdictAllow {
+			moderationResult = result
+			flaggedText = text
+		}
+	}
+
+	// A sequence's cover is its first item
+	if len(req.Items) > 0 {
+		req.MediaURL = req.Items[0].MediaURL
+		req.MediaType = req.Items[0].MediaType
 	}
 
 	// Get user to check premium status
@@ -80,31 +145,122 @@ func (server *Server) createStory(ctx *gin.Context) {
 		expiryDuration = 48 * time.Hour
 		isPremium = true
 	}
-	expiresAt := time.Now().UTC().Add(expiryDuration)
+	liveFrom := time.Now().UTC()
+	if req.PublishAt != nil {
+		liveFrom = req.PublishAt.UTC()
+	}
+	expiresAt := liveFrom.Add(expiryDuration)
 
 	// In real app: Validate/Upload to S3 here if receiving raw file.
 	// Here we accept URL.
 
+	visibility := db.StoryAvailabilityPublic
+	if req.Visibility != "" {
+		visibility = db.StoryAvailability(req.Visibility)
+	}
+
 	// Prepare caption as sql.NullString
 	var captionNull sql.NullString
 	if req.Caption != "" {
 		captionNull = sql.NullString{String: req.Caption, Valid: true}
 	}
 
-	story, err := server.store.CreateStory(ctx, db.CreateStoryParams{
-		UserID:       authPayload.UserID,
-		MediaUrl:     req.MediaURL,
-		MediaType:    req.MediaType,
-		Caption:      captionNull,
-		Geohash:      hash,
-		Lng:          req.Longitude,
-		Lat:          req.Latitude,
-		IsAnonymous:  req.IsAnonymous,
-		ShowLocation: req.ShowLocation,
-		IsPremium:    sql.NullBool{Bool: isPremium, Valid: true},
-		ExpiresAt:    expiresAt,
+	// Only stories showing their location are labelled
+	var placeLabelArg sql.NullString
+	if req.ShowLocation {
+		placeLabelArg = toNullString(server.geocoder.Label(req.Latitude, req.Longitude))
+	}
+
+	// The audience rules are stored with the story so it's never visible without them
+	var story db.CreateStoryRow
+	err = server.store.ExecTx(ctx, func(q *db.Queries) error {
+		var err error
+		story, err = q.CreateStory(ctx, db.CreateStoryParams{
+			UserID:       authPayload.UserID,
+			MediaUrl:     req.MediaURL,
+			MediaType:    req.MediaType,
+			Caption:      captionNull,
+			Geohash:      hash,
+			Lng:          req.Longitude,
+			Lat:          req.Latitude,
+			IsAnonymous:  req.IsAnonymous,
+			ShowLocation: req.ShowLocation,
+			IsPremium:    sql.NullBool{Bool: isPremium, Valid: true},
+			ExpiresAt:    expiresAt,
+			Visibility:   visibility,
+			PlaceLabel:   placeLabelArg,
+		})
+		if err != nil {
+			return err
+		}
+
+		for userID, rule := range audience {
+			if err := q.SetStoryAudienceRule(ctx, db.SetStoryAudienceRuleParams{
+				StoryID: story.ID,
+				UserID:  userID,
+				Rule:    rule,
+			}); err != nil {
+				return err
+			}
+		}
+
+		for i, item := range req.Items {
+			duration := item.DurationMs
+			if duration == 0 {
+				duration = defaultItemDurationMs
+			}
+			var itemCaption sql.NullString
+			if item.Caption != "" {
+				itemCaption = sql.NullString{String: item.Caption, Valid: true}
+			}
+			if _, err := q.CreateStoryItem(ctx, db.CreateStoryItemParams{
+				StoryID:    story.ID,
+				Position:   int32(i),
+				MediaUrl:   item.MediaURL,
+				MediaType:  item.MediaType,
+				Caption:    itemCaption,
+				DurationMs: duration,
+			}); err != nil {
+				return err
+			}
+		}
+
+		for _, sticker := range req.Stickers {
+			options := sticker.Options
+			if options == nil {
+				options = []string{}
+			}
+			if _, err := q.CreateStorySticker(ctx, db.CreateStoryStickerParams{
+				StoryID: story.ID,
+				Kind:    sticker.Kind,
+				Prompt:  sticker.Prompt,
+				Options: options,
+				Emoji:   toNullString(sticker.Emoji),
+			}); err != nil {
+				return err
+			}
+		}
+
+		// Flagged stories stay hidden from others until reviewed
+		if moderationResult.Verdict == moderation.VerdictFlag {
+			if err := holdForReview(ctx, q, flagContentStory, story.ID, authPayload.UserID, moderation.KindCaption, flaggedText, moderationResult); err != nil {
+				return err
+			}
+		}
+
+		if req.PublishAt != nil {
+			return q.ScheduleStory(ctx, db.ScheduleStoryParams{
+				StoryID:   story.ID,
+				PublishAt: liveFrom,
+			})
+		}
+		return nil
 	})
 	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code.Name() == "foreign_key_violation" {
+			ctx.JSON(http.StatusUnprocessableEntity, gin.H{"error": "audience user not found"})
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
@@ -116,21 +272,32 @@ func (server *Server) createStory(ctx *gin.Context) {
 		log.Error().Err(err).Msg("Failed to update user activity")
 	}
 
-	// Create mentions if caption has @username
-	if req.Caption != "" {
-		go server.createStoryMentions(ctx, story.ID, req.Caption)
+	rsp := toStoryResponseFromCreate(story)
+	rsp.ItemCount = int64(len(req.Items))
+
+	// Scheduled stories are announced by the publish worker when they go live
+	if req.PublishAt != nil {
+		rsp.PublishAt = &liveFrom
+		ctx.JSON(http.StatusCreated, rsp)
+		return
+	}
+
+	// Create mentions if caption has @username (not for held stories)
+	if req.Caption != "" && moderationResult.Verdict == moderation.VerdictAllow {
+		go mentions.NotifyStory(context.Background(), server.store, story.ID, req.Caption)
 	}
 
-	// Invalidate feed cache for the area
-	userGeohash := truncatedGeohash(req.Latitude, req.Longitude, 5)
-	server.invalidateFeedCache(userGeohash)
+	// Invalidate feed caches around the story
+	server.invalidateFeedCache(story.Geohash)
 
-	ctx.JSON(http.StatusCreated, toStoryResponseFromCreate(story))
+	ctx.JSON(http.StatusCreated, rsp)
 }
 
 type getFeedRequest struct {
 	Latitude  float64 `form:"latitude" binding:"required,min=-90,max=90"`
 	Longitude float64 `form:"longitude" binding:"required,min=-180,max=180"`
+	locationTelemetry
+	feedPageRequest
 }
 
 func (server *Server) getFeed(ctx *gin.Context) {
@@ -139,47 +306,105 @@ func (server *Server) getFeed(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
+	page, err := req.parse()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
 
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
 
-	// Create cache key based on user's geohash (5 chars = ~2.4km precision)
-	userGeohash := geohash.Encode(req.Latitude, req.Longitude)
-	if len(userGeohash) > 5 {
-		userGeohash = userGeohash[:5]
+	// Safety Check: Fake GPS
+	if !server.checkLocation(ctx, authPayload.UserID, req.sample(req.Latitude, req.Longitude)) {
+		ctx.JSON(http.StatusUnprocessableEntity, errorResponse(errUnverifiedLocation))
+		return
 	}
-	cacheKey := "feed:" + userGeohash
 
-	// Try to get from Redis cache first
-	cachedData, err := server.redis.Get(ctx, cacheKey).Result()
-	if err == nil && cachedData != "" {
-		// Cache hit - return cached data
-		ctx.Header("X-Cache", "HIT")
-		ctx.Data(http.StatusOK, "application/json", []byte(cachedData))
+	response, cacheHit, err := server.loadFeed(ctx, authPayload.UserID, req.Latitude, req.Longitude)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	// Cache miss - Fetch from DB
-	// Incremental Radius Search (5km -> 25km)
-	var searchRadius float64 = 5000
-	const maxRadius = 25000
-	const stepRadius = 5000
+	server.filterFeedForViewer(ctx, authPayload.UserID, &response)
+	if _, err := server.pageFeed(ctx, authPayload.UserID, req.Latitude, req.Longitude, page, &response); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
 
-	var stories []db.GetStoriesWithinRadiusRow
-	var message string
+	if cacheHit {
+		ctx.Header("X-Cache", "HIT")
+	} else {
+		ctx.Header("X-Cache", "MISS")
+	}
+	ctx.JSON(http.StatusOK, response)
+}
 
-	for searchRadius <= maxRadius {
-		stories, err = server.store.GetStoriesWithinRadius(ctx, db.GetStoriesWithinRadiusParams{
-			Lng:          req.Longitude,
-			Lat:          req.Latitude,
-			RadiusMeters: searchRadius,
-			UserID:       authPayload.UserID,
+// loadFeed returns the stories around a point that the viewer may see,
+// within the smallest search radius that has any. Candidates come from the
+// shared per-cell cache; the viewer's blocks, mutes, audiences and privacy
+// rules are applied here on every request, before the search radius is
+// chosen. Hidden words are left to filterFeedForViewer, and ranking to pageFeed.
+func (server *Server) loadFeed(ctx context.Context, viewerID uuid.UUID, lat, lng float64) (feedResponse, bool, error) {
+	cell := feedcache.Cell(lat, lng)
+	candidates, cacheHit := feedcache.Get(ctx, server.redis, cell)
+	if !cacheHit {
+		centreLat, centreLng := feedcache.Centre(cell)
+		ids, err := server.store.ListFeedCandidates(ctx, db.ListFeedCandidatesParams{
+			Lng:           centreLng,
+			Lat:           centreLat,
+			RadiusMeters:  feedcache.CandidateRadiusMeters,
+			BandMeters:    feedcache.BandMeters,
+			MaxCandidates: feedcache.MaxCandidates,
 		})
 		if err != nil {
-			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-			return
+			return feedResponse{}, false, err
 		}
+		candidates = feedcache.Candidates{StoryIDs: ids}
+		feedcache.Set(ctx, server.redis, cell, candidates)
+	}
+
+	// The shared candidates are capped; the viewer's own, connections' and
+	// close friends' stories are always in
+	personal, err := server.store.ListFeedViewerCandidates(ctx, db.ListFeedViewerCandidatesParams{
+		Lng:           lng,
+		Lat:           lat,
+		RadiusMeters:  feedcache.MaxSearchRadiusMeters,
+		ViewerID:      viewerID,
+		MaxCandidates: feedcache.MaxCandidates,
+	})
+	if err != nil {
+		return feedResponse{}, cacheHit, err
+	}
+	storyIDs := mergeStoryIDs(candidates.StoryIDs, personal)
 
-		if len(stories) > 0 {
+	var stories []db.ListFeedStoriesRow
+	if len(storyIDs) > 0 {
+		stories, err = server.store.ListFeedStories(ctx, db.ListFeedStoriesParams{
+			Lng:      lng,
+			Lat:      lat,
+			StoryIds: storyIDs,
+			ViewerID: viewerID,
+		})
+		if err != nil {
+			return feedResponse{}, cacheHit, err
+		}
+	}
+
+	// Incremental Radius Search (5km -> 25km)
+	var searchRadius float64 = 5000
+	const maxRadius = feedcache.MaxSearchRadiusMeters
+	const stepRadius = feedcache.BandMeters
+
+	var nearby []StoryResponse
+	for searchRadius <= maxRadius {
+		for _, story := range stories {
+			if story.DistanceMeters <= searchRadius {
+				nearby = append(nearby, toStoryResponse(story))
+			}
+		}
+
+		if len(nearby) > 0 {
 			break
 		}
 
@@ -187,31 +412,33 @@ func (server *Server) getFeed(ctx *gin.Context) {
 	}
 
 	// Update message based on results
-	if len(stories) == 0 {
+	message := "Stories found nearby"
+	if len(nearby) == 0 {
 		message = "No stories found within 25km"
-	} else {
-		message = "Stories found nearby"
 	}
 
-	// Convert to response DTOs
-	storyResponses := make([]StoryResponse, len(stories))
-	for i, story := range stories {
-		storyResponses[i] = toStoryResponse(story)
-	}
+	return feedResponse{
+		Stories:      nearby,
+		Count:        len(nearby),
+		Message:      message,
+		SearchRadius: searchRadius,
+	}, cacheHit, nil
+}
 
-	response := gin.H{
-		"stories":       storyResponses,
-		"count":         len(storyResponses),
-		"message":       message,
-		"search_radius": searchRadius,
+// mergeStoryIDs returns ids followed by the IDs of extra it's missing
+func mergeStoryIDs(ids, extra []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		seen[id] = true
+	}
+	merged := append([]uuid.UUID(nil), ids...)
+	for _, id := range extra {
+		if !seen[id] {
+			seen[id] = true
+			merged = append(merged, id)
+		}
 	}
-
-	// Cache the result for 5 minutes
-	responseJSON, _ := json.Marshal(response)
-	server.redis.Set(ctx, cacheKey, responseJSON, feedCacheTTL)
-
-	ctx.Header("X-Cache", "MISS")
-	ctx.JSON(http.StatusOK, response)
+	return merged
 }
 
 // deleteStory allows users to delete their own stories
@@ -248,12 +475,8 @@ func (server *Server) deleteUserStory(ctx *gin.Context) {
 		return
 	}
 
-	// Invalidate feed cache
-	userGeohash := story.Geohash
-	if len(userGeohash) > 5 {
-		userGeohash = userGeohash[:5]
-	}
-	server.invalidateFeedCache(userGeohash)
+	// Invalidate feed caches around the story
+	server.invalidateFeedCache(story.Geohash)
 
 	ctx.JSON(http.StatusOK, gin.H{"message": "story deleted successfully"})
 }
@@ -282,7 +505,13 @@ func (server *Server) updateStory(ctx *gin.Context) {
 
 	// Prepare nullable parameters for SQL
 	var captionArg sql.NullString
+	var moderationResult moderation.Result
 	if req.Caption != nil {
+		moderationResult = server.moderateText(ctx, moderation.KindCaption, authPayload.UserID, *req.Caption)
+		if moderationResult.Verdict == moderation.VerdictReject {
+			ctx.JSON(http.StatusUnprocessableEntity, errorResponse(errContentRejected))
+			return
+		}
 		captionArg = sql.NullString{String: *req.Caption, Valid: true}
 	}
 
@@ -296,13 +525,37 @@ func (server *Server) updateStory(ctx *gin.Context) {
 		showLocationArg = sql.NullBool{Bool: *req.ShowLocation, Valid: true}
 	}
 
-	// Update the story
-	story, err := server.store.UpdateStory(ctx, db.UpdateStoryParams{
-		ID:           storyID,
-		UserID:       authPayload.UserID,
-		Caption:      captionArg,
-		IsAnonymous:  isAnonymousArg,
-		ShowLocation: showLocationArg,
+	// Turning the location on labels the story's place
+	var placeLabelArg sql.NullString
+	if req.ShowLocation != nil && *req.ShowLocation {
+		current, err := server.store.GetStoryByID(ctx, storyID)
+		if err != nil && err != sql.ErrNoRows {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		lat, latOK := current.Lat.(float64)
+		lng, lngOK := current.Lng.(float64)
+		if err == nil && latOK && lngOK {
+			placeLabelArg = toNullString(server.geocoder.Label(lat, lng))
+		}
+	}
+
+	// Update the story; a flagged caption is held in the same transaction
+	var story db.UpdateStoryRow
+	err = server.store.ExecTx(ctx, func(q *db.Queries) error {
+		var err error
+		story, err = q.UpdateStory(ctx, db.UpdateStoryParams{
+			ID:           storyID,
+			UserID:       authPayload.UserID,
+			Caption:      captionArg,
+			IsAnonymous:  isAnonymousArg,
+			ShowLocation: showLocationArg,
+			PlaceLabel:   placeLabelArg,
+		})
+		if err != nil || moderationResult.Verdict != moderation.VerdictFlag {
+			return err
+		}
+		return holdForReview(ctx, q, flagContentStory, story.ID, authPayload.UserID, moderation.KindCaption, *req.Caption, moderationResult)
 	})
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -313,12 +566,8 @@ func (server *Server) updateStory(ctx *gin.Context) {
 		return
 	}
 
-	// Invalidate feed cache
-	userGeohash := story.Geohash
-	if len(userGeohash) > 5 {
-		userGeohash = userGeohash[:5]
-	}
-	server.invalidateFeedCache(userGeohash)
+	// Invalidate feed caches around the story
+	server.invalidateFeedCache(story.Geohash)
 
 	// Convert to response
 	rsp := toStoryResponseFromUpdate(story)
@@ -352,6 +601,7 @@ func (server *Server) getConnectionStories(ctx *gin.Context) {
 	for i, story := range stories {
 		storyResponses[i] = toStoryResponseFromConnection(story)
 	}
+	collapseHiddenCaptions(storyResponses, authPayload.UserID, server.hiddenWordsMatcher(ctx, authPayload.UserID))
 
 	// Cache for 5 minutes
 	responseJSON, _ := json.Marshal(storyResponses)
@@ -385,9 +635,47 @@ func (server *Server) getStory(ctx *gin.Context) {
 		return
 	}
 
+	// Outside the story's audience it doesn't exist
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if !server.requireStoryAudience(ctx, story.ID, authPayload.UserID) {
+		return
+	}
+
+	// Stories held for review are only visible to their author
+	if story.UserID != authPayload.UserID {
+		held, err := server.store.HasPendingContentFlag(ctx, db.HasPendingContentFlagParams{
+			ContentType: flagContentStory,
+			ContentID:   story.ID,
+		})
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		if held {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "story not found"})
+			return
+		}
+	}
+
 	// Convert to response DTO
 	rsp := toStoryResponseFromGet(story)
 
+	// Sequences carry their items; single-media stories have none
+	items, err := server.store.ListStoryItems(ctx, story.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if len(items) > 0 {
+		rsp.Items = items
+		rsp.ItemCount = int64(len(items))
+	}
+	rsp.Stickers, err = server.store.ListStoryStickers(ctx, story.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
 	// Fetch author details since they aren't in the partial story object
 	user, err := server.store.GetUserByID(ctx, story.UserID)
 	if err == nil {
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"database/sql"
 	"net/http"
 	"strconv"
@@ -12,6 +13,41 @@ import (
 	"github.com/google/uuid"
 )
 
+// archivedStoryResponse is an archived story with its items, for sequences
+type archivedStoryResponse struct {
+	db.ArchivedStory
+	Items []db.ArchivedStoryItem `json:"items,omitempty"`
+}
+
+// archivedStoryItems loads the items of archived sequences, by archive ID
+func (server *Server) archivedStoryItems(ctx context.Context, archiveIDs []uuid.UUID) (map[uuid.UUID][]db.ArchivedStoryItem, error) {
+	items, err := server.store.ListArchivedStoryItems(ctx, archiveIDs)
+	if err != nil {
+		return nil, err
+	}
+	byArchive := make(map[uuid.UUID][]db.ArchivedStoryItem)
+	for _, item := range items {
+		byArchive[item.ArchiveID] = append(byArchive[item.ArchiveID], item)
+	}
+	return byArchive, nil
+}
+
+func (server *Server) withArchivedStoryItems(ctx context.Context, archives []db.ArchivedStory) ([]archivedStoryResponse, error) {
+	ids := make([]uuid.UUID, len(archives))
+	for i, a := range archives {
+		ids[i] = a.ID
+	}
+	items, err := server.archivedStoryItems(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	rsp := make([]archivedStoryResponse, len(archives))
+	for i, a := range archives {
+		rsp[i] = archivedStoryResponse{ArchivedStory: a, Items: items[a.ID]}
+	}
+	return rsp, nil
+}
+
 // archiveStory saves a story to the user's archive before it expires
 func (server *Server) archiveStory(ctx *gin.Context) {
 	storyID, err := uuid.Parse(ctx.Param("id"))
@@ -22,14 +58,23 @@ func (server *Server) archiveStory(ctx *gin.Context) {
 
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
 
-	// Archive the story
-	archivedStory, err := server.store.ArchiveStory(ctx, db.ArchiveStoryParams{
-		ID:     storyID,
-		UserID: authPayload.UserID,
+	// Archive the story, with its items if it's a sequence
+	var rsp archivedStoryResponse
+	err = server.store.ExecTx(ctx, func(q *db.Queries) error {
+		var err error
+		rsp.ArchivedStory, err = q.ArchiveStory(ctx, db.ArchiveStoryParams{
+			ID:     storyID,
+			UserID: authPayload.UserID,
+		})
+		if err != nil {
+			return err
+		}
+		rsp.Items, err = q.ArchiveStoryItems(ctx, rsp.ID)
+		return err
 	})
 	if err != nil {
 		if err == sql.ErrNoRows {
-			ctx.JSON(http.StatusNotFound, gin.H{"error": "story not found or already archived"})
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "story not found, already archived, or held for review"})
 			return
 		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
@@ -38,7 +83,7 @@ func (server *Server) archiveStory(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusCreated, gin.H{
 		"message": "story archived successfully",
-		"archive": archivedStory,
+		"archive": rsp,
 	})
 }
 
@@ -70,6 +115,12 @@ func (server *Server) getArchivedStories(ctx *gin.Context) {
 		return
 	}
 
+	rsp, err := server.withArchivedStoryItems(ctx, archives)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
 	// Get total count
 	count, err := server.store.CountArchivedStories(ctx, authPayload.UserID)
 	if err != nil {
@@ -78,7 +129,7 @@ func (server *Server) getArchivedStories(ctx *gin.Context) {
 	}
 
 	ctx.JSON(http.StatusOK, gin.H{
-		"archives":    archives,
+		"archives":    rsp,
 		"total":       count,
 		"page":        page,
 		"page_size":   pageSize,
@@ -86,7 +137,7 @@ func (server *Server) getArchivedStories(ctx *gin.Context) {
 	})
 }
 
-// deleteArchivedStory removes a story from the user's archive
+// deleteArchivedStory removes a story from the user's archive, and from every highlight it was in
 func (server *Server) deleteArchivedStory(ctx *gin.Context) {
 	archiveID, err := uuid.Parse(ctx.Param("id"))
 	if err != nil {
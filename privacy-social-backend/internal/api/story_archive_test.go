@@ -0,0 +1,103 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"privacy-social-backend/internal/repository/db"
+	mockdb "privacy-social-backend/internal/repository/mock"
+)
+
+// An archived sequence keeps every item, in the archive and in highlights,
+// after the story and its story_items are gone
+func TestArchivedSequenceKeepsItems(t *testing.T) {
+	userID := uuid.New()
+	sequence := db.ArchivedStory{ID: uuid.New(), UserID: userID, StoryID: uuid.New(), MediaUrl: "/uploads/1.jpg", MediaType: "image"}
+	single := db.ArchivedStory{ID: uuid.New(), UserID: userID, StoryID: uuid.New(), MediaUrl: "/uploads/single.jpg", MediaType: "image"}
+	items := []db.ArchivedStoryItem{
+		{ArchiveID: sequence.ID, Position: 0, MediaUrl: "/uploads/1.jpg", MediaType: "image", DurationMs: 5000},
+		{ArchiveID: sequence.ID, Position: 1, MediaUrl: "/uploads/2.mp4", MediaType: "video", DurationMs: 8000},
+		{ArchiveID: sequence.ID, Position: 2, MediaUrl: "/uploads/3.jpg", MediaType: "image", DurationMs: 5000},
+	}
+	highlight := db.Highlight{ID: uuid.New(), UserID: userID, Title: "Trip"}
+
+	testCases := []struct {
+		name          string
+		url           string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, body []byte)
+	}{
+		{
+			name: "Archive",
+			url:  "/stories/archived",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetArchivedStories(gomock.Any(), gomock.Any()).Times(1).
+					Return([]db.ArchivedStory{sequence, single}, nil)
+				store.EXPECT().ListArchivedStoryItems(gomock.Any(), []uuid.UUID{sequence.ID, single.ID}).Times(1).
+					Return(items, nil)
+				store.EXPECT().CountArchivedStories(gomock.Any(), userID).Times(1).Return(int64(2), nil)
+			},
+			checkResponse: func(t *testing.T, body []byte) {
+				var rsp struct {
+					Archives []archivedStoryResponse `json:"archives"`
+				}
+				require.NoError(t, json.Unmarshal(body, &rsp))
+				require.Len(t, rsp.Archives, 2)
+				require.Equal(t, items, rsp.Archives[0].Items)
+				require.Empty(t, rsp.Archives[1].Items)
+			},
+		},
+		{
+			name: "Highlight",
+			url:  fmt.Sprintf("/highlights/%s", highlight.ID),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetHighlightForViewer(gomock.Any(), db.GetHighlightForViewerParams{ID: highlight.ID, ViewerID: userID}).Times(1).
+					Return(highlight, nil)
+				store.EXPECT().ListHighlightItems(gomock.Any(), highlight.ID).Times(1).
+					Return([]db.ListHighlightItemsRow{{ID: sequence.ID, MediaUrl: sequence.MediaUrl, MediaType: sequence.MediaType}}, nil)
+				store.EXPECT().ListArchivedStoryItems(gomock.Any(), []uuid.UUID{sequence.ID}).Times(1).
+					Return(items, nil)
+			},
+			checkResponse: func(t *testing.T, body []byte) {
+				var rsp highlightDetailResponse
+				require.NoError(t, json.Unmarshal(body, &rsp))
+				require.Len(t, rsp.Items, 1)
+				require.Equal(t, items, rsp.Items[0].Items)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, tc.url, nil)
+			require.NoError(t, err)
+
+			accessToken, _, err := server.tokenMaker.CreateToken("owner", userID, time.Minute)
+			require.NoError(t, err)
+			request.Header.Set(authorizationHeaderKey, authorizationTypeBearer+" "+accessToken)
+
+			server.router.ServeHTTP(recorder, request)
+			require.Equal(t, http.StatusOK, recorder.Code)
+			tc.checkResponse(t, recorder.Body.Bytes())
+		})
+	}
+}
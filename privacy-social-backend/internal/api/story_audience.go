@@ -0,0 +1,140 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"privacy-social-backend/internal/repository/db"
+)
+
+// Story audience rules (story_audience.rule)
+const (
+	audienceAllow   = "allow"
+	audienceExclude = "exclude"
+)
+
+var errAudienceOverlap = errors.New("a user can't be both allowed and excluded")
+
+// storyAudienceRules turns the allow/exclude lists of a new story into rules.
+// The author is always in the audience, so they're left out.
+func storyAudienceRules(authorID uuid.UUID, allow, exclude []string) (map[uuid.UUID]string, error) {
+	rules := make(map[uuid.UUID]string, len(allow)+len(exclude))
+	for _, list := range []struct {
+		ids  []string
+		rule string
+	}{{allow, audienceAllow}, {exclude, audienceExclude}} {
+		for _, s := range list.ids {
+			id, err := uuid.Parse(s)
+			if err != nil {
+				return nil, err
+			}
+			if id == authorID {
+				continue
+			}
+			if rule, ok := rules[id]; ok && rule != list.rule {
+				return nil, errAudienceOverlap
+			}
+			rules[id] = list.rule
+		}
+	}
+	return rules, nil
+}
+
+// canViewStory reports whether the viewer is in the story's audience:
+// visibility, allow/exclude lists, the author's hide-from list and blocks
+func (server *Server) canViewStory(ctx context.Context, storyID, viewerID uuid.UUID) (bool, error) {
+	return server.store.CanViewStory(ctx, db.CanViewStoryParams{
+		StoryID:  storyID,
+		ViewerID: viewerID,
+	})
+}
+
+// requireStoryAudience responds 404 unless the viewer can see the story, so
+// people outside the audience can't tell it exists
+func (server *Server) requireStoryAudience(ctx *gin.Context, storyID, viewerID uuid.UUID) bool {
+	canView, err := server.canViewStory(ctx, storyID, viewerID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+	if !canView {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "story not found"})
+		return false
+	}
+	return true
+}
+
+// List users my stories are hidden from
+func (server *Server) listStoriesHiddenFrom(ctx *gin.Context) {
+	authPayload := getAuthPayload(ctx)
+
+	users, err := server.store.ListStoriesHiddenFrom(ctx, authPayload.UserID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, users)
+}
+
+// Hide my stories from a user, current and future ones
+type hideStoriesFromRequest struct {
+	UserID string `json:"user_id" binding:"required,uuid"`
+}
+
+func (server *Server) hideStoriesFrom(ctx *gin.Context) {
+	var req hideStoriesFromRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := getAuthPayload(ctx)
+	hiddenID, ok := parseUUIDParam(ctx, req.UserID, "user_id")
+	if !ok {
+		return
+	}
+	if hiddenID == authPayload.UserID {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "cannot hide stories from yourself"})
+		return
+	}
+
+	err := server.store.HideStoriesFrom(ctx, db.HideStoriesFromParams{
+		UserID:       authPayload.UserID,
+		HiddenUserID: hiddenID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.invalidateStoryCaches(ctx, hiddenID)
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "stories hidden"})
+}
+
+// Stop hiding my stories from a user
+func (server *Server) unhideStoriesFrom(ctx *gin.Context) {
+	hiddenID, ok := parseUUIDParam(ctx, ctx.Param("id"), "user_id")
+	if !ok {
+		return
+	}
+	authPayload := getAuthPayload(ctx)
+
+	err := server.store.UnhideStoriesFrom(ctx, db.UnhideStoriesFromParams{
+		UserID:       authPayload.UserID,
+		HiddenUserID: hiddenID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	server.invalidateStoryCaches(ctx, hiddenID)
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "stories unhidden"})
+}
@@ -0,0 +1,134 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"privacy-social-backend/internal/repository/db"
+	mockdb "privacy-social-backend/internal/repository/mock"
+)
+
+func TestStoryAudienceRules(t *testing.T) {
+	author, a, b := uuid.New(), uuid.New(), uuid.New()
+
+	rules, err := storyAudienceRules(author, []string{a.String(), author.String()}, []string{b.String()})
+	require.NoError(t, err)
+	require.Equal(t, map[uuid.UUID]string{a: audienceAllow, b: audienceExclude}, rules)
+
+	_, err = storyAudienceRules(author, []string{a.String()}, []string{a.String()})
+	require.ErrorIs(t, err, errAudienceOverlap)
+}
+
+// Users outside a story's audience (excluded, hidden from, not on the allow
+// list) get the same 404 as for a story that doesn't exist
+func TestStoryOutsideAudienceNotFound(t *testing.T) {
+	viewerID := uuid.New()
+	story := db.GetStoryByIDRow{
+		ID:        uuid.New(),
+		UserID:    uuid.New(),
+		MediaUrl:  "/uploads/story.jpg",
+		MediaType: "image",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	canView := db.CanViewStoryParams{StoryID: story.ID, ViewerID: viewerID}
+
+	testCases := []struct {
+		name       string
+		method     string
+		url        string
+		body       gin.H
+		buildStubs func(store *mockdb.MockStore)
+	}{
+		{
+			name:   "GetStory",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("/stories/%s", story.ID),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetStoryByID(gomock.Any(), story.ID).Times(1).Return(story, nil)
+				store.EXPECT().CanViewStory(gomock.Any(), canView).Times(1).Return(false, nil)
+				store.EXPECT().HasPendingContentFlag(gomock.Any(), gomock.Any()).Times(0)
+			},
+		},
+		{
+			name:   "ViewStory",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/stories/%s/view", story.ID),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetStoryByID(gomock.Any(), story.ID).Times(1).Return(story, nil)
+				store.EXPECT().CanViewStory(gomock.Any(), canView).Times(1).Return(false, nil)
+				store.EXPECT().CreateStoryView(gomock.Any(), gomock.Any()).Times(0)
+			},
+		},
+		{
+			name:   "ReactToStory",
+			method: http.MethodPost,
+			url:    fmt.Sprintf("/stories/%s/react", story.ID),
+			body:   gin.H{"emoji": "🔥"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CanViewStory(gomock.Any(), canView).Times(1).Return(false, nil)
+				store.EXPECT().CreateStoryReaction(gomock.Any(), gomock.Any()).Times(0)
+			},
+		},
+		{
+			name:   "GetStoryReactions",
+			method: http.MethodGet,
+			url:    fmt.Sprintf("/stories/%s/reactions", story.ID),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CanViewStory(gomock.Any(), canView).Times(1).Return(false, nil)
+				store.EXPECT().GetStoryReactions(gomock.Any(), gomock.Any()).Times(0)
+			},
+		},
+		{
+			name:   "ShareStory",
+			method: http.MethodPost,
+			url:    "/stories/share",
+			body:   gin.H{"story_id": story.ID.String(), "user_ids": []string{uuid.NewString()}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().HasActiveRestriction(gomock.Any(), gomock.Any()).AnyTimes().Return(false, nil)
+				store.EXPECT().GetUserByID(gomock.Any(), viewerID).AnyTimes().Return(db.User{ID: viewerID}, nil)
+				store.EXPECT().GetStoryByID(gomock.Any(), story.ID).Times(1).Return(story, nil)
+				store.EXPECT().CanViewStory(gomock.Any(), canView).Times(1).Return(false, nil)
+				store.EXPECT().CreateMessage(gomock.Any(), gomock.Any()).Times(0)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			var body bytes.Buffer
+			if tc.body != nil {
+				require.NoError(t, json.NewEncoder(&body).Encode(tc.body))
+			}
+			request, err := http.NewRequest(tc.method, tc.url, &body)
+			require.NoError(t, err)
+
+			accessToken, _, err := server.tokenMaker.CreateToken("viewer", viewerID, time.Minute)
+			require.NoError(t, err)
+			request.Header.Set(authorizationHeaderKey, authorizationTypeBearer+" "+accessToken)
+
+			server.router.ServeHTTP(recorder, request)
+			require.Equal(t, http.StatusNotFound, recorder.Code)
+		})
+	}
+}
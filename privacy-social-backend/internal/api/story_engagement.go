@@ -40,18 +40,8 @@ func (server *Server) viewStory(ctx *gin.Context) {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
-	// Check if blocked
-	isBlocked, err := server.store.IsUserBlocked(ctx, db.IsUserBlockedParams{
-		BlockerID: story.UserID,
-		BlockedID: authPayload.UserID,
-	})
-	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
-		return
-	}
-	if isBlocked {
-		// Privacy: Act as if story doesn't exist or just forbid
-		ctx.JSON(http.StatusForbidden, gin.H{"error": "access denied"})
+	// Blocks and story audience: act as if the story doesn't exist
+	if !server.requireStoryAudience(ctx, story.ID, authPayload.UserID) {
 		return
 	}
 
@@ -153,6 +143,10 @@ func (server *Server) reactToStory(ctx *gin.Context) {
 		return
 	}
 
+	if !server.requireStoryAudience(ctx, storyID, authPayload.UserID) {
+		return
+	}
+
 	reaction, err := server.store.CreateStoryReaction(ctx, db.CreateStoryReactionParams{
 		StoryID: storyID,
 		UserID:  authPayload.UserID,
@@ -205,6 +199,10 @@ func (server *Server) getStoryReactions(ctx *gin.Context) {
 		return
 	}
 
+	if !server.requireStoryAudience(ctx, storyID, getAuthPayload(ctx).UserID) {
+		return
+	}
+
 	reactions, err := server.store.GetStoryReactions(ctx, storyID)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
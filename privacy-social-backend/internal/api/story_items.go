@@ -0,0 +1,96 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"privacy-social-backend/internal/repository/db"
+)
+
+// Record that the viewer reached an item of a sequence. Owners see the
+// per-item counts to find where viewers drop off.
+func (server *Server) viewStoryItem(ctx *gin.Context) {
+	storyID, ok := parseUUIDParam(ctx, ctx.Param("id"), "story_id")
+	if !ok {
+		return
+	}
+	itemID, ok := parseUUIDParam(ctx, ctx.Param("item_id"), "item_id")
+	if !ok {
+		return
+	}
+	authPayload := getAuthPayload(ctx)
+
+	story, err := server.store.GetStoryByID(ctx, storyID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "story not found"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	// Items expire with their story
+	if time.Now().After(story.ExpiresAt) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "story has expired"})
+		return
+	}
+	if !server.requireStoryAudience(ctx, story.ID, authPayload.UserID) {
+		return
+	}
+
+	if story.UserID == authPayload.UserID {
+		// Do not record views of own items
+		ctx.JSON(http.StatusOK, gin.H{"message": "own story viewed"})
+		return
+	}
+
+	_, err = server.store.RecordStoryItemView(ctx, db.RecordStoryItemViewParams{
+		ItemID:  itemID,
+		StoryID: storyID,
+		UserID:  authPayload.UserID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "story item not found"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "story item viewed"})
+}
+
+// Per-item view counts for the owner, in sequence order
+func (server *Server) getStoryItemStats(ctx *gin.Context) {
+	storyID, ok := parseUUIDParam(ctx, ctx.Param("id"), "story_id")
+	if !ok {
+		return
+	}
+	authPayload := getAuthPayload(ctx)
+
+	story, err := server.store.GetStoryByID(ctx, storyID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "story not found"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if story.UserID != authPayload.UserID {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": "you can only view your own story stats"})
+		return
+	}
+
+	stats, err := server.store.GetStoryItemStats(ctx, storyID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, stats)
+}
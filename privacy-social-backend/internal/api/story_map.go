@@ -21,7 +21,10 @@ type getStoriesMapRequest struct {
 	West  float64 `form:"west" binding:"required,min=-180,max=180"`
 }
 
-const mapCacheTTL = 5 * time.Minute
+const (
+	mapCacheTTL   = 5 * time.Minute
+	mapVersionTTL = 24 * time.Hour
+)
 
 // getStoriesMap returns stories within a bounding box for map display
 func (server *Server) getStoriesMap(ctx *gin.Context) {
@@ -44,8 +47,10 @@ func (server *Server) getStoriesMap(ctx *gin.Context) {
 		return
 	}
 
-	// Create cache key from bounding box (rounded to 2 decimals for better cache hits) + UserID for personalization
-	cacheKey := fmt.Sprintf("map:%.2f:%.2f:%.2f:%.2f:%s", req.North, req.South, req.East, req.West, authPayload.UserID)
+	// Create cache key from bounding box (rounded to 2 decimals for better cache hits) + UserID for personalization,
+	// versioned so blocks and mutes can retire the viewer's tiles without scanning for them
+	cacheKey := fmt.Sprintf("map:%.2f:%.2f:%.2f:%.2f:%s:%s", req.North, req.South, req.East, req.West,
+		authPayload.UserID, server.mapCacheVersion(ctx, authPayload.UserID))
 
 	// Try Redis cache first
 	cachedData, err := server.redis.Get(context.Background(), cacheKey).Result()
@@ -80,11 +85,12 @@ func (server *Server) getStoriesMap(ctx *gin.Context) {
 
 	// Convert clusters to response format
 	type ClusterResponse struct {
-		Geohash   string          `json:"geohash"`
-		Latitude  float64         `json:"latitude"`
-		Longitude float64         `json:"longitude"`
-		Count     int             `json:"count"`
-		Stories   []StoryResponse `json:"stories,omitempty"`
+		Geohash    string          `json:"geohash"`
+		Latitude   float64         `json:"latitude"`
+		Longitude  float64         `json:"longitude"`
+		Count      int             `json:"count"`
+		PlaceLabel string          `json:"place_label,omitempty"` // Coarse place of the cluster
+		Stories    []StoryResponse `json:"stories,omitempty"`
 	}
 
 	var response []ClusterResponse
@@ -92,10 +98,11 @@ func (server *Server) getStoriesMap(ctx *gin.Context) {
 		lat, lng := geohash.Decode(hash)
 
 		cluster := ClusterResponse{
-			Geohash:   hash,
-			Latitude:  lat,
-			Longitude: lng,
-			Count:     len(clusterStories),
+			Geohash:    hash,
+			Latitude:   lat,
+			Longitude:  lng,
+			Count:      len(clusterStories),
+			PlaceLabel: server.geocoder.Label(lat, lng),
 		}
 
 		// If cluster has 3 or fewer stories, include them
@@ -0,0 +1,282 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"privacy-social-backend/internal/repository/db"
+	"privacy-social-backend/internal/service/moderation"
+	"privacy-social-backend/internal/service/spam"
+)
+
+var errStoryRepliesOff = errors.New("this user doesn't accept story replies")
+
+// checkStoryReply applies the chat rules to a story reply. Connections reply
+// as they would message; others only when the author takes story replies and
+// messages from everyone. Returns sql.ErrNoRows when the reply isn't allowed.
+func (server *Server) checkStoryReply(ctx context.Context, senderID, authorID uuid.UUID) error {
+	connErr := server.checkConnection(ctx, senderID, authorID)
+	if connErr != nil && connErr != sql.ErrNoRows {
+		return connErr
+	}
+
+	settings, err := server.store.GetPrivacySettings(ctx, authorID)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	storyReplies, whoCanMessage := "connections", "connections"
+	if err == nil {
+		storyReplies = settings.StoryReplies
+		if settings.WhoCanMessage.Valid {
+			whoCanMessage = settings.WhoCanMessage.String
+		}
+	}
+
+	if storyReplies == "off" {
+		return errStoryRepliesOff
+	}
+	if connErr == nil {
+		return nil
+	}
+	if storyReplies != "everyone" || whoCanMessage != "everyone" {
+		return sql.ErrNoRows
+	}
+
+	// Not connected: blocks in either direction still apply
+	for _, pair := range [][2]uuid.UUID{{authorID, senderID}, {senderID, authorID}} {
+		blocked, err := server.store.IsUserBlocked(ctx, db.IsUserBlockedParams{
+			BlockerID: pair[0],
+			BlockedID: pair[1],
+		})
+		if err != nil {
+			return err
+		}
+		if blocked {
+			return sql.ErrNoRows
+		}
+	}
+	return nil
+}
+
+// snapshotStoryThumbnail copies the story's thumbnail (or image) so the reply
+// keeps it after the story and its media are gone. There is one copy per
+// story, shared by all its replies; created reports whether this call made it,
+// so the caller can remove it if the reply isn't saved. Media hosted elsewhere
+// is referenced as is; videos without a thumbnail and text stories have none.
+func snapshotStoryThumbnail(story db.GetStoryByIDRow) (thumbnail sql.NullString, created bool) {
+	source := story.ThumbnailUrl.String
+	if !story.ThumbnailUrl.Valid && story.MediaType == "image" {
+		source = story.MediaUrl
+	}
+	if source == "" {
+		return sql.NullString{}, false
+	}
+	if !strings.HasPrefix(source, uploadsURLPrefix) {
+		return sql.NullString{String: source, Valid: true}, false
+	}
+
+	name := "story-ref-" + story.ID.String() + filepath.Ext(source)
+	snapshot := sql.NullString{String: uploadsURLPrefix + name, Valid: true}
+	if _, err := os.Stat(filepath.Join("uploads", name)); err == nil {
+		return snapshot, false
+	}
+	if err := copyUpload(filepath.Base(source), name); err != nil {
+		if errors.Is(err, fs.ErrExist) {
+			return snapshot, false
+		}
+		log.Error().Err(err).Str("story_id", story.ID.String()).Msg("failed to snapshot story thumbnail")
+		return sql.NullString{String: source, Valid: true}, false
+	}
+	return snapshot, true
+}
+
+func copyUpload(srcName, dstName string) error {
+	src, err := os.Open(filepath.Join("uploads", srcName))
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	path := filepath.Join("uploads", dstName)
+	dst, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		os.Remove(path)
+		return err
+	}
+	return dst.Close()
+}
+
+type replyToStoryRequest struct {
+	Content string `json:"content" binding:"required,max=1000"`
+}
+
+type storyReplyResponse struct {
+	db.Message
+	StoryRef db.MessageStoryRef `json:"story_ref"`
+}
+
+// replyToStory sends the story's author a DM linked to the story
+func (server *Server) replyToStory(ctx *gin.Context) {
+	storyID, ok := parseUUIDParam(ctx, ctx.Param("id"), "story_id")
+	if !ok {
+		return
+	}
+
+	var req replyToStoryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := getAuthPayload(ctx)
+
+	if server.isRestricted(ctx, authPayload.UserID, restrictionMessages) {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": accountRestrictedMessage})
+		return
+	}
+	if server.isSenderThrottled(ctx, authPayload.UserID) {
+		ctx.JSON(http.StatusTooManyRequests, errorResponse(errSenderThrottled))
+		return
+	}
+
+	story, err := server.store.GetStoryByID(ctx, storyID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "story not found"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if time.Now().After(story.ExpiresAt) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "story has expired"})
+		return
+	}
+	if !server.requireStoryAudience(ctx, story.ID, authPayload.UserID) {
+		return
+	}
+	if story.UserID == authPayload.UserID {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "cannot reply to your own story"})
+		return
+	}
+	// A DM would reveal who posted it
+	if story.IsAnonymous {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": "anonymous stories can't be replied to"})
+		return
+	}
+
+	if err := server.checkStoryReply(ctx, authPayload.UserID, story.UserID); err != nil {
+		switch err {
+		case errStoryRepliesOff:
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+		case sql.ErrNoRows:
+			ctx.JSON(http.StatusForbidden, gin.H{"error": "You can't reply to this user's stories."})
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+
+	sender, err := server.store.GetUserByID(ctx, authPayload.UserID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// Same moderation and spam checks as any DM
+	moderationResult := server.moderateText(ctx, moderation.KindMessage, authPayload.UserID, req.Content)
+	if moderationResult.Verdict == moderation.VerdictReject {
+		ctx.JSON(http.StatusUnprocessableEntity, errorResponse(errContentRejected))
+		return
+	}
+	spamVerdict := server.checkSpam(ctx, sender, spam.Message{ReceiverID: story.UserID, Content: req.Content})
+	if spamVerdict.Hold && moderationResult.Verdict == moderation.VerdictAllow {
+		moderationResult = spamModerationResult(spamVerdict)
+	}
+
+	thumbnail, snapshotCreated := snapshotStoryThumbnail(story)
+
+	held := moderationResult.Verdict == moderation.VerdictFlag
+	hidden := false
+
+	var rsp storyReplyResponse
+	err = server.store.ExecTx(ctx, func(q *db.Queries) error {
+		var err error
+		rsp.Message, err = q.CreateMessage(ctx, db.CreateMessageParams{
+			SenderID:   authPayload.UserID,
+			ReceiverID: story.UserID,
+			Content:    req.Content,
+			ExpiresAt:  sql.NullTime{Time: time.Now().UTC().Add(24 * time.Hour), Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		rsp.StoryRef, err = q.CreateMessageStoryRef(ctx, db.CreateMessageStoryRefParams{
+			MessageID:      rsp.Message.ID,
+			StoryID:        uuid.NullUUID{UUID: story.ID, Valid: true},
+			StoryOwnerID:   story.UserID,
+			MediaType:      story.MediaType,
+			ThumbnailUrl:   thumbnail,
+			Caption:        story.Caption,
+			StoryCreatedAt: story.CreatedAt,
+		})
+		if err != nil {
+			return err
+		}
+		if held {
+			return holdForReview(ctx, q, flagContentMessage, rsp.Message.ID, authPayload.UserID, moderation.KindMessage, req.Content, moderationResult)
+		}
+		hidden, err = hideIfFiltered(ctx, q, rsp.Message.ID, story.UserID, req.Content)
+		return err
+	})
+	if err != nil {
+		if snapshotCreated {
+			os.Remove(filepath.Join("uploads", filepath.Base(thumbnail.String)))
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	msg := rsp.Message
+
+	if spamVerdict.Hold {
+		server.reportSpam(ctx, authPayload.UserID, spamVerdict, req.Content)
+	}
+
+	server.invalidateConversationCache(authPayload.UserID, story.UserID)
+
+	wsMsg := WSMessage{
+		Type:      "new_message",
+		Payload:   rsp,
+		SenderID:  authPayload.UserID,
+		CreatedAt: msg.CreatedAt,
+	}
+	wsMsgBytes, _ := json.Marshal(wsMsg)
+
+	if !held && !hidden {
+		server.incrementUnreadCount(story.UserID)
+		if !server.conversationMuted(ctx, story.UserID, authPayload.UserID) {
+			server.hub.SendToUser(story.UserID, wsMsgBytes)
+		}
+	}
+	server.hub.SendToUser(authPayload.UserID, wsMsgBytes)
+
+	ctx.JSON(http.StatusCreated, rsp)
+}
@@ -0,0 +1,263 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"privacy-social-backend/internal/repository/db"
+	mockdb "privacy-social-backend/internal/repository/mock"
+)
+
+func TestReplyToStory(t *testing.T) {
+	replierID, authorID := uuid.New(), uuid.New()
+	story := db.GetStoryByIDRow{
+		ID:        uuid.New(),
+		UserID:    authorID,
+		MediaType: "text",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+
+	settings := func(storyReplies, whoCanMessage string) db.PrivacySetting {
+		return db.PrivacySetting{
+			UserID:        authorID,
+			StoryReplies:  storyReplies,
+			WhoCanMessage: sql.NullString{String: whoCanMessage, Valid: true},
+		}
+	}
+	blocked := func(store *mockdb.MockStore, blocker, blockee uuid.UUID, isBlocked bool, times int) {
+		store.EXPECT().
+			IsUserBlocked(gomock.Any(), db.IsUserBlockedParams{BlockerID: blocker, BlockedID: blockee}).
+			Times(times).
+			Return(isBlocked, nil)
+	}
+	connected := func(store *mockdb.MockStore, status db.ConnectionStatus, err error) {
+		store.EXPECT().
+			GetConnection(gomock.Any(), db.GetConnectionParams{RequesterID: replierID, TargetID: authorID}).
+			Times(1).
+			Return(db.Connection{Status: status}, err)
+	}
+	viewable := func(store *mockdb.MockStore, story db.GetStoryByIDRow) {
+		store.EXPECT().GetStoryByID(gomock.Any(), story.ID).Times(1).Return(story, nil)
+		store.EXPECT().
+			CanViewStory(gomock.Any(), db.CanViewStoryParams{StoryID: story.ID, ViewerID: replierID}).
+			Times(1).
+			Return(true, nil)
+	}
+	sent := func(store *mockdb.MockStore) {
+		store.EXPECT().GetUserByID(gomock.Any(), replierID).Times(1).Return(db.User{ID: replierID, CreatedAt: time.Now().Add(-30 * 24 * time.Hour)}, nil)
+		store.EXPECT().ExecTx(gomock.Any(), gomock.Any()).Times(1).Return(nil)
+		store.EXPECT().IsConversationMuted(gomock.Any(), gomock.Any()).Times(1).Return(false, nil)
+	}
+	notSent := func(store *mockdb.MockStore) {
+		store.EXPECT().ExecTx(gomock.Any(), gomock.Any()).Times(0)
+	}
+
+	testCases := []struct {
+		name       string
+		buildStubs func(store *mockdb.MockStore)
+		wantStatus int
+	}{
+		{
+			name: "RepliesOff",
+			buildStubs: func(store *mockdb.MockStore) {
+				viewable(store, story)
+				blocked(store, authorID, replierID, false, 1)
+				blocked(store, replierID, authorID, false, 1)
+				connected(store, db.ConnectionStatusAccepted, nil)
+				// Once for who_can_message, once for story_replies
+				store.EXPECT().GetPrivacySettings(gomock.Any(), authorID).Times(2).Return(settings("off", "everyone"), nil)
+				notSent(store)
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "ConnectionsFromConnection",
+			buildStubs: func(store *mockdb.MockStore) {
+				viewable(store, story)
+				blocked(store, authorID, replierID, false, 1)
+				blocked(store, replierID, authorID, false, 1)
+				connected(store, db.ConnectionStatusAccepted, nil)
+				store.EXPECT().GetPrivacySettings(gomock.Any(), authorID).Times(2).Return(settings("connections", "connections"), nil)
+				sent(store)
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name: "ConnectionsFromStranger",
+			buildStubs: func(store *mockdb.MockStore) {
+				viewable(store, story)
+				blocked(store, authorID, replierID, false, 1)
+				blocked(store, replierID, authorID, false, 1)
+				connected(store, "", sql.ErrNoRows)
+				store.EXPECT().GetPrivacySettings(gomock.Any(), authorID).Times(1).Return(settings("connections", "everyone"), nil)
+				notSent(store)
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "EveryoneFromStranger",
+			buildStubs: func(store *mockdb.MockStore) {
+				viewable(store, story)
+				blocked(store, authorID, replierID, false, 2)
+				blocked(store, replierID, authorID, false, 2)
+				connected(store, "", sql.ErrNoRows)
+				store.EXPECT().GetPrivacySettings(gomock.Any(), authorID).Times(1).Return(settings("everyone", "everyone"), nil)
+				sent(store)
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			// Story replies from everyone, but not DMs from everyone
+			name: "EveryoneButMessagesFromConnections",
+			buildStubs: func(store *mockdb.MockStore) {
+				viewable(store, story)
+				blocked(store, authorID, replierID, false, 1)
+				blocked(store, replierID, authorID, false, 1)
+				connected(store, "", sql.ErrNoRows)
+				store.EXPECT().GetPrivacySettings(gomock.Any(), authorID).Times(1).Return(settings("everyone", "connections"), nil)
+				notSent(store)
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "EveryoneBlockedByAuthor",
+			buildStubs: func(store *mockdb.MockStore) {
+				viewable(store, story)
+				blocked(store, authorID, replierID, true, 2)
+				store.EXPECT().GetConnection(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().GetPrivacySettings(gomock.Any(), authorID).Times(1).Return(settings("everyone", "everyone"), nil)
+				notSent(store)
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "EveryoneBlockedAuthor",
+			buildStubs: func(store *mockdb.MockStore) {
+				viewable(store, story)
+				blocked(store, authorID, replierID, false, 2)
+				blocked(store, replierID, authorID, true, 2)
+				store.EXPECT().GetConnection(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().GetPrivacySettings(gomock.Any(), authorID).Times(1).Return(settings("everyone", "everyone"), nil)
+				notSent(store)
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "AnonymousStory",
+			buildStubs: func(store *mockdb.MockStore) {
+				s := story
+				s.IsAnonymous = true
+				viewable(store, s)
+				store.EXPECT().GetPrivacySettings(gomock.Any(), gomock.Any()).Times(0)
+				notSent(store)
+			},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name: "ExpiredStory",
+			buildStubs: func(store *mockdb.MockStore) {
+				s := story
+				s.ExpiresAt = time.Now().Add(-time.Minute)
+				store.EXPECT().GetStoryByID(gomock.Any(), story.ID).Times(1).Return(s, nil)
+				store.EXPECT().CanViewStory(gomock.Any(), gomock.Any()).Times(0)
+				notSent(store)
+			},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().HasActiveRestriction(gomock.Any(), gomock.Any()).AnyTimes().Return(false, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			var body bytes.Buffer
+			require.NoError(t, json.NewEncoder(&body).Encode(gin.H{"content": "love this"}))
+			request, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/stories/%s/reply", story.ID), &body)
+			require.NoError(t, err)
+
+			accessToken, _, err := server.tokenMaker.CreateToken("replier", replierID, time.Minute)
+			require.NoError(t, err)
+			request.Header.Set(authorizationHeaderKey, authorizationTypeBearer+" "+accessToken)
+
+			server.router.ServeHTTP(recorder, request)
+			require.Equal(t, tc.wantStatus, recorder.Code)
+		})
+	}
+}
+
+// The author can answer a story reply from someone they aren't connected to,
+// in the thread the reply started
+func TestSendMessageInStoryReplyThread(t *testing.T) {
+	authorID, replierID := uuid.New(), uuid.New()
+
+	testCases := []struct {
+		name       string
+		thread     bool
+		wantStatus int
+	}{
+		{name: "Thread", thread: true, wantStatus: http.StatusCreated},
+		{name: "NoThread", thread: false, wantStatus: http.StatusForbidden},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			store.EXPECT().IsUserBlocked(gomock.Any(), gomock.Any()).Times(2).Return(false, nil)
+			store.EXPECT().GetConnection(gomock.Any(), gomock.Any()).Times(1).Return(db.Connection{}, sql.ErrNoRows)
+			store.EXPECT().
+				HasStoryReplyThread(gomock.Any(), db.HasStoryReplyThreadParams{UserID: authorID, OtherUserID: replierID}).
+				Times(1).
+				Return(tc.thread, nil)
+
+			sends := 0
+			if tc.thread {
+				sends = 1
+			}
+			store.EXPECT().HasActiveRestriction(gomock.Any(), gomock.Any()).Times(sends).Return(false, nil)
+			store.EXPECT().GetUserByID(gomock.Any(), authorID).Times(sends).Return(db.User{ID: authorID, CreatedAt: time.Now().Add(-30 * 24 * time.Hour)}, nil)
+			store.EXPECT().ExecTx(gomock.Any(), gomock.Any()).Times(sends).Return(nil)
+			store.EXPECT().IsConversationMuted(gomock.Any(), gomock.Any()).Times(sends).Return(false, nil)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			var body bytes.Buffer
+			require.NoError(t, json.NewEncoder(&body).Encode(gin.H{"receiver_id": replierID, "content": "thanks!"}))
+			request, err := http.NewRequest(http.MethodPost, "/messages", &body)
+			require.NoError(t, err)
+
+			accessToken, _, err := server.tokenMaker.CreateToken("author", authorID, time.Minute)
+			require.NoError(t, err)
+			request.Header.Set(authorizationHeaderKey, authorizationTypeBearer+" "+accessToken)
+
+			server.router.ServeHTTP(recorder, request)
+			require.Equal(t, tc.wantStatus, recorder.Code)
+		})
+	}
+}
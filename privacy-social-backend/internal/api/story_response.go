@@ -1,6 +1,7 @@
 package api
 
 import (
+	"database/sql"
 	"time"
 
 	"privacy-social-backend/internal/repository/db"
@@ -10,27 +11,43 @@ import (
 
 // StoryResponse is the DTO for story API responses
 type StoryResponse struct {
-	ID           uuid.UUID `json:"id"`
-	UserID       uuid.UUID `json:"user_id"`
-	MediaURL     string    `json:"media_url"`
-	MediaType    string    `json:"media_type"`
-	ThumbnailURL *string   `json:"thumbnail_url"`
-	Caption      *string   `json:"caption"`
-	Geohash      string    `json:"geohash"`
-	Visibility   string    `json:"visibility"`
-	ExpiresAt    time.Time `json:"expires_at"`
-	CreatedAt    time.Time `json:"created_at"`
-	IsAnonymous  bool      `json:"is_anonymous"`
-	ShowLocation bool      `json:"show_location"`
-	IsPremium    *bool     `json:"is_premium"`
-	Username     string    `json:"username"`
-	AvatarURL    *string   `json:"avatar_url"`
-	Lat          float64   `json:"lat"`
-	Lng          float64   `json:"lng"`
+	ID           uuid.UUID  `json:"id"`
+	UserID       uuid.UUID  `json:"user_id"`
+	MediaURL     string     `json:"media_url"`
+	MediaType    string     `json:"media_type"`
+	ThumbnailURL *string    `json:"thumbnail_url"`
+	Caption      *string    `json:"caption"`
+	Geohash      string     `json:"geohash"`
+	Visibility   string     `json:"visibility"`
+	ExpiresAt    time.Time  `json:"expires_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+	IsAnonymous  bool       `json:"is_anonymous"`
+	ShowLocation bool       `json:"show_location"`
+	PlaceLabel   *string    `json:"place_label,omitempty"` // Coarse place, only while show_location is on
+	IsPremium    *bool      `json:"is_premium"`
+	Username     string     `json:"username"`
+	AvatarURL    *string    `json:"avatar_url"`
+	Lat          float64    `json:"lat"`
+	Lng          float64    `json:"lng"`
+	Collapsed    bool       `json:"collapsed,omitempty"`  // Caption matches the viewer's hidden words
+	PublishAt    *time.Time `json:"publish_at,omitempty"` // Set while the story is scheduled
+	// Sequences: feeds carry the count, a single story carries the items
+	ItemCount int64          `json:"item_count,omitempty"`
+	Items     []db.StoryItem `json:"items,omitempty"`
+	// Polls, sliders and questions; results via /stories/:id/stickers/:sticker_id/results
+	Stickers []db.StorySticker `json:"stickers,omitempty"`
 }
 
-// Convert db.GetStoriesWithinRadiusRow to StoryResponse
-func toStoryResponse(row db.GetStoriesWithinRadiusRow) StoryResponse {
+// placeLabel hides the label when the story doesn't show its location
+func placeLabel(showLocation bool, label sql.NullString) *string {
+	if !showLocation || !label.Valid {
+		return nil
+	}
+	return &label.String
+}
+
+// Convert db.ListFeedStoriesRow to StoryResponse
+func toStoryResponse(row db.ListFeedStoriesRow) StoryResponse {
 	resp := StoryResponse{
 		ID:           row.ID,
 		UserID:       row.UserID,
@@ -42,6 +59,8 @@ func toStoryResponse(row db.GetStoriesWithinRadiusRow) StoryResponse {
 		CreatedAt:    row.CreatedAt,
 		IsAnonymous:  row.IsAnonymous,
 		ShowLocation: row.ShowLocation,
+		PlaceLabel:   placeLabel(row.ShowLocation, row.PlaceLabel),
+		ItemCount:    row.ItemCount,
 		Username:     row.Username,
 	}
 
@@ -84,6 +103,8 @@ func toStoryResponseFromConnection(row db.GetConnectionStoriesRow) StoryResponse
 		CreatedAt:    row.CreatedAt,
 		IsAnonymous:  row.IsAnonymous,
 		ShowLocation: row.ShowLocation,
+		PlaceLabel:   placeLabel(row.ShowLocation, row.PlaceLabel),
+		ItemCount:    row.ItemCount,
 		Username:     row.Username,
 	}
 
@@ -126,6 +147,8 @@ func toStoryResponseFromBounds(row db.GetStoriesInBoundsRow) StoryResponse {
 		CreatedAt:    row.CreatedAt,
 		IsAnonymous:  row.IsAnonymous,
 		ShowLocation: row.ShowLocation,
+		PlaceLabel:   placeLabel(row.ShowLocation, row.PlaceLabel),
+		ItemCount:    row.ItemCount,
 		Username:     row.Username,
 	}
 
@@ -164,6 +187,7 @@ func toStoryResponseFromCreate(row db.CreateStoryRow) StoryResponse {
 		CreatedAt:    row.CreatedAt,
 		IsAnonymous:  row.IsAnonymous,
 		ShowLocation: row.ShowLocation,
+		PlaceLabel:   placeLabel(row.ShowLocation, row.PlaceLabel),
 		Username:     "",
 	}
 
@@ -202,6 +226,7 @@ func toStoryResponseFromGet(row db.GetStoryByIDRow) StoryResponse {
 		CreatedAt:    row.CreatedAt,
 		IsAnonymous:  row.IsAnonymous,
 		ShowLocation: row.ShowLocation,
+		PlaceLabel:   placeLabel(row.ShowLocation, row.PlaceLabel),
 		Username:     "",
 	}
 
@@ -240,6 +265,7 @@ func toStoryResponseFromUpdate(row db.UpdateStoryRow) StoryResponse {
 		CreatedAt:    row.CreatedAt,
 		IsAnonymous:  row.IsAnonymous,
 		ShowLocation: row.ShowLocation,
+		PlaceLabel:   placeLabel(row.ShowLocation, row.PlaceLabel),
 		Username:     "",
 	}
 
@@ -0,0 +1,179 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"privacy-social-backend/internal/repository/db"
+	"privacy-social-backend/internal/service/moderation"
+)
+
+// Stories can be scheduled up to a week ahead
+const maxScheduleAhead = 7 * 24 * time.Hour
+
+var (
+	errPublishAtPast    = errors.New("publish_at must be in the future")
+	errPublishAtTooFar  = errors.New("publish_at can be at most 7 days ahead")
+	errScheduledMissing = errors.New("scheduled story not found")
+)
+
+func validatePublishAt(publishAt time.Time) error {
+	now := time.Now()
+	if !publishAt.After(now) {
+		return errPublishAtPast
+	}
+	if publishAt.After(now.Add(maxScheduleAhead)) {
+		return errPublishAtTooFar
+	}
+	return nil
+}
+
+// List my scheduled stories, soonest first
+func (server *Server) listScheduledStories(ctx *gin.Context) {
+	authPayload := getAuthPayload(ctx)
+
+	stories, err := server.store.ListScheduledStories(ctx, authPayload.UserID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, stories)
+}
+
+// Edit a scheduled story. Moving publish_at keeps the story's lifetime.
+type updateScheduledStoryRequest struct {
+	Caption      *string    `json:"caption"`
+	IsAnonymous  *bool      `json:"is_anonymous"`
+	ShowLocation *bool      `json:"show_location"`
+	Visibility   *string    `json:"visibility" binding:"omitempty,oneof=public connections close_friends"`
+	PublishAt    *time.Time `json:"publish_at"`
+}
+
+func (server *Server) updateScheduledStory(ctx *gin.Context) {
+	storyID, ok := parseUUIDParam(ctx, ctx.Param("id"), "story_id")
+	if !ok {
+		return
+	}
+
+	var req updateScheduledStoryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if req.PublishAt != nil {
+		if err := validatePublishAt(*req.PublishAt); err != nil {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+	}
+
+	authPayload := getAuthPayload(ctx)
+
+	arg := db.UpdateScheduledStoryParams{
+		ID:     storyID,
+		UserID: authPayload.UserID,
+	}
+
+	var moderationResult moderation.Result
+	if req.Caption != nil {
+		moderationResult = server.moderateText(ctx, moderation.KindCaption, authPayload.UserID, *req.Caption)
+		if moderationResult.Verdict == moderation.VerdictReject {
+			ctx.JSON(http.StatusUnprocessableEntity, errorResponse(errContentRejected))
+			return
+		}
+		arg.Caption = sql.NullString{String: *req.Caption, Valid: true}
+	}
+	if req.IsAnonymous != nil {
+		arg.IsAnonymous = sql.NullBool{Bool: *req.IsAnonymous, Valid: true}
+	}
+	if req.ShowLocation != nil {
+		arg.ShowLocation = sql.NullBool{Bool: *req.ShowLocation, Valid: true}
+	}
+	if req.Visibility != nil {
+		arg.Visibility = db.NullStoryAvailability{StoryAvailability: db.StoryAvailability(*req.Visibility), Valid: true}
+	}
+
+	current, err := server.store.GetScheduledStory(ctx, db.GetScheduledStoryParams{
+		ID:     storyID,
+		UserID: authPayload.UserID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(errScheduledMissing))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// Turning the location on labels the story's place
+	if req.ShowLocation != nil && *req.ShowLocation {
+		lat, latOK := current.Lat.(float64)
+		lng, lngOK := current.Lng.(float64)
+		if latOK && lngOK {
+			arg.PlaceLabel = toNullString(server.geocoder.Label(lat, lng))
+		}
+	}
+
+	var story db.UpdateScheduledStoryRow
+	err = server.store.ExecTx(ctx, func(q *db.Queries) error {
+		if req.PublishAt != nil {
+			publishAt := req.PublishAt.UTC()
+			if err := q.RescheduleStory(ctx, db.RescheduleStoryParams{
+				StoryID:   storyID,
+				PublishAt: publishAt,
+			}); err != nil {
+				return err
+			}
+			lifetime := current.ExpiresAt.Sub(current.PublishAt)
+			arg.ExpiresAt = sql.NullTime{Time: publishAt.Add(lifetime), Valid: true}
+		}
+
+		var err error
+		story, err = q.UpdateScheduledStory(ctx, arg)
+		if err != nil || moderationResult.Verdict != moderation.VerdictFlag {
+			return err
+		}
+		return holdForReview(ctx, q, flagContentStory, story.ID, authPayload.UserID, moderation.KindCaption, *req.Caption, moderationResult)
+	})
+	if err != nil {
+		// Published by the worker in the meantime
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(errScheduledMissing))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, story)
+}
+
+// Cancel a scheduled story. It's deleted without ever being shown.
+func (server *Server) cancelScheduledStory(ctx *gin.Context) {
+	storyID, ok := parseUUIDParam(ctx, ctx.Param("id"), "story_id")
+	if !ok {
+		return
+	}
+	authPayload := getAuthPayload(ctx)
+
+	_, err := server.store.CancelScheduledStory(ctx, db.CancelScheduledStoryParams{
+		ID:     storyID,
+		UserID: authPayload.UserID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(errScheduledMissing))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "scheduled story cancelled"})
+}
@@ -3,13 +3,13 @@ package api
 import (
 	"fmt"
 	"net/http"
-	"regexp"
-	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 
 	"privacy-social-backend/internal/repository/db"
+	"privacy-social-backend/internal/service/moderation"
+	"privacy-social-backend/internal/service/spam"
 )
 
 type shareStoryRequest struct {
@@ -31,12 +31,31 @@ func (server *Server) shareStory(ctx *gin.Context) {
 		return
 	}
 
+	if server.isRestricted(ctx, authPayload.UserID, restrictionMessages) {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": accountRestrictedMessage})
+		return
+	}
+	if server.isSenderThrottled(ctx, authPayload.UserID) {
+		ctx.JSON(http.StatusTooManyRequests, errorResponse(errSenderThrottled))
+		return
+	}
+
+	sender, err := server.store.GetUserByID(ctx, authPayload.UserID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
 	// Get story to create share message
 	story, err := server.store.GetStoryByID(ctx, storyID)
 	if err != nil {
 		ctx.JSON(http.StatusNotFound, gin.H{"error": "story not found"})
 		return
 	}
+	// Only stories the sharer can see
+	if !server.requireStoryAudience(ctx, story.ID, authPayload.UserID) {
+		return
+	}
 
 	// Create message with story link in content
 	// Use relative path for internal deep linking in frontend
@@ -54,16 +73,40 @@ func (server *Server) shareStory(ctx *gin.Context) {
 			continue // Skip non-connected users
 		}
 
-		// Create message with story link in content
-		_, err = server.store.CreateMessage(ctx, db.CreateMessageParams{
-			SenderID:   authPayload.UserID,
+		// Recipients outside the story's audience are skipped, like non-connections
+		if canView, err := server.canViewStory(ctx, story.ID, targetUserID); err != nil || !canView {
+			continue
+		}
+
+		// Shares count towards the same fan-out window as typed messages
+		spamVerdict := server.checkSpam(ctx, sender, spam.Message{
 			ReceiverID: targetUserID,
 			Content:    shareText,
+			Generated:  true,
+		})
+
+		// Create message with story link in content; held shares stay hidden from the receiver
+		err = server.store.ExecTx(ctx, func(q *db.Queries) error {
+			msg, err := q.CreateMessage(ctx, db.CreateMessageParams{
+				SenderID:   authPayload.UserID,
+				ReceiverID: targetUserID,
+				Content:    shareText,
+			})
+			if err != nil || !spamVerdict.Hold {
+				return err
+			}
+			return holdForReview(ctx, q, flagContentMessage, msg.ID, authPayload.UserID, moderation.KindMessage, shareText, spamModerationResult(spamVerdict))
 		})
 		if err != nil {
 			continue
 		}
 
+		// Stop fanning out once the sender looks like a spammer
+		if spamVerdict.Hold {
+			server.reportSpam(ctx, authPayload.UserID, spamVerdict, shareText)
+			break
+		}
+
 		successCount++
 	}
 
@@ -72,68 +115,3 @@ func (server *Server) shareStory(ctx *gin.Context) {
 		"shared_to": successCount,
 	})
 }
-
-// parseMentions extracts @username mentions from text
-func parseMentions(text string) []string {
-	re := regexp.MustCompile(`@(\w+)`)
-	matches := re.FindAllStringSubmatch(text, -1)
-
-	mentions := make([]string, 0)
-	seen := make(map[string]bool)
-
-	for _, match := range matches {
-		if len(match) > 1 {
-			username := strings.ToLower(match[1])
-			if !seen[username] {
-				mentions = append(mentions, username)
-				seen[username] = true
-			}
-		}
-	}
-
-	return mentions
-}
-
-// createStoryMentions creates mention records for a story
-func (server *Server) createStoryMentions(ctx *gin.Context, storyID uuid.UUID, caption string) error {
-	if caption == "" {
-		return nil
-	}
-
-	mentions := parseMentions(caption)
-	if len(mentions) == 0 {
-		return nil
-	}
-
-	for _, username := range mentions {
-		// Get user by username
-		user, err := server.store.GetUserByUsername(ctx, username)
-		if err != nil {
-			continue // Skip if user not found
-		}
-
-		// Create mention
-		_, err = server.store.CreateStoryMention(ctx, db.CreateStoryMentionParams{
-			StoryID:         storyID,
-			MentionedUserID: user.ID,
-		})
-		if err != nil {
-			continue // Skip on error
-		}
-
-		// Send notification to mentioned user
-		_, err = server.store.CreateNotification(ctx, db.CreateNotificationParams{
-			UserID:         user.ID,
-			Type:           "story_mention",
-			Title:          "You were mentioned!",
-			Message:        "You were mentioned in a story",
-			RelatedStoryID: uuid.NullUUID{UUID: storyID, Valid: true},
-		})
-		if err != nil {
-			// Log error but don't fail the whole operation
-			continue
-		}
-	}
-
-	return nil
-}
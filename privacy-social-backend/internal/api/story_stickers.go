@@ -0,0 +1,336 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"privacy-social-backend/internal/repository/db"
+	"privacy-social-backend/internal/service/moderation"
+)
+
+const (
+	stickerPoll     = "poll"
+	stickerSlider   = "slider"
+	stickerQuestion = "question"
+)
+
+var (
+	errPollOptions     = errors.New("polls need 2 to 4 options")
+	errStickerOptions  = errors.New("only polls have options")
+	errSliderEmoji     = errors.New("sliders need an emoji")
+	errStickerResponse = errors.New("response doesn't match the sticker")
+	errStickerAnswered = errors.New("you already responded to this sticker")
+)
+
+type storyStickerRequest struct {
+	Kind    string   `json:"kind" binding:"required,oneof=poll slider question"`
+	Prompt  string   `json:"prompt" binding:"required,max=200"`
+	Options []string `json:"options" binding:"omitempty,max=4,dive,required,max=50"` // Polls only
+	Emoji   string   `json:"emoji" binding:"omitempty,max=10"`                       // Sliders only
+}
+
+func (req storyStickerRequest) validate() error {
+	switch req.Kind {
+	case stickerPoll:
+		if len(req.Options) < 2 || len(req.Options) > 4 {
+			return errPollOptions
+		}
+	case stickerSlider:
+		if req.Emoji == "" {
+			return errSliderEmoji
+		}
+		fallthrough
+	default:
+		if len(req.Options) > 0 {
+			return errStickerOptions
+		}
+	}
+	return nil
+}
+
+// texts returns the sticker's user-written text for moderation
+func (req storyStickerRequest) texts() []string {
+	return append([]string{req.Prompt}, req.Options...)
+}
+
+// stickerResults are the aggregate results shown to voters and the owner.
+// Question answers are only listed to the owner.
+type stickerResults struct {
+	StickerID     uuid.UUID `json:"sticker_id"`
+	Kind          string    `json:"kind"`
+	ResponseCount int64     `json:"response_count"`
+	Votes         []int64   `json:"votes,omitempty"`          // Polls: votes per option, in order
+	SliderAverage *float64  `json:"slider_average,omitempty"` // Sliders: 0-100
+}
+
+func (server *Server) stickerResults(ctx context.Context, sticker db.StorySticker) (stickerResults, error) {
+	results := stickerResults{StickerID: sticker.ID, Kind: sticker.Kind}
+
+	tally, err := server.store.GetStickerTally(ctx, sticker.ID)
+	if err != nil {
+		return results, err
+	}
+	results.ResponseCount = tally.ResponseCount
+
+	switch sticker.Kind {
+	case stickerPoll:
+		votes, err := server.store.ListPollVotes(ctx, sticker.ID)
+		if err != nil {
+			return results, err
+		}
+		results.Votes = make([]int64, len(sticker.Options))
+		for _, v := range votes {
+			if int(v.OptionIndex) < len(results.Votes) {
+				results.Votes[v.OptionIndex] = v.Votes
+			}
+		}
+	case stickerSlider:
+		average := tally.SliderAverage
+		results.SliderAverage = &average
+	}
+	return results, nil
+}
+
+// stickerAnswer is one response as shown to the story owner. On anonymous
+// stories the responder's identity is left out.
+type stickerAnswer struct {
+	UserID      *uuid.UUID `json:"user_id,omitempty"`
+	Username    *string    `json:"username,omitempty"`
+	AvatarURL   *string    `json:"avatar_url,omitempty"`
+	OptionIndex *int32     `json:"option_index,omitempty"`
+	SliderValue *int32     `json:"slider_value,omitempty"`
+	Answer      *string    `json:"answer,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+func newStickerAnswer(r db.StoryStickerResponse, anonymous bool) stickerAnswer {
+	answer := stickerAnswer{
+		Answer:    nullStringToStrPtr(r.Answer),
+		CreatedAt: r.CreatedAt,
+	}
+	if !anonymous {
+		answer.UserID = &r.UserID
+	}
+	if r.OptionIndex.Valid {
+		answer.OptionIndex = &r.OptionIndex.Int32
+	}
+	if r.SliderValue.Valid {
+		answer.SliderValue = &r.SliderValue.Int32
+	}
+	return answer
+}
+
+// loadStorySticker fetches a live story and one of its stickers, responding
+// 404 when either is missing or the viewer is outside the story's audience
+func (server *Server) loadStorySticker(ctx *gin.Context, viewerID uuid.UUID) (db.GetStoryByIDRow, db.StorySticker, bool) {
+	var sticker db.StorySticker
+
+	storyID, ok := parseUUIDParam(ctx, ctx.Param("id"), "story_id")
+	if !ok {
+		return db.GetStoryByIDRow{}, sticker, false
+	}
+	stickerID, ok := parseUUIDParam(ctx, ctx.Param("sticker_id"), "sticker_id")
+	if !ok {
+		return db.GetStoryByIDRow{}, sticker, false
+	}
+
+	story, err := server.store.GetStoryByID(ctx, storyID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "story not found"})
+			return story, sticker, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return story, sticker, false
+	}
+	if time.Now().After(story.ExpiresAt) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "story has expired"})
+		return story, sticker, false
+	}
+	// Blocks and story audience: act as if the story doesn't exist
+	if !server.requireStoryAudience(ctx, story.ID, viewerID) {
+		return story, sticker, false
+	}
+
+	sticker, err = server.store.GetStorySticker(ctx, db.GetStoryStickerParams{
+		ID:      stickerID,
+		StoryID: story.ID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "sticker not found"})
+			return story, sticker, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return story, sticker, false
+	}
+	return story, sticker, true
+}
+
+// Respond to a sticker: vote in a poll, move a slider or answer a question.
+// Each viewer responds once.
+type respondToStickerRequest struct {
+	OptionIndex *int32 `json:"option_index" binding:"omitempty,min=0,max=3"`
+	SliderValue *int32 `json:"slider_value" binding:"omitempty,min=0,max=100"`
+	Answer      string `json:"answer" binding:"omitempty,max=500"`
+}
+
+func (server *Server) respondToSticker(ctx *gin.Context) {
+	var req respondToStickerRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	authPayload := getAuthPayload(ctx)
+
+	story, sticker, ok := server.loadStorySticker(ctx, authPayload.UserID)
+	if !ok {
+		return
+	}
+	if story.UserID == authPayload.UserID {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "cannot respond to your own story"})
+		return
+	}
+
+	arg := db.CreateStickerResponseParams{
+		StickerID: sticker.ID,
+		UserID:    authPayload.UserID,
+	}
+	switch {
+	case sticker.Kind == stickerPoll && req.OptionIndex != nil && int(*req.OptionIndex) < len(sticker.Options) && req.SliderValue == nil && req.Answer == "":
+		arg.OptionIndex = sql.NullInt32{Int32: *req.OptionIndex, Valid: true}
+	case sticker.Kind == stickerSlider && req.SliderValue != nil && req.OptionIndex == nil && req.Answer == "":
+		arg.SliderValue = sql.NullInt32{Int32: *req.SliderValue, Valid: true}
+	case sticker.Kind == stickerQuestion && req.Answer != "" && req.OptionIndex == nil && req.SliderValue == nil:
+		// Answers go straight to the owner with no review queue, so flagged ones are refused too
+		result := server.moderateText(ctx, moderation.KindMessage, authPayload.UserID, req.Answer)
+		if result.Verdict != moderation.VerdictAllow {
+			ctx.JSON(http.StatusUnprocessableEntity, errorResponse(errContentRejected))
+			return
+		}
+		arg.Answer = sql.NullString{String: req.Answer, Valid: true}
+	default:
+		ctx.JSON(http.StatusBadRequest, errorResponse(errStickerResponse))
+		return
+	}
+
+	response, err := server.store.CreateStickerResponse(ctx, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusConflict, errorResponse(errStickerAnswered))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	results, err := server.stickerResults(ctx, sticker)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	// Live tally and the new response for the owner
+	answer := newStickerAnswer(response, story.IsAnonymous)
+	if !story.IsAnonymous {
+		answer.Username = &authPayload.Username
+	}
+	event := struct {
+		Type    string      `json:"type"`
+		Payload interface{} `json:"payload"`
+	}{
+		Type: "story_sticker_response",
+		Payload: gin.H{
+			"story_id": story.ID,
+			"results":  results,
+			"response": answer,
+		},
+	}
+	eventBytes, err := json.Marshal(event)
+	if err == nil {
+		server.hub.SendToUser(story.UserID, eventBytes)
+	} else {
+		log.Error().Err(err).Msg("Failed to marshal story_sticker_response event")
+	}
+
+	ctx.JSON(http.StatusCreated, results)
+}
+
+// Aggregate results, for the owner and viewers who responded
+func (server *Server) getStickerResults(ctx *gin.Context) {
+	authPayload := getAuthPayload(ctx)
+
+	story, sticker, ok := server.loadStorySticker(ctx, authPayload.UserID)
+	if !ok {
+		return
+	}
+
+	if story.UserID != authPayload.UserID {
+		_, err := server.store.GetStickerResponse(ctx, db.GetStickerResponseParams{
+			StickerID: sticker.ID,
+			UserID:    authPayload.UserID,
+		})
+		if err != nil {
+			if err == sql.ErrNoRows {
+				ctx.JSON(http.StatusForbidden, gin.H{"error": "respond to see the results"})
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+	}
+
+	results, err := server.stickerResults(ctx, sticker)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, results)
+}
+
+// List a sticker's responses (owner only), newest first
+func (server *Server) listStickerResponses(ctx *gin.Context) {
+	authPayload := getAuthPayload(ctx)
+
+	story, sticker, ok := server.loadStorySticker(ctx, authPayload.UserID)
+	if !ok {
+		return
+	}
+	if story.UserID != authPayload.UserID {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": "you can only view responses to your own story"})
+		return
+	}
+
+	rows, err := server.store.ListStickerResponses(ctx, sticker.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	answers := make([]stickerAnswer, len(rows))
+	for i, row := range rows {
+		answers[i] = newStickerAnswer(db.StoryStickerResponse{
+			StickerID:   row.StickerID,
+			UserID:      row.UserID,
+			OptionIndex: row.OptionIndex,
+			SliderValue: row.SliderValue,
+			Answer:      row.Answer,
+			CreatedAt:   row.CreatedAt,
+		}, story.IsAnonymous)
+		if !story.IsAnonymous {
+			answers[i].Username = &rows[i].Username
+			answers[i].AvatarURL = nullStringToStrPtr(row.AvatarUrl)
+		}
+	}
+
+	ctx.JSON(http.StatusOK, answers)
+}
@@ -0,0 +1,297 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"privacy-social-backend/internal/repository/db"
+	mockdb "privacy-social-backend/internal/repository/mock"
+)
+
+func TestStoryStickers(t *testing.T) {
+	ownerID, viewerID := uuid.New(), uuid.New()
+	story := db.GetStoryByIDRow{
+		ID:        uuid.New(),
+		UserID:    ownerID,
+		MediaUrl:  "/uploads/story.jpg",
+		MediaType: "image",
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	anonymousStory := story
+	anonymousStory.IsAnonymous = true
+	poll := db.StorySticker{
+		ID:      uuid.New(),
+		StoryID: story.ID,
+		Kind:    stickerPoll,
+		Prompt:  "Coffee or tea?",
+		Options: []string{"Coffee", "Tea"},
+	}
+
+	stickerURL := func(path string) string {
+		return fmt.Sprintf("/stories/%s/stickers/%s/%s", story.ID, poll.ID, path)
+	}
+	loaded := func(store *mockdb.MockStore, story db.GetStoryByIDRow, userID uuid.UUID) {
+		store.EXPECT().GetStoryByID(gomock.Any(), story.ID).Times(1).Return(story, nil)
+		store.EXPECT().
+			CanViewStory(gomock.Any(), db.CanViewStoryParams{StoryID: story.ID, ViewerID: userID}).
+			Times(1).
+			Return(true, nil)
+		store.EXPECT().
+			GetStorySticker(gomock.Any(), db.GetStoryStickerParams{ID: poll.ID, StoryID: story.ID}).
+			Times(1).
+			Return(poll, nil)
+	}
+	tallied := func(store *mockdb.MockStore) {
+		store.EXPECT().GetStickerTally(gomock.Any(), poll.ID).Times(1).Return(db.GetStickerTallyRow{ResponseCount: 3}, nil)
+		store.EXPECT().ListPollVotes(gomock.Any(), poll.ID).Times(1).Return([]db.ListPollVotesRow{
+			{OptionIndex: 0, Votes: 1},
+			{OptionIndex: 1, Votes: 2},
+		}, nil)
+	}
+	responses := []db.ListStickerResponsesRow{{
+		StickerID:   poll.ID,
+		UserID:      viewerID,
+		OptionIndex: sql.NullInt32{Int32: 1, Valid: true},
+		CreatedAt:   time.Now(),
+		Username:    "viewer",
+	}}
+
+	testCases := []struct {
+		name          string
+		method        string
+		url           string
+		body          gin.H
+		userID        uuid.UUID
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:   "Vote",
+			method: http.MethodPost,
+			url:    stickerURL("respond"),
+			body:   gin.H{"option_index": 1},
+			userID: viewerID,
+			buildStubs: func(store *mockdb.MockStore) {
+				loaded(store, story, viewerID)
+				store.EXPECT().
+					CreateStickerResponse(gomock.Any(), db.CreateStickerResponseParams{
+						StickerID:   poll.ID,
+						UserID:      viewerID,
+						OptionIndex: sql.NullInt32{Int32: 1, Valid: true},
+					}).
+					Times(1).
+					Return(db.StoryStickerResponse{StickerID: poll.ID, UserID: viewerID}, nil)
+				tallied(store)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusCreated, recorder.Code)
+
+				var results stickerResults
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &results))
+				require.Equal(t, []int64{1, 2}, results.Votes)
+			},
+		},
+		{
+			// Within the request's 0-3 range, but the poll only has two options
+			name:   "PollOptionOutOfRange",
+			method: http.MethodPost,
+			url:    stickerURL("respond"),
+			body:   gin.H{"option_index": 2},
+			userID: viewerID,
+			buildStubs: func(store *mockdb.MockStore) {
+				loaded(store, story, viewerID)
+				store.EXPECT().CreateStickerResponse(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "PollWithSliderValue",
+			method: http.MethodPost,
+			url:    stickerURL("respond"),
+			body:   gin.H{"slider_value": 50},
+			userID: viewerID,
+			buildStubs: func(store *mockdb.MockStore) {
+				loaded(store, story, viewerID)
+				store.EXPECT().CreateStickerResponse(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "DuplicateResponse",
+			method: http.MethodPost,
+			url:    stickerURL("respond"),
+			body:   gin.H{"option_index": 0},
+			userID: viewerID,
+			buildStubs: func(store *mockdb.MockStore) {
+				loaded(store, story, viewerID)
+				store.EXPECT().
+					CreateStickerResponse(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.StoryStickerResponse{}, sql.ErrNoRows)
+				store.EXPECT().GetStickerTally(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:   "OwnStory",
+			method: http.MethodPost,
+			url:    stickerURL("respond"),
+			body:   gin.H{"option_index": 0},
+			userID: ownerID,
+			buildStubs: func(store *mockdb.MockStore) {
+				loaded(store, story, ownerID)
+				store.EXPECT().CreateStickerResponse(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:   "ResultsVoter",
+			method: http.MethodGet,
+			url:    stickerURL("results"),
+			userID: viewerID,
+			buildStubs: func(store *mockdb.MockStore) {
+				loaded(store, story, viewerID)
+				store.EXPECT().
+					GetStickerResponse(gomock.Any(), db.GetStickerResponseParams{StickerID: poll.ID, UserID: viewerID}).
+					Times(1).
+					Return(db.StoryStickerResponse{StickerID: poll.ID, UserID: viewerID}, nil)
+				tallied(store)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "ResultsNonVoter",
+			method: http.MethodGet,
+			url:    stickerURL("results"),
+			userID: viewerID,
+			buildStubs: func(store *mockdb.MockStore) {
+				loaded(store, story, viewerID)
+				store.EXPECT().
+					GetStickerResponse(gomock.Any(), db.GetStickerResponseParams{StickerID: poll.ID, UserID: viewerID}).
+					Times(1).
+					Return(db.StoryStickerResponse{}, sql.ErrNoRows)
+				store.EXPECT().GetStickerTally(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "ResultsOwner",
+			method: http.MethodGet,
+			url:    stickerURL("results"),
+			userID: ownerID,
+			buildStubs: func(store *mockdb.MockStore) {
+				loaded(store, story, ownerID)
+				store.EXPECT().GetStickerResponse(gomock.Any(), gomock.Any()).Times(0)
+				tallied(store)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:   "ResponsesNonOwner",
+			method: http.MethodGet,
+			url:    stickerURL("responses"),
+			userID: viewerID,
+			buildStubs: func(store *mockdb.MockStore) {
+				loaded(store, story, viewerID)
+				store.EXPECT().ListStickerResponses(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "ResponsesOwner",
+			method: http.MethodGet,
+			url:    stickerURL("responses"),
+			userID: ownerID,
+			buildStubs: func(store *mockdb.MockStore) {
+				loaded(store, story, ownerID)
+				store.EXPECT().ListStickerResponses(gomock.Any(), poll.ID).Times(1).Return(responses, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var answers []map[string]interface{}
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &answers))
+				require.Len(t, answers, 1)
+				require.Equal(t, viewerID.String(), answers[0]["user_id"])
+				require.Equal(t, "viewer", answers[0]["username"])
+			},
+		},
+		{
+			name:   "ResponsesAnonymousStory",
+			method: http.MethodGet,
+			url:    stickerURL("responses"),
+			userID: ownerID,
+			buildStubs: func(store *mockdb.MockStore) {
+				loaded(store, anonymousStory, ownerID)
+				store.EXPECT().ListStickerResponses(gomock.Any(), poll.ID).Times(1).Return(responses, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var answers []map[string]interface{}
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &answers))
+				require.Len(t, answers, 1)
+				require.NotContains(t, answers[0], "user_id")
+				require.NotContains(t, answers[0], "username")
+				require.NotContains(t, answers[0], "avatar_url")
+				require.EqualValues(t, 1, answers[0]["option_index"])
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockdb.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			var body bytes.Buffer
+			if tc.body != nil {
+				require.NoError(t, json.NewEncoder(&body).Encode(tc.body))
+			}
+			request, err := http.NewRequest(tc.method, tc.url, &body)
+			require.NoError(t, err)
+
+			accessToken, _, err := server.tokenMaker.CreateToken("user", tc.userID, time.Minute)
+			require.NoError(t, err)
+			request.Header.Set(authorizationHeaderKey, authorizationTypeBearer+" "+accessToken)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+
+	"privacy-social-backend/internal/service/trust"
+)
+
+// Key prefix for the per-user hourly story counter
+const storyTrustRateKeyPrefix = "trust_rate:stories:"
+
+// trustLimits returns the feature limits for the user's current trust level
+func (server *Server) trustLimits(ctx context.Context, userID uuid.UUID) (trust.Limits, error) {
+	user, err := server.store.GetUserByID(ctx, userID)
+	if err != nil {
+		return trust.Limits{}, err
+	}
+	return trust.LimitsFor(user.TrustLevel), nil
+}
+
+// recalculateTrust refreshes trust levels after an event that affects them.
+// Failures are logged; the periodic worker catches up.
+func (server *Server) recalculateTrust(ctx context.Context, userIDs ...uuid.UUID) {
+	for _, id := range userIDs {
+		if _, err := server.trust.Recalculate(ctx, id); err != nil {
+			log.Error().Err(err).Str("user_id", id.String()).Msg("failed to recalculate trust")
+			continue
+		}
+		server.invalidateProfileCache(id)
+	}
+}
+
+// storyTrustLimiter caps story creation per hour by trust level.
+// It runs after storyRateLimiter, which remains the hard ceiling. Only stories
+// actually created count, so rejected or invalid requests don't use the quota.
+func (server *Server) storyTrustLimiter() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		authPayload := getAuthPayload(ctx)
+
+		limits, err := server.trustLimits(ctx, authPayload.UserID)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		key := storyTrustRateKeyPrefix + authPayload.UserID.String()
+		count, err := server.redis.Get(ctx, key).Int64()
+		if err != nil && err != redis.Nil {
+			// Redis unavailable: fall back to the global limiter only
+			ctx.Next()
+			return
+		}
+		if count >= limits.StoriesPerHour {
+			ctx.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+				"error": fmt.Sprintf("story limit reached (%d/hour)", limits.StoriesPerHour),
+			})
+			return
+		}
+
+		ctx.Next()
+
+		if ctx.Writer.Status() != http.StatusCreated {
+			return
+		}
+		count, err = server.redis.Incr(ctx, key).Result()
+		if err == nil && count == 1 {
+			server.redis.Expire(ctx, key, time.Hour)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
@@ -9,19 +10,60 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"privacy-social-backend/internal/repository/db"
+	"privacy-social-backend/internal/service/mediahash"
 )
 
+// maxUploadBytes caps the request body of an upload
+const maxUploadBytes = 100 << 20
+
 type uploadResponse struct {
 	URL string `json:"url"`
 }
 
 func (server *Server) uploadFile(ctx *gin.Context) {
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxUploadBytes)
+
 	file, err := ctx.FormFile("file")
 	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			ctx.JSON(http.StatusRequestEntityTooLarge, errorResponse(fmt.Errorf("file exceeds %d MB", maxUploadBytes>>20)))
+			return
+		}
 		ctx.JSON(http.StatusBadRequest, errorResponse(fmt.Errorf("no file uploaded")))
 		return
 	}
 
+	authPayload := getAuthPayload(ctx)
+
+	// Perceptual hash check against the blocklist (images only)
+	var hashes mediahash.Hashes
+	isImage := false
+	if f, err := file.Open(); err == nil {
+		hashes, err = mediahash.Compute(f)
+		f.Close()
+		if err == mediahash.ErrTooLarge {
+			ctx.JSON(http.StatusUnprocessableEntity, errorResponse(err))
+			return
+		}
+		isImage = err == nil
+	}
+	if isImage {
+		blocked, err := server.isMediaBlocked(ctx, hashes)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		if blocked {
+			log.Warn().Str("user_id", authPayload.UserID.String()).Msg("Blocked image upload rejected")
+			ctx.JSON(http.StatusUnprocessableEntity, errorResponse(errMediaBlocked))
+			return
+		}
+	}
+
 	// Generate unique filename
 	extension := filepath.Ext(file.Filename)
 	newFilename := fmt.Sprintf("%s_%d%s", uuid.New().String(), time.Now().Unix(), extension)
@@ -40,7 +82,20 @@ func (server *Server) uploadFile(ctx *gin.Context) {
 	}
 
 	// Return public URL (relative for proxy support)
-	publicURL := fmt.Sprintf("/api/uploads/%s", newFilename)
+	publicURL := uploadsURLPrefix + newFilename
+
+	// Remember the hash so moderators can blocklist this media later
+	if isImage {
+		err := server.store.CreateMediaHash(ctx, db.CreateMediaHashParams{
+			MediaUrl:   publicURL,
+			UploaderID: authPayload.UserID,
+			Dhash:      int64(hashes.DHash),
+			Phash:      int64(hashes.PHash),
+		})
+		if err != nil {
+			log.Error().Err(err).Msg("failed to store media hash")
+		}
+	}
 
 	ctx.JSON(http.StatusOK, uploadResponse{
 		URL: publicURL,
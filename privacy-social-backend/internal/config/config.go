@@ -14,6 +14,55 @@ type Config struct {
 	TokenSymmetricKey    string        `mapstructure:"JWT_SECRET"`
 	AccessTokenDuration  time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
 	RefreshTokenDuration time.Duration `mapstructure:"REFRESH_TOKEN_DURATION"`
+
+	// Fake GPS policy (see api.GPSPolicy)
+	GPSMaxSpeedKmH        float64       `mapstructure:"GPS_MAX_SPEED_KMH"`
+	GPSTeleportDistanceKm float64       `mapstructure:"GPS_TELEPORT_DISTANCE_KM"`
+	GPSTeleportWindow     time.Duration `mapstructure:"GPS_TELEPORT_WINDOW"`
+	GPSMaxClockSkew       time.Duration `mapstructure:"GPS_MAX_CLOCK_SKEW"`
+	GPSStrikeScore        float64       `mapstructure:"GPS_STRIKE_SCORE"`
+	GPSStrikeWindow       time.Duration `mapstructure:"GPS_STRIKE_WINDOW"`
+	GPSDropStrikes        int64         `mapstructure:"GPS_DROP_STRIKES"`
+	GPSRestrictStrikes    int64         `mapstructure:"GPS_RESTRICT_STRIKES"`
+	GPSBanStrikes         int64         `mapstructure:"GPS_BAN_STRIKES"`
+	GPSRestrictDuration   time.Duration `mapstructure:"GPS_RESTRICT_DURATION"`
+
+	// Text moderation (see moderation.ContentModerator)
+	ModerationRulesFile   string  `mapstructure:"MODERATION_RULES_FILE"`
+	ModerationModelURL    string  `mapstructure:"MODERATION_MODEL_URL"`
+	ModerationFlagScore   float64 `mapstructure:"MODERATION_FLAG_SCORE"`
+	ModerationRejectScore float64 `mapstructure:"MODERATION_REJECT_SCORE"`
+
+	// Report escalation (see api.ReportEscalationPolicy)
+	ReportStoryThreshold   float64       `mapstructure:"REPORT_STORY_THRESHOLD"`
+	ReportStoryWindow      time.Duration `mapstructure:"REPORT_STORY_WINDOW"`
+	ReportUserThreshold    float64       `mapstructure:"REPORT_USER_THRESHOLD"`
+	ReportUserWindow       time.Duration `mapstructure:"REPORT_USER_WINDOW"`
+	ReportTrustPenalty     int32         `mapstructure:"REPORT_TRUST_PENALTY"`
+	ReportRestrictDuration time.Duration `mapstructure:"REPORT_RESTRICT_DURATION"`
+
+	// Chat spam detection (see spam.Config)
+	SpamWindow           time.Duration `mapstructure:"SPAM_WINDOW"`
+	SpamFanoutReceivers  int64         `mapstructure:"SPAM_FANOUT_RECEIVERS"`
+	SpamBurstWindow      time.Duration `mapstructure:"SPAM_BURST_WINDOW"`
+	SpamBurstMessages    int64         `mapstructure:"SPAM_BURST_MESSAGES"`
+	SpamNewAccountAge    time.Duration `mapstructure:"SPAM_NEW_ACCOUNT_AGE"`
+	SpamHoldScore        float64       `mapstructure:"SPAM_HOLD_SCORE"`
+	SpamThrottleDuration time.Duration `mapstructure:"SPAM_THROTTLE_DURATION"`
+
+	// Max Hamming distance for a perceptual hash blocklist match
+	MediaHashMaxDistance int `mapstructure:"MEDIA_HASH_MAX_DISTANCE"`
+
+	// Evidence snapshots for reported messages (moderator-only storage)
+	EvidenceDir             string        `mapstructure:"EVIDENCE_DIR"`
+	EvidenceRetention       time.Duration `mapstructure:"EVIDENCE_RETENTION"`
+	EvidenceContextMessages int32         `mapstructure:"EVIDENCE_CONTEXT_MESSAGES"`
+
+	// Email/SMS gateway for emergency contact alerts (see safetyalert.Notifier)
+	AlertWebhookURL string `mapstructure:"ALERT_WEBHOOK_URL"`
+
+	// GeoNames dump for story place labels (see geocode.Geocoder); empty disables labels
+	GeoNamesFile string `mapstructure:"GEONAMES_FILE"`
 }
 
 func LoadConfig(path string) (config Config, err error) {
@@ -23,6 +72,38 @@ func LoadConfig(path string) (config Config, err error) {
 
 	viper.AutomaticEnv()
 
+	viper.SetDefault("GPS_MAX_SPEED_KMH", 1000.0)
+	viper.SetDefault("GPS_TELEPORT_DISTANCE_KM", 50.0)
+	viper.SetDefault("GPS_TELEPORT_WINDOW", 30*time.Minute)
+	viper.SetDefault("GPS_MAX_CLOCK_SKEW", 5*time.Minute)
+	viper.SetDefault("GPS_STRIKE_SCORE", 1.0)
+	viper.SetDefault("GPS_STRIKE_WINDOW", 30*24*time.Hour)
+	viper.SetDefault("GPS_DROP_STRIKES", 2)
+	viper.SetDefault("GPS_RESTRICT_STRIKES", 4)
+	viper.SetDefault("GPS_BAN_STRIKES", 6)
+	viper.SetDefault("GPS_RESTRICT_DURATION", 7*24*time.Hour)
+	viper.SetDefault("MODERATION_RULES_FILE", "")
+	viper.SetDefault("MODERATION_MODEL_URL", "")
+	viper.SetDefault("MODERATION_FLAG_SCORE", 0.7)
+	viper.SetDefault("MODERATION_REJECT_SCORE", 0.95)
+	viper.SetDefault("MEDIA_HASH_MAX_DISTANCE", 8)
+	viper.SetDefault("REPORT_STORY_THRESHOLD", 3.0)
+	viper.SetDefault("REPORT_STORY_WINDOW", time.Hour)
+	viper.SetDefault("REPORT_USER_THRESHOLD", 5.0)
+	viper.SetDefault("REPORT_USER_WINDOW", 7*24*time.Hour)
+	viper.SetDefault("REPORT_TRUST_PENALTY", 3)
+	viper.SetDefault("REPORT_RESTRICT_DURATION", 72*time.Hour)
+	viper.SetDefault("SPAM_WINDOW", 10*time.Minute)
+	viper.SetDefault("SPAM_FANOUT_RECEIVERS", 5)
+	viper.SetDefault("SPAM_NEW_ACCOUNT_AGE", 48*time.Hour)
+	viper.SetDefault("SPAM_HOLD_SCORE", 1.0)
+	viper.SetDefault("SPAM_THROTTLE_DURATION", 30*time.Minute)
+	viper.SetDefault("EVIDENCE_DIR", "evidence")
+	viper.SetDefault("EVIDENCE_RETENTION", 180*24*time.Hour)
+	viper.SetDefault("EVIDENCE_CONTEXT_MESSAGES", 5)
+	viper.SetDefault("ALERT_WEBHOOK_URL", "")
+	viper.SetDefault("GEONAMES_FILE", "")
+
 	err = viper.ReadInConfig()
 	if err != nil {
 		return
@@ -9,20 +9,30 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 const archiveStory = `-- name: ArchiveStory :one
 INSERT INTO archived_stories (
     user_id, story_id, media_url, media_type, caption,
-    geohash, geom, is_anonymous, show_location, original_created_at
+    geohash, geom, is_anonymous, show_location, original_created_at,
+    view_count, reaction_count, place_label
 )
 SELECT 
     s.user_id, s.id, s.media_url, s.media_type, s.caption,
-    s.geohash, s.geom, s.is_anonymous, s.show_location, s.created_at
+    s.geohash, s.geom, s.is_anonymous, s.show_location, s.created_at,
+    (SELECT COUNT(*) FROM story_views v WHERE v.story_id = s.id),
+    (SELECT COUNT(*) FROM story_reactions r WHERE r.story_id = s.id),
+    s.place_label
 FROM stories s
 WHERE s.id = $1 AND s.user_id = $2
+  -- Stories held for review can't be archived until they're approved
+  AND NOT EXISTS (
+    SELECT 1 FROM content_flags cf
+    WHERE cf.content_type = 'story' AND cf.content_id = s.id AND cf.status IN ('pending', 'removed')
+  )
 ON CONFLICT (user_id, story_id) DO NOTHING
-RETURNING id, user_id, story_id, media_url, media_type, caption, geohash, geom, is_anonymous, show_location, original_created_at, archived_at, created_at
+RETURNING id, user_id, story_id, media_url, media_type, caption, geohash, geom, is_anonymous, show_location, original_created_at, archived_at, created_at, view_count, reaction_count, place_label
 `
 
 type ArchiveStoryParams struct {
@@ -47,10 +57,54 @@ func (q *Queries) ArchiveStory(ctx context.Context, arg ArchiveStoryParams) (Arc
 		&i.OriginalCreatedAt,
 		&i.ArchivedAt,
 		&i.CreatedAt,
+		&i.ViewCount,
+		&i.ReactionCount,
+		&i.PlaceLabel,
 	)
 	return i, err
 }
 
+const archiveStoryItems = `-- name: ArchiveStoryItems :many
+INSERT INTO archived_story_items (archive_id, position, media_url, media_type, caption, duration_ms)
+SELECT a.id, si.position, si.media_url, si.media_type, si.caption, si.duration_ms
+FROM archived_stories a
+JOIN story_items si ON si.story_id = a.story_id
+WHERE a.id = $1
+ON CONFLICT (archive_id, position) DO NOTHING
+RETURNING archive_id, position, media_url, media_type, caption, duration_ms
+`
+
+// Copies a sequence's items into the archive. Run with ArchiveStory, in its transaction.
+func (q *Queries) ArchiveStoryItems(ctx context.Context, id uuid.UUID) ([]ArchivedStoryItem, error) {
+	rows, err := q.db.QueryContext(ctx, archiveStoryItems, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ArchivedStoryItem
+	for rows.Next() {
+		var i ArchivedStoryItem
+		if err := rows.Scan(
+			&i.ArchiveID,
+			&i.Position,
+			&i.MediaUrl,
+			&i.MediaType,
+			&i.Caption,
+			&i.DurationMs,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const countArchivedStories = `-- name: CountArchivedStories :one
 SELECT COUNT(*) FROM archived_stories
 WHERE user_id = $1
@@ -78,8 +132,19 @@ func (q *Queries) DeleteArchivedStory(ctx context.Context, arg DeleteArchivedSto
 	return err
 }
 
+const deleteStoryArchives = `-- name: DeleteStoryArchives :exec
+DELETE FROM archived_stories
+WHERE story_id = $1
+`
+
+// Removes every archive copy of a story. Run with moderation deletes, in their transaction.
+func (q *Queries) DeleteStoryArchives(ctx context.Context, storyID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteStoryArchives, storyID)
+	return err
+}
+
 const getArchivedStories = `-- name: GetArchivedStories :many
-SELECT id, user_id, story_id, media_url, media_type, caption, geohash, geom, is_anonymous, show_location, original_created_at, archived_at, created_at FROM archived_stories
+SELECT id, user_id, story_id, media_url, media_type, caption, geohash, geom, is_anonymous, show_location, original_created_at, archived_at, created_at, view_count, reaction_count, place_label FROM archived_stories
 WHERE user_id = $1
 ORDER BY archived_at DESC
 LIMIT $2 OFFSET $3
@@ -114,6 +179,9 @@ func (q *Queries) GetArchivedStories(ctx context.Context, arg GetArchivedStories
 			&i.OriginalCreatedAt,
 			&i.ArchivedAt,
 			&i.CreatedAt,
+			&i.ViewCount,
+			&i.ReactionCount,
+			&i.PlaceLabel,
 		); err != nil {
 			return nil, err
 		}
@@ -129,7 +197,7 @@ func (q *Queries) GetArchivedStories(ctx context.Context, arg GetArchivedStories
 }
 
 const getArchivedStory = `-- name: GetArchivedStory :one
-SELECT id, user_id, story_id, media_url, media_type, caption, geohash, geom, is_anonymous, show_location, original_created_at, archived_at, created_at FROM archived_stories
+SELECT id, user_id, story_id, media_url, media_type, caption, geohash, geom, is_anonymous, show_location, original_created_at, archived_at, created_at, view_count, reaction_count, place_label FROM archived_stories
 WHERE id = $1 AND user_id = $2
 `
 
@@ -155,6 +223,45 @@ func (q *Queries) GetArchivedStory(ctx context.Context, arg GetArchivedStoryPara
 		&i.OriginalCreatedAt,
 		&i.ArchivedAt,
 		&i.CreatedAt,
+		&i.ViewCount,
+		&i.ReactionCount,
+		&i.PlaceLabel,
 	)
 	return i, err
 }
+
+const listArchivedStoryItems = `-- name: ListArchivedStoryItems :many
+SELECT archive_id, position, media_url, media_type, caption, duration_ms FROM archived_story_items
+WHERE archive_id = ANY($1::uuid[])
+ORDER BY archive_id, position
+`
+
+func (q *Queries) ListArchivedStoryItems(ctx context.Context, archiveIds []uuid.UUID) ([]ArchivedStoryItem, error) {
+	rows, err := q.db.QueryContext(ctx, listArchivedStoryItems, pq.Array(archiveIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ArchivedStoryItem
+	for rows.Next() {
+		var i ArchivedStoryItem
+		if err := rows.Scan(
+			&i.ArchiveID,
+			&i.Position,
+			&i.MediaUrl,
+			&i.MediaType,
+			&i.Caption,
+			&i.DurationMs,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
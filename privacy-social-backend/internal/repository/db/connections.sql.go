@@ -126,6 +126,7 @@ SELECT
 FROM users u
 WHERE u.id NOT IN (SELECT id FROM excluded_users)
 AND u.is_shadow_banned = false
+AND u.trust_level >= $3
 ORDER BY mutual_count DESC, u.created_at DESC
 LIMIT $2
 `
@@ -133,6 +134,7 @@ LIMIT $2
 type GetSuggestedConnectionsParams struct {
 	RequesterID uuid.UUID `json:"requester_id"`
 	Limit       int32     `json:"limit"`
+	TrustLevel  int32     `json:"trust_level"`
 }
 
 type GetSuggestedConnectionsRow struct {
@@ -144,7 +146,7 @@ type GetSuggestedConnectionsRow struct {
 }
 
 func (q *Queries) GetSuggestedConnections(ctx context.Context, arg GetSuggestedConnectionsParams) ([]GetSuggestedConnectionsRow, error) {
-	rows, err := q.db.QueryContext(ctx, getSuggestedConnections, arg.RequesterID, arg.Limit)
+	rows, err := q.db.QueryContext(ctx, getSuggestedConnections, arg.RequesterID, arg.Limit, arg.TrustLevel)
 	if err != nil {
 		return nil, err
 	}
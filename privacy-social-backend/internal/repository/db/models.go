@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/sqlc-dev/pqtype"
 )
 
 type ConnectionStatus string
@@ -65,6 +66,10 @@ const (
 	NotificationTypeCrossingDetected   NotificationType = "crossing_detected"
 	NotificationTypeMessageReceived    NotificationType = "message_received"
 	NotificationTypeStoryReaction      NotificationType = "story_reaction"
+	NotificationTypeSafetyWarning      NotificationType = "safety_warning"
+	NotificationTypeReportReviewed     NotificationType = "report_reviewed"
+	NotificationTypeSafetyAlert        NotificationType = "safety_alert"
+	NotificationTypeStoryExpiring      NotificationType = "story_expiring"
 )
 
 func (e *NotificationType) Scan(src interface{}) error {
@@ -246,6 +251,30 @@ type ArchivedStory struct {
 	OriginalCreatedAt time.Time      `json:"original_created_at"`
 	ArchivedAt        sql.NullTime   `json:"archived_at"`
 	CreatedAt         sql.NullTime   `json:"created_at"`
+	ViewCount         int32          `json:"view_count"`
+	ReactionCount     int32          `json:"reaction_count"`
+	PlaceLabel        sql.NullString `json:"place_label"`
+}
+
+type ArchivedStoryItem struct {
+	ArchiveID  uuid.UUID      `json:"archive_id"`
+	Position   int32          `json:"position"`
+	MediaUrl   string         `json:"media_url"`
+	MediaType  string         `json:"media_type"`
+	Caption    sql.NullString `json:"caption"`
+	DurationMs int32          `json:"duration_ms"`
+}
+
+type BanAppeal struct {
+	ID                 uuid.UUID      `json:"id"`
+	UserID             uuid.UUID      `json:"user_id"`
+	ModerationActionID uuid.NullUUID  `json:"moderation_action_id"`
+	Message            string         `json:"message"`
+	Status             string         `json:"status"`
+	ReviewerID         uuid.NullUUID  `json:"reviewer_id"`
+	DecisionNote       sql.NullString `json:"decision_note"`
+	CreatedAt          time.Time      `json:"created_at"`
+	DecidedAt          sql.NullTime   `json:"decided_at"`
 }
 
 type BlockedUser struct {
@@ -263,6 +292,21 @@ type Connection struct {
 	UpdatedAt   time.Time        `json:"updated_at"`
 }
 
+type ContentFlag struct {
+	ID          uuid.UUID     `json:"id"`
+	ContentType string        `json:"content_type"`
+	ContentID   uuid.UUID     `json:"content_id"`
+	UserID      uuid.UUID     `json:"user_id"`
+	Field       string        `json:"field"`
+	Content     string        `json:"content"`
+	Rule        string        `json:"rule"`
+	Score       float32       `json:"score"`
+	Status      string        `json:"status"`
+	ReviewerID  uuid.NullUUID `json:"reviewer_id"`
+	ReviewedAt  sql.NullTime  `json:"reviewed_at"`
+	CreatedAt   time.Time     `json:"created_at"`
+}
+
 type Crossing struct {
 	ID             uuid.UUID `json:"id"`
 	UserID1        uuid.UUID `json:"user_id_1"`
@@ -272,6 +316,41 @@ type Crossing struct {
 	CreatedAt      time.Time `json:"created_at"`
 }
 
+type EmergencyContact struct {
+	ID            uuid.UUID      `json:"id"`
+	UserID        uuid.UUID      `json:"user_id"`
+	ContactUserID uuid.NullUUID  `json:"contact_user_id"`
+	Name          string         `json:"name"`
+	Email         sql.NullString `json:"email"`
+	Phone         sql.NullString `json:"phone"`
+	CreatedAt     time.Time      `json:"created_at"`
+}
+
+type HiddenWord struct {
+	ID        uuid.UUID `json:"id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Phrase    string    `json:"phrase"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Highlight struct {
+	ID             uuid.UUID         `json:"id"`
+	UserID         uuid.UUID         `json:"user_id"`
+	Title          string            `json:"title"`
+	CoverArchiveID uuid.NullUUID     `json:"cover_archive_id"`
+	Audience       StoryAvailability `json:"audience"`
+	Position       int32             `json:"position"`
+	CreatedAt      time.Time         `json:"created_at"`
+	UpdatedAt      time.Time         `json:"updated_at"`
+}
+
+type HighlightItem struct {
+	HighlightID uuid.UUID `json:"highlight_id"`
+	ArchiveID   uuid.UUID `json:"archive_id"`
+	Position    int32     `json:"position"`
+	AddedAt     time.Time `json:"added_at"`
+}
+
 type Location struct {
 	ID         uuid.UUID   `json:"id"`
 	UserID     uuid.UUID   `json:"user_id"`
@@ -282,6 +361,46 @@ type Location struct {
 	ExpiresAt  time.Time   `json:"expires_at"`
 }
 
+type LocationStrike struct {
+	ID        uuid.UUID       `json:"id"`
+	UserID    uuid.UUID       `json:"user_id"`
+	Score     float32         `json:"score"`
+	Signals   json.RawMessage `json:"signals"`
+	Action    string          `json:"action"`
+	Geohash   string          `json:"geohash"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type MediaBlocklist struct {
+	ID            uuid.UUID     `json:"id"`
+	Dhash         int64         `json:"dhash"`
+	Phash         int64         `json:"phash"`
+	Reason        string        `json:"reason"`
+	SourceStoryID uuid.NullUUID `json:"source_story_id"`
+	AddedBy       uuid.NullUUID `json:"added_by"`
+	CreatedAt     time.Time     `json:"created_at"`
+}
+
+type MediaHash struct {
+	MediaUrl   string    `json:"media_url"`
+	UploaderID uuid.UUID `json:"uploader_id"`
+	Dhash      int64     `json:"dhash"`
+	Phash      int64     `json:"phash"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type MeetupCheckin struct {
+	ID            uuid.UUID      `json:"id"`
+	UserID        uuid.UUID      `json:"user_id"`
+	Note          sql.NullString `json:"note"`
+	Deadline      time.Time      `json:"deadline"`
+	Status        string         `json:"status"`
+	ClosedAt      sql.NullTime   `json:"closed_at"`
+	CreatedAt     time.Time      `json:"created_at"`
+	AlertAttempts int32          `json:"alert_attempts"`
+	NextAlertAt   sql.NullTime   `json:"next_alert_at"`
+}
+
 type Message struct {
 	ID         uuid.UUID      `json:"id"`
 	SenderID   uuid.UUID      `json:"sender_id"`
@@ -303,6 +422,42 @@ type MessageReaction struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
+type MessageStoryRef struct {
+	MessageID      uuid.UUID      `json:"message_id"`
+	StoryID        uuid.NullUUID  `json:"story_id"`
+	StoryOwnerID   uuid.UUID      `json:"story_owner_id"`
+	MediaType      string         `json:"media_type"`
+	ThumbnailUrl   sql.NullString `json:"thumbnail_url"`
+	Caption        sql.NullString `json:"caption"`
+	StoryCreatedAt time.Time      `json:"story_created_at"`
+	CreatedAt      time.Time      `json:"created_at"`
+}
+
+type ModerationAction struct {
+	ID        uuid.UUID       `json:"id"`
+	UserID    uuid.UUID       `json:"user_id"`
+	Action    string          `json:"action"`
+	Source    string          `json:"source"`
+	Reason    string          `json:"reason"`
+	Evidence  json.RawMessage `json:"evidence"`
+	ActorID   uuid.NullUUID   `json:"actor_id"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type ModerationAudit struct {
+	ID         uuid.UUID             `json:"id"`
+	ActorID    uuid.UUID             `json:"actor_id"`
+	ActorRole  string                `json:"actor_role"`
+	Action     string                `json:"action"`
+	TargetType string                `json:"target_type"`
+	TargetID   uuid.UUID             `json:"target_id"`
+	Reason     string                `json:"reason"`
+	Before     pqtype.NullRawMessage `json:"before"`
+	After      pqtype.NullRawMessage `json:"after"`
+	RequestID  string                `json:"request_id"`
+	CreatedAt  time.Time             `json:"created_at"`
+}
+
 type Notification struct {
 	ID                uuid.UUID        `json:"id"`
 	UserID            uuid.UUID        `json:"user_id"`
@@ -323,6 +478,8 @@ type PrivacySetting struct {
 	ShowLocation     sql.NullBool   `json:"show_location"`
 	CreatedAt        sql.NullTime   `json:"created_at"`
 	UpdatedAt        sql.NullTime   `json:"updated_at"`
+	AutoArchive      bool           `json:"auto_archive"`
+	StoryReplies     string         `json:"story_replies"`
 }
 
 type ProfileView struct {
@@ -333,14 +490,70 @@ type ProfileView struct {
 }
 
 type Report struct {
-	ID            uuid.UUID      `json:"id"`
-	ReporterID    uuid.UUID      `json:"reporter_id"`
-	TargetUserID  uuid.NullUUID  `json:"target_user_id"`
-	TargetStoryID uuid.NullUUID  `json:"target_story_id"`
-	Reason        ReportReason   `json:"reason"`
-	Description   sql.NullString `json:"description"`
-	IsResolved    bool           `json:"is_resolved"`
-	CreatedAt     time.Time      `json:"created_at"`
+	ID              uuid.UUID      `json:"id"`
+	ReporterID      uuid.NullUUID  `json:"reporter_id"`
+	TargetUserID    uuid.NullUUID  `json:"target_user_id"`
+	TargetStoryID   uuid.NullUUID  `json:"target_story_id"`
+	Reason          ReportReason   `json:"reason"`
+	Description     sql.NullString `json:"description"`
+	IsResolved      bool           `json:"is_resolved"`
+	CreatedAt       time.Time      `json:"created_at"`
+	Source          string         `json:"source"`
+	Status          string         `json:"status"`
+	Priority        string         `json:"priority"`
+	AssigneeID      uuid.NullUUID  `json:"assignee_id"`
+	ClosedAt        sql.NullTime   `json:"closed_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	TargetType      string         `json:"target_type"`
+	TargetMessageID uuid.NullUUID  `json:"target_message_id"`
+}
+
+type ReportEscalation struct {
+	ID              uuid.UUID     `json:"id"`
+	TargetType      string        `json:"target_type"`
+	TargetID        uuid.UUID     `json:"target_id"`
+	UserID          uuid.UUID     `json:"user_id"`
+	Action          string        `json:"action"`
+	WeightedReports float32       `json:"weighted_reports"`
+	Reporters       int32         `json:"reporters"`
+	ContentFlagID   uuid.NullUUID `json:"content_flag_id"`
+	TrustPenalty    int32         `json:"trust_penalty"`
+	RevertedAt      sql.NullTime  `json:"reverted_at"`
+	RevertedBy      uuid.NullUUID `json:"reverted_by"`
+	CreatedAt       time.Time     `json:"created_at"`
+	ExpiresAt       sql.NullTime  `json:"expires_at"`
+}
+
+type ReportEvidence struct {
+	ID               uuid.UUID      `json:"id"`
+	ReportID         uuid.UUID      `json:"report_id"`
+	MessageID        uuid.UUID      `json:"message_id"`
+	SenderID         uuid.UUID      `json:"sender_id"`
+	ReceiverID       uuid.UUID      `json:"receiver_id"`
+	Content          string         `json:"content"`
+	MediaType        sql.NullString `json:"media_type"`
+	OriginalMediaUrl sql.NullString `json:"original_media_url"`
+	MediaPath        sql.NullString `json:"media_path"`
+	IsReported       bool           `json:"is_reported"`
+	SentAt           time.Time      `json:"sent_at"`
+	ExpiresAt        time.Time      `json:"expires_at"`
+	CreatedAt        time.Time      `json:"created_at"`
+}
+
+type ReportNote struct {
+	ID        uuid.UUID     `json:"id"`
+	ReportID  uuid.UUID     `json:"report_id"`
+	AuthorID  uuid.NullUUID `json:"author_id"`
+	Body      string        `json:"body"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+type ReportOutcome struct {
+	ID        uuid.UUID     `json:"id"`
+	ReportID  uuid.UUID     `json:"report_id"`
+	Action    string        `json:"action"`
+	ActorID   uuid.NullUUID `json:"actor_id"`
+	CreatedAt time.Time     `json:"created_at"`
 }
 
 type Session struct {
@@ -369,6 +582,24 @@ type Story struct {
 	IsAnonymous  bool              `json:"is_anonymous"`
 	IsPremium    sql.NullBool      `json:"is_premium"`
 	ShowLocation bool              `json:"show_location"`
+	PlaceLabel   sql.NullString    `json:"place_label"`
+}
+
+type StoryItem struct {
+	ID         uuid.UUID      `json:"id"`
+	StoryID    uuid.UUID      `json:"story_id"`
+	Position   int32          `json:"position"`
+	MediaUrl   string         `json:"media_url"`
+	MediaType  string         `json:"media_type"`
+	Caption    sql.NullString `json:"caption"`
+	DurationMs int32          `json:"duration_ms"`
+	CreatedAt  time.Time      `json:"created_at"`
+}
+
+type StoryItemView struct {
+	ItemID   uuid.UUID `json:"item_id"`
+	UserID   uuid.UUID `json:"user_id"`
+	ViewedAt time.Time `json:"viewed_at"`
 }
 
 type StoryMention struct {
@@ -386,6 +617,25 @@ type StoryReaction struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
+type StorySticker struct {
+	ID        uuid.UUID      `json:"id"`
+	StoryID   uuid.UUID      `json:"story_id"`
+	Kind      string         `json:"kind"`
+	Prompt    string         `json:"prompt"`
+	Options   []string       `json:"options"`
+	Emoji     sql.NullString `json:"emoji"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+type StoryStickerResponse struct {
+	StickerID   uuid.UUID      `json:"sticker_id"`
+	UserID      uuid.UUID      `json:"user_id"`
+	OptionIndex sql.NullInt32  `json:"option_index"`
+	SliderValue sql.NullInt32  `json:"slider_value"`
+	Answer      sql.NullString `json:"answer"`
+	CreatedAt   time.Time      `json:"created_at"`
+}
+
 type StoryView struct {
 	ID        uuid.UUID `json:"id"`
 	StoryID   uuid.UUID `json:"story_id"`
@@ -421,3 +671,24 @@ type User struct {
 	WebsiteUrl             sql.NullString  `json:"website_url"`
 	Links                  json.RawMessage `json:"links"`
 }
+
+type UserMute struct {
+	ID           uuid.UUID    `json:"id"`
+	MuterID      uuid.UUID    `json:"muter_id"`
+	MutedID      uuid.UUID    `json:"muted_id"`
+	MuteStories  bool         `json:"mute_stories"`
+	MuteMessages bool         `json:"mute_messages"`
+	ExpiresAt    sql.NullTime `json:"expires_at"`
+	CreatedAt    time.Time    `json:"created_at"`
+}
+
+type UserRestriction struct {
+	ID        uuid.UUID    `json:"id"`
+	UserID    uuid.UUID    `json:"user_id"`
+	Kind      string       `json:"kind"`
+	Source    string       `json:"source"`
+	Reason    string       `json:"reason"`
+	ExpiresAt time.Time    `json:"expires_at"`
+	LiftedAt  sql.NullTime `json:"lifted_at"`
+	CreatedAt time.Time    `json:"created_at"`
+}
@@ -12,8 +12,14 @@ import (
 )
 
 const countUnreadNotifications = `-- name: CountUnreadNotifications :one
-SELECT COUNT(*) FROM notifications
+SELECT COUNT(*) FROM notifications n
 WHERE user_id = $1 AND is_read = false
+  -- Block Logic: hide notifications about blocked users until unblocked
+  AND NOT EXISTS (
+    SELECT 1 FROM blocked_users bu
+    WHERE (bu.blocker_id = $1 AND bu.blocked_id = n.related_user_id)
+       OR (bu.blocker_id = n.related_user_id AND bu.blocked_id = $1)
+  )
 `
 
 func (q *Queries) CountUnreadNotifications(ctx context.Context, userID uuid.UUID) (int64, error) {
@@ -85,8 +91,14 @@ func (q *Queries) DeleteOldNotifications(ctx context.Context) error {
 }
 
 const listNotifications = `-- name: ListNotifications :many
-SELECT id, user_id, type, title, message, related_user_id, related_story_id, related_crossing_id, is_read, created_at FROM notifications
+SELECT id, user_id, type, title, message, related_user_id, related_story_id, related_crossing_id, is_read, created_at FROM notifications n
 WHERE user_id = $1
+  -- Block Logic: hide notifications about blocked users until unblocked
+  AND NOT EXISTS (
+    SELECT 1 FROM blocked_users bu
+    WHERE (bu.blocker_id = $1 AND bu.blocked_id = n.related_user_id)
+       OR (bu.blocker_id = n.related_user_id AND bu.blocked_id = $1)
+  )
 ORDER BY created_at DESC
 LIMIT $2 OFFSET $3
 `
@@ -13,7 +13,7 @@ import (
 )
 
 const getPrivacySettings = `-- name: GetPrivacySettings :one
-SELECT user_id, who_can_message, who_can_see_stories, show_location, created_at, updated_at FROM privacy_settings WHERE user_id = $1
+SELECT user_id, who_can_message, who_can_see_stories, show_location, created_at, updated_at, auto_archive, story_replies FROM privacy_settings WHERE user_id = $1
 `
 
 func (q *Queries) GetPrivacySettings(ctx context.Context, userID uuid.UUID) (PrivacySetting, error) {
@@ -26,22 +26,28 @@ func (q *Queries) GetPrivacySettings(ctx context.Context, userID uuid.UUID) (Pri
 		&i.ShowLocation,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.AutoArchive,
+		&i.StoryReplies,
 	)
 	return i, err
 }
 
 const upsertPrivacySettings = `-- name: UpsertPrivacySettings :one
 INSERT INTO privacy_settings (
-    user_id, who_can_message, who_can_see_stories, show_location
+    user_id, who_can_message, who_can_see_stories, show_location, auto_archive, story_replies
 ) VALUES (
-    $1, $2, $3, $4
+    $1, $2, $3, $4, COALESCE($5::boolean, false),
+    COALESCE($6::varchar, 'connections')
 ) ON CONFLICT (user_id) DO UPDATE
 SET 
     who_can_message = EXCLUDED.who_can_message,
     who_can_see_stories = EXCLUDED.who_can_see_stories,
     show_location = EXCLUDED.show_location,
+    -- Left unchanged when omitted
+    auto_archive = COALESCE($5::boolean, privacy_settings.auto_archive),
+    story_replies = COALESCE($6::varchar, privacy_settings.story_replies),
     updated_at = NOW()
-RETURNING user_id, who_can_message, who_can_see_stories, show_location, created_at, updated_at
+RETURNING user_id, who_can_message, who_can_see_stories, show_location, created_at, updated_at, auto_archive, story_replies
 `
 
 type UpsertPrivacySettingsParams struct {
@@ -49,6 +55,8 @@ type UpsertPrivacySettingsParams struct {
 	WhoCanMessage    sql.NullString `json:"who_can_message"`
 	WhoCanSeeStories sql.NullString `json:"who_can_see_stories"`
 	ShowLocation     sql.NullBool   `json:"show_location"`
+	AutoArchive      sql.NullBool   `json:"auto_archive"`
+	StoryReplies     sql.NullString `json:"story_replies"`
 }
 
 func (q *Queries) UpsertPrivacySettings(ctx context.Context, arg UpsertPrivacySettingsParams) (PrivacySetting, error) {
@@ -57,6 +65,8 @@ func (q *Queries) UpsertPrivacySettings(ctx context.Context, arg UpsertPrivacySe
 		arg.WhoCanMessage,
 		arg.WhoCanSeeStories,
 		arg.ShowLocation,
+		arg.AutoArchive,
+		arg.StoryReplies,
 	)
 	var i PrivacySetting
 	err := row.Scan(
@@ -66,6 +76,8 @@ func (q *Queries) UpsertPrivacySettings(ctx context.Context, arg UpsertPrivacySe
 		&i.ShowLocation,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.AutoArchive,
+		&i.StoryReplies,
 	)
 	return i, err
 }
@@ -7,45 +7,116 @@ package db
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type Querier interface {
+	AddCloseFriend(ctx context.Context, arg AddCloseFriendParams) error
+	// Adds one of the owner's archived stories to the end of their highlight.
+	// No row means the highlight or the archived story isn't theirs.
+	AddHighlightItem(ctx context.Context, arg AddHighlightItemParams) (uuid.UUID, error)
+	AddMediaBlocklistEntry(ctx context.Context, arg AddMediaBlocklistEntryParams) (MediaBlocklist, error)
+	AddReportToEscalation(ctx context.Context, arg AddReportToEscalationParams) error
+	// Shift trust by a delta, never below zero
+	AdjustUserTrust(ctx context.Context, arg AdjustUserTrustParams) (int32, error)
 	ArchiveStory(ctx context.Context, arg ArchiveStoryParams) (ArchivedStory, error)
+	// Copies a sequence's items into the archive. Run with ArchiveStory, in its transaction.
+	ArchiveStoryItems(ctx context.Context, id uuid.UUID) ([]ArchivedStoryItem, error)
+	// Copies a batch of stories expired before the cutoff, of users with auto-archive
+	// on, into their archive with engagement counts and sequence items. Run before
+	// DeleteExpiredStories with the same cutoff. Stories held or removed by
+	// moderators are never archived.
+	AutoArchiveExpiredStories(ctx context.Context, arg AutoArchiveExpiredStoriesParams) ([]uuid.UUID, error)
 	BanUser(ctx context.Context, arg BanUserParams) (User, error)
 	BlockUser(ctx context.Context, arg BlockUserParams) (BlockedUser, error)
 	BoostUser(ctx context.Context, arg BoostUserParams) (User, error)
+	// Whether the viewer is in the story's audience. Mirrors the visibility rules of the feed queries.
+	CanViewStory(ctx context.Context, arg CanViewStoryParams) (bool, error)
+	// Deletes a story that hasn't been published yet
+	CancelScheduledStory(ctx context.Context, arg CancelScheduledStoryParams) (uuid.UUID, error)
+	// Claims missed check-ins for alerting. The claim is a lease: if delivery
+	// fails the check-in is claimed again after next_alert_at, backing off up to
+	// 15 minutes, so a missed check-in is never dropped.
+	ClaimOverdueMeetupCheckins(ctx context.Context, limit int32) ([]MeetupCheckin, error)
+	// Confirm or cancel the active check-in before the worker alerts
+	CloseMeetupCheckin(ctx context.Context, arg CloseMeetupCheckinParams) (MeetupCheckin, error)
 	CountArchivedStories(ctx context.Context, userID uuid.UUID) (int64, error)
 	CountConnectionRequestsToday(ctx context.Context, requesterID uuid.UUID) (int64, error)
 	CountCrossingsToday(ctx context.Context, userID1 uuid.UUID) (int64, error)
+	CountEmergencyContacts(ctx context.Context, userID uuid.UUID) (int64, error)
+	// Strikes issued before the user was last unbanned (e.g. an approved appeal) no longer count
+	CountLocationStrikesSince(ctx context.Context, arg CountLocationStrikesSinceParams) (int64, error)
 	CountStoryReactions(ctx context.Context, storyID uuid.UUID) (int64, error)
 	CountStoryViews(ctx context.Context, storyID uuid.UUID) (int64, error)
 	CountUnreadNotifications(ctx context.Context, userID uuid.UUID) (int64, error)
 	CountUsers(ctx context.Context) (int64, error)
+	CreateBanAppeal(ctx context.Context, arg CreateBanAppealParams) (BanAppeal, error)
 	CreateConnectionRequest(ctx context.Context, arg CreateConnectionRequestParams) (Connection, error)
+	CreateContentFlag(ctx context.Context, arg CreateContentFlagParams) (ContentFlag, error)
 	CreateCrossing(ctx context.Context, arg CreateCrossingParams) (Crossing, error)
+	CreateEmergencyContact(ctx context.Context, arg CreateEmergencyContactParams) (EmergencyContact, error)
+	CreateHiddenWord(ctx context.Context, arg CreateHiddenWordParams) (HiddenWord, error)
+	CreateHighlight(ctx context.Context, arg CreateHighlightParams) (Highlight, error)
 	CreateLocation(ctx context.Context, arg CreateLocationParams) (Location, error)
+	CreateLocationStrike(ctx context.Context, arg CreateLocationStrikeParams) (LocationStrike, error)
+	CreateMediaHash(ctx context.Context, arg CreateMediaHashParams) error
+	CreateMeetupCheckin(ctx context.Context, arg CreateMeetupCheckinParams) (MeetupCheckin, error)
 	CreateMessage(ctx context.Context, arg CreateMessageParams) (Message, error)
 	CreateMessageReaction(ctx context.Context, arg CreateMessageReactionParams) (MessageReaction, error)
+	CreateMessageStoryRef(ctx context.Context, arg CreateMessageStoryRefParams) (MessageStoryRef, error)
+	CreateModerationAction(ctx context.Context, arg CreateModerationActionParams) (ModerationAction, error)
+	CreateModerationAudit(ctx context.Context, arg CreateModerationAuditParams) error
 	CreateNotification(ctx context.Context, arg CreateNotificationParams) (Notification, error)
 	CreateReport(ctx context.Context, arg CreateReportParams) (Report, error)
+	CreateReportEscalation(ctx context.Context, arg CreateReportEscalationParams) (ReportEscalation, error)
+	CreateReportEvidence(ctx context.Context, arg CreateReportEvidenceParams) (ReportEvidence, error)
+	CreateReportNote(ctx context.Context, arg CreateReportNoteParams) (ReportNote, error)
+	CreateReportOutcome(ctx context.Context, arg CreateReportOutcomeParams) (ReportOutcome, error)
 	CreateSession(ctx context.Context, arg CreateSessionParams) (Session, error)
+	// No row means the viewer already responded
+	CreateStickerResponse(ctx context.Context, arg CreateStickerResponseParams) (StoryStickerResponse, error)
 	CreateStory(ctx context.Context, arg CreateStoryParams) (CreateStoryRow, error)
+	// Notifies owners of stories expiring before the given time that would be lost:
+	// auto-archive off and not archived by hand. Each story is announced once.
+	CreateStoryExpiryNotices(ctx context.Context, expiresBefore time.Time) ([]uuid.NullUUID, error)
+	CreateStoryItem(ctx context.Context, arg CreateStoryItemParams) (StoryItem, error)
 	CreateStoryMention(ctx context.Context, arg CreateStoryMentionParams) (StoryMention, error)
 	// Story Reactions
 	CreateStoryReaction(ctx context.Context, arg CreateStoryReactionParams) (StoryReaction, error)
+	CreateStorySticker(ctx context.Context, arg CreateStoryStickerParams) (StorySticker, error)
 	// Story Views
 	CreateStoryView(ctx context.Context, arg CreateStoryViewParams) (StoryView, error)
+	// Automatic report without a human reporter
+	CreateSystemReport(ctx context.Context, arg CreateSystemReportParams) (Report, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	CreateUserRestriction(ctx context.Context, arg CreateUserRestrictionParams) (UserRestriction, error)
+	// Admin: Decide a pending appeal
+	DecideBanAppeal(ctx context.Context, arg DecideBanAppealParams) (BanAppeal, error)
 	// Used for panic mode - deletes all user data
 	DeleteAllUserData(ctx context.Context, id uuid.UUID) error
 	DeleteArchivedStory(ctx context.Context, arg DeleteArchivedStoryParams) error
+	// Drops both users from each other's list when their connection ends
+	DeleteCloseFriendPair(ctx context.Context, arg DeleteCloseFriendPairParams) error
 	DeleteConnection(ctx context.Context, arg DeleteConnectionParams) error
 	DeleteConversation(ctx context.Context, arg DeleteConversationParams) error
+	DeleteEmergencyContact(ctx context.Context, arg DeleteEmergencyContactParams) (EmergencyContact, error)
+	// Drops both users from each other's emergency contacts when one blocks the other
+	DeleteEmergencyContactPair(ctx context.Context, arg DeleteEmergencyContactPairParams) error
 	DeleteExpiredLocations(ctx context.Context) error
 	DeleteExpiredMessages(ctx context.Context) error
-	DeleteExpiredStories(ctx context.Context) error
+	// Evidence past retention, kept while its case is still open. Returns stored media to remove.
+	DeleteExpiredReportEvidence(ctx context.Context) ([]sql.NullString, error)
+	// Deletes stories expired before the cutoff. Stories of users with auto-archive
+	// on stay until AutoArchiveExpiredStories has copied them, unless moderators
+	// held or removed them.
+	DeleteExpiredStories(ctx context.Context, expiredBefore time.Time) error
+	DeleteExpiredUserMutes(ctx context.Context) error
+	DeleteHiddenWord(ctx context.Context, arg DeleteHiddenWordParams) (HiddenWord, error)
+	DeleteHighlight(ctx context.Context, arg DeleteHighlightParams) (uuid.UUID, error)
+	// Admin: Remove a blocklist entry
+	DeleteMediaBlocklistEntry(ctx context.Context, id uuid.UUID) (MediaBlocklist, error)
 	DeleteMessage(ctx context.Context, arg DeleteMessageParams) error
 	DeleteMessageReaction(ctx context.Context, arg DeleteMessageReactionParams) error
 	// Delete messages older than specified days (default: 30 days)
@@ -54,42 +125,64 @@ type Querier interface {
 	DeleteOldNotifications(ctx context.Context) error
 	// Admin: Delete story
 	DeleteStory(ctx context.Context, id uuid.UUID) error
+	// Removes every archive copy of a story. Run with moderation deletes, in their transaction.
+	DeleteStoryArchives(ctx context.Context, storyID uuid.UUID) error
 	DeleteStoryMentions(ctx context.Context, storyID uuid.UUID) error
 	DeleteStoryReaction(ctx context.Context, arg DeleteStoryReactionParams) error
 	DeleteUser(ctx context.Context, id uuid.UUID) error
+	DeleteUserMute(ctx context.Context, arg DeleteUserMuteParams) error
 	// Block Logic
 	FindPotentialCrossings(ctx context.Context, arg FindPotentialCrossingsParams) ([]FindPotentialCrossingsRow, error)
+	GetActiveMeetupCheckin(ctx context.Context, userID uuid.UUID) (MeetupCheckin, error)
 	GetArchivedStories(ctx context.Context, arg GetArchivedStoriesParams) ([]ArchivedStory, error)
 	GetArchivedStory(ctx context.Context, arg GetArchivedStoryParams) (ArchivedStory, error)
+	GetBanAppeal(ctx context.Context, id uuid.UUID) (BanAppeal, error)
 	GetBlockedUsers(ctx context.Context, blockerID uuid.UUID) ([]GetBlockedUsersRow, error)
 	GetConnection(ctx context.Context, arg GetConnectionParams) (Connection, error)
 	// Get stories from connected users (not limited by radius)
 	GetConnectionStories(ctx context.Context, userID uuid.UUID) ([]GetConnectionStoriesRow, error)
+	GetContentFlag(ctx context.Context, id uuid.UUID) (ContentFlag, error)
 	GetConversationList(ctx context.Context, receiverID uuid.UUID) ([]GetConversationListRow, error)
 	GetConversionStats(ctx context.Context) (GetConversionStatsRow, error)
 	GetCrossingsForUser(ctx context.Context, userID1 uuid.UUID) ([]Crossing, error)
 	GetEngagementStats(ctx context.Context) (GetEngagementStatsRow, error)
+	// The highlight, if the viewer may see it
+	GetHighlightForViewer(ctx context.Context, arg GetHighlightForViewerParams) (Highlight, error)
+	GetLatestBanAppeal(ctx context.Context, userID uuid.UUID) (BanAppeal, error)
+	GetLatestModerationAction(ctx context.Context, arg GetLatestModerationActionParams) (ModerationAction, error)
+	GetLatestReportEscalation(ctx context.Context, arg GetLatestReportEscalationParams) (ReportEscalation, error)
+	GetMediaHash(ctx context.Context, mediaUrl string) (MediaHash, error)
 	GetMessage(ctx context.Context, id uuid.UUID) (Message, error)
 	GetMessageReactions(ctx context.Context, messageID uuid.UUID) ([]GetMessageReactionsRow, error)
 	GetMyProfileViews(ctx context.Context, viewerID uuid.UUID) ([]GetMyProfileViewsRow, error)
 	GetPrivacySettings(ctx context.Context, userID uuid.UUID) (PrivacySetting, error)
 	GetProfileViewCount(ctx context.Context, viewedUserID uuid.UUID) (int64, error)
 	GetRecentProfileVisitors(ctx context.Context, viewedUserID uuid.UUID) ([]GetRecentProfileVisitorsRow, error)
+	GetReport(ctx context.Context, id uuid.UUID) (Report, error)
+	GetReportEvidence(ctx context.Context, arg GetReportEvidenceParams) (ReportEvidence, error)
+	GetScheduledStory(ctx context.Context, arg GetScheduledStoryParams) (GetScheduledStoryRow, error)
 	GetSession(ctx context.Context, id uuid.UUID) (Session, error)
+	GetStickerResponse(ctx context.Context, arg GetStickerResponseParams) (StoryStickerResponse, error)
+	// Aggregate results: response count and, for sliders, the average
+	GetStickerTally(ctx context.Context, stickerID uuid.UUID) (GetStickerTallyRow, error)
 	// Get stories within a bounding box for map view
 	// AND DATE(u.last_active_at) >= CURRENT_DATE - INTERVAL '1 day'
 	GetStoriesInBounds(ctx context.Context, arg GetStoriesInBoundsParams) ([]GetStoriesInBoundsRow, error)
-	GetStoriesWithinRadius(ctx context.Context, arg GetStoriesWithinRadiusParams) ([]GetStoriesWithinRadiusRow, error)
 	GetStoryByID(ctx context.Context, id uuid.UUID) (GetStoryByIDRow, error)
+	// Viewers per item, in order, for the owner's drop-off view
+	GetStoryItemStats(ctx context.Context, storyID uuid.UUID) ([]GetStoryItemStatsRow, error)
 	GetStoryMentions(ctx context.Context, storyID uuid.UUID) ([]GetStoryMentionsRow, error)
 	GetStoryReactions(ctx context.Context, storyID uuid.UUID) ([]GetStoryReactionsRow, error)
 	// Admin: Story stats
 	GetStoryStats(ctx context.Context) (GetStoryStatsRow, error)
+	GetStorySticker(ctx context.Context, arg GetStoryStickerParams) (StorySticker, error)
 	// Only accessible by story owner
 	GetStoryViewers(ctx context.Context, storyID uuid.UUID) ([]GetStoryViewersRow, error)
 	GetStreakRetentionStats(ctx context.Context) (GetStreakRetentionStatsRow, error)
 	GetSuggestedConnections(ctx context.Context, arg GetSuggestedConnectionsParams) ([]GetSuggestedConnectionsRow, error)
 	GetSystemStats(ctx context.Context) (GetSystemStatsRow, error)
+	// Signals for the trust engine
+	GetTrustInputs(ctx context.Context, id uuid.UUID) (GetTrustInputsRow, error)
 	GetUnreadMessageCount(ctx context.Context, receiverID uuid.UUID) (int64, error)
 	// Get user's activity status and visibility
 	GetUserActivityStatus(ctx context.Context, id uuid.UUID) (GetUserActivityStatusRow, error)
@@ -100,33 +193,133 @@ type Querier interface {
 	GetUserEngagementStats(ctx context.Context, userID uuid.UUID) (GetUserEngagementStatsRow, error)
 	GetUserMentions(ctx context.Context, arg GetUserMentionsParams) ([]GetUserMentionsRow, error)
 	GetUserProfile(ctx context.Context, id uuid.UUID) (GetUserProfileRow, error)
+	HasActiveRestriction(ctx context.Context, arg HasActiveRestrictionParams) (bool, error)
+	HasPendingContentFlag(ctx context.Context, arg HasPendingContentFlagParams) (bool, error)
+	// A live story reply between two unblocked users lets them read the
+	// conversation without being connected
+	HasStoryReplyThread(ctx context.Context, arg HasStoryReplyThreadParams) (bool, error)
 	HasValidStory(ctx context.Context, userID uuid.UUID) (bool, error)
+	// Moves an incoming message to the receiver's hidden requests
+	HideMessage(ctx context.Context, arg HideMessageParams) error
+	HideStoriesFrom(ctx context.Context, arg HideStoriesFromParams) error
+	// Whether muter has silenced messages from muted
+	IsConversationMuted(ctx context.Context, arg IsConversationMutedParams) (bool, error)
 	IsUserBlocked(ctx context.Context, arg IsUserBlockedParams) (bool, error)
+	LiftUserRestrictionsBySource(ctx context.Context, arg LiftUserRestrictionsBySourceParams) error
 	// Admin: List all stories
 	ListAllStories(ctx context.Context, arg ListAllStoriesParams) ([]ListAllStoriesRow, error)
+	ListArchivedStoryItems(ctx context.Context, archiveIds []uuid.UUID) ([]ArchivedStoryItem, error)
+	// Admin: Appeal queue, oldest first
+	ListBanAppeals(ctx context.Context, arg ListBanAppealsParams) ([]ListBanAppealsRow, error)
+	ListCloseFriends(ctx context.Context, userID uuid.UUID) ([]ListCloseFriendsRow, error)
 	ListConnections(ctx context.Context, requesterID uuid.UUID) ([]ListConnectionsRow, error)
+	// Admin: Review queue
+	ListContentFlags(ctx context.Context, arg ListContentFlagsParams) ([]ListContentFlagsRow, error)
+	ListEmergencyContacts(ctx context.Context, userID uuid.UUID) ([]EmergencyContact, error)
+	// Stories near a point that some viewer may be shown. Nothing here depends
+	// on who is looking: the result is cached per area and shared between
+	// viewers, and ListFeedStories applies each viewer's rules to it.
+	ListFeedCandidates(ctx context.Context, arg ListFeedCandidatesParams) ([]uuid.UUID, error)
+	// Per-viewer inputs for feed ranking, as of the time the feed is ranked at.
+	// Engagement, connections and stories after as_of are left out, so every page
+	// of a feed ranks on the same signals. Visibility is the caller's job.
+	ListFeedRankingSignals(ctx context.Context, arg ListFeedRankingSignalsParams) ([]ListFeedRankingSignalsRow, error)
+	// The feed candidates one viewer may see, with their distance from the viewer.
+	// Expiry and moderation are checked again as the candidates may be cached.
+	ListFeedStories(ctx context.Context, arg ListFeedStoriesParams) ([]ListFeedStoriesRow, error)
+	// Stories near the viewer by the viewer, their connections and users who have
+	// them as a close friend. Merged with the shared candidates, so the cap on
+	// those never drops them. ListFeedStories applies the viewer's rules to both.
+	ListFeedViewerCandidates(ctx context.Context, arg ListFeedViewerCandidatesParams) ([]uuid.UUID, error)
+	ListHiddenMessages(ctx context.Context, userID uuid.UUID) ([]ListHiddenMessagesRow, error)
+	ListHiddenPhrases(ctx context.Context, userID uuid.UUID) ([]string, error)
+	ListHiddenWords(ctx context.Context, userID uuid.UUID) ([]HiddenWord, error)
+	ListHighlightItems(ctx context.Context, highlightID uuid.UUID) ([]ListHighlightItemsRow, error)
+	ListLocationStrikes(ctx context.Context, arg ListLocationStrikesParams) ([]LocationStrike, error)
+	// Admin: List blocklist entries
+	ListMediaBlocklist(ctx context.Context, arg ListMediaBlocklistParams) ([]MediaBlocklist, error)
+	// All hashes, compared by Hamming distance in the application
+	ListMediaBlocklistHashes(ctx context.Context) ([]ListMediaBlocklistHashesRow, error)
+	// Messages around a point in a conversation, oldest first
+	ListMessageContext(ctx context.Context, arg ListMessageContextParams) ([]Message, error)
+	ListMessageStoryRefs(ctx context.Context, messageIds []uuid.UUID) ([]MessageStoryRef, error)
 	ListMessages(ctx context.Context, arg ListMessagesParams) ([]ListMessagesRow, error)
+	ListModerationActions(ctx context.Context, userID uuid.UUID) ([]ModerationAction, error)
+	// Admin: Audit log with optional filters, newest first
+	ListModerationAudit(ctx context.Context, arg ListModerationAuditParams) ([]ModerationAudit, error)
 	ListNotifications(ctx context.Context, arg ListNotificationsParams) ([]Notification, error)
+	ListPendingProfileFlags(ctx context.Context, userID uuid.UUID) ([]ContentFlag, error)
 	ListPendingRequests(ctx context.Context, targetID uuid.UUID) ([]ListPendingRequestsRow, error)
-	// Admin: List all reports
+	// Votes per poll option; options without votes are left out
+	ListPollVotes(ctx context.Context, stickerID uuid.UUID) ([]ListPollVotesRow, error)
+	// A user's highlights as the viewer may see them. The cover falls back to the first item.
+	// Archives of stories held for review or removed by moderators don't count.
+	ListProfileHighlights(ctx context.Context, arg ListProfileHighlightsParams) ([]ListProfileHighlightsRow, error)
+	// Users to recompute trust for
+	ListRecentlyActiveUserIDs(ctx context.Context, arg ListRecentlyActiveUserIDsParams) ([]uuid.UUID, error)
+	// Admin: Automatic actions, newest first
+	ListReportEscalations(ctx context.Context, arg ListReportEscalationsParams) ([]ListReportEscalationsRow, error)
+	ListReportEvidence(ctx context.Context, reportID uuid.UUID) ([]ReportEvidence, error)
+	ListReportNotes(ctx context.Context, reportID uuid.UUID) ([]ReportNote, error)
+	ListReportOutcomes(ctx context.Context, reportID uuid.UUID) ([]ReportOutcome, error)
+	// Admin: Report queue with optional filters, most urgent first
 	ListReports(ctx context.Context, arg ListReportsParams) ([]ListReportsRow, error)
+	ListScheduledStories(ctx context.Context, userID uuid.UUID) ([]ListScheduledStoriesRow, error)
 	ListSentConnectionRequests(ctx context.Context, requesterID uuid.UUID) ([]ListSentConnectionRequestsRow, error)
+	// Responses for the story owner, newest first. Users blocked either way are left out.
+	ListStickerResponses(ctx context.Context, stickerID uuid.UUID) ([]ListStickerResponsesRow, error)
+	ListStoriesHiddenFrom(ctx context.Context, userID uuid.UUID) ([]ListStoriesHiddenFromRow, error)
+	ListStoryAudience(ctx context.Context, storyID uuid.UUID) ([]ListStoryAudienceRow, error)
+	ListStoryItems(ctx context.Context, storyID uuid.UUID) ([]StoryItem, error)
+	// Open reports on a story with what is needed to weight each reporter
+	ListStoryReportWeights(ctx context.Context, arg ListStoryReportWeightsParams) ([]ListStoryReportWeightsRow, error)
+	ListStoryStickers(ctx context.Context, storyID uuid.UUID) ([]StorySticker, error)
+	ListUserMutes(ctx context.Context, muterID uuid.UUID) ([]ListUserMutesRow, error)
+	// Open reports against a user or any of their stories
+	ListUserReportWeights(ctx context.Context, arg ListUserReportWeightsParams) ([]ListUserReportWeightsRow, error)
 	// Admin Queries
 	ListUsers(ctx context.Context, arg ListUsersParams) ([]User, error)
+	// The subset of stories the viewer is in the audience of, for filtering shared caches
 	MarkAllNotificationsAsRead(ctx context.Context, userID uuid.UUID) error
 	MarkConversationRead(ctx context.Context, arg MarkConversationReadParams) error
+	// Closes a check-in once its alert was delivered
+	MarkMeetupCheckinAlerted(ctx context.Context, id uuid.UUID) error
 	MarkMessageRead(ctx context.Context, arg MarkMessageReadParams) (Message, error)
 	MarkNotificationAsRead(ctx context.Context, arg MarkNotificationAsReadParams) (Notification, error)
-	// Admin: Resolve report
-	ResolveReport(ctx context.Context, id uuid.UUID) (Report, error)
+	// Publishes stories whose time has come. Expiry and created_at restart from now,
+	// keeping the lifetime chosen at scheduling time.
+	PublishDueStories(ctx context.Context, limit int32) ([]PublishDueStoriesRow, error)
+	// No row means the item isn't part of the story
+	RecordStoryItemView(ctx context.Context, arg RecordStoryItemViewParams) (uuid.UUID, error)
+	RemoveCloseFriend(ctx context.Context, arg RemoveCloseFriendParams) error
+	RemoveHighlightItem(ctx context.Context, arg RemoveHighlightItemParams) error
+	ReorderHighlightItems(ctx context.Context, arg ReorderHighlightItemsParams) error
+	// Positions follow the order of the ids; ids that aren't the user's are ignored
+	ReorderHighlights(ctx context.Context, arg ReorderHighlightsParams) error
+	RescheduleStory(ctx context.Context, arg RescheduleStoryParams) error
+	// Admin: Close a case as actioned or dismissed
+	ResolveReport(ctx context.Context, arg ResolveReportParams) (Report, error)
+	// Admin: Undo an automatic action
+	RevertReportEscalation(ctx context.Context, arg RevertReportEscalationParams) (ReportEscalation, error)
+	// Admin: Approve or remove flagged content
+	ReviewContentFlag(ctx context.Context, arg ReviewContentFlagParams) (ContentFlag, error)
 	SaveMessage(ctx context.Context, id uuid.UUID) (Message, error)
+	ScheduleStory(ctx context.Context, arg ScheduleStoryParams) error
 	SearchUsers(ctx context.Context, query string) ([]SearchUsersRow, error)
+	SetStoryAudienceRule(ctx context.Context, arg SetStoryAudienceRuleParams) error
 	// Privacy Features
 	ToggleGhostMode(ctx context.Context, arg ToggleGhostModeParams) (User, error)
 	TrackProfileView(ctx context.Context, arg TrackProfileViewParams) (ProfileView, error)
 	UnblockUser(ctx context.Context, arg UnblockUserParams) error
+	// Moves a hidden message back into its conversation
+	UnhideMessage(ctx context.Context, arg UnhideMessageParams) (uuid.UUID, error)
+	UnhideStoriesFrom(ctx context.Context, arg UnhideStoriesFromParams) error
 	UpdateConnectionStatus(ctx context.Context, arg UpdateConnectionStatusParams) (Connection, error)
+	UpdateHighlight(ctx context.Context, arg UpdateHighlightParams) (Highlight, error)
 	UpdateMessage(ctx context.Context, arg UpdateMessageParams) (Message, error)
+	// Admin: Change status, priority or assignee of an open case
+	UpdateReportTriage(ctx context.Context, arg UpdateReportTriageParams) (Report, error)
+	UpdateScheduledStory(ctx context.Context, arg UpdateScheduledStoryParams) (UpdateScheduledStoryRow, error)
 	UpdateStory(ctx context.Context, arg UpdateStoryParams) (UpdateStoryRow, error)
 	// Updates last_active_at and calculates activity streak
 	UpdateUserActivity(ctx context.Context, id uuid.UUID) (User, error)
@@ -135,6 +328,8 @@ type Querier interface {
 	UpdateUserProfile(ctx context.Context, arg UpdateUserProfileParams) (UpdateUserProfileRow, error)
 	UpdateUserTrust(ctx context.Context, arg UpdateUserTrustParams) (User, error)
 	UpsertPrivacySettings(ctx context.Context, arg UpsertPrivacySettingsParams) (PrivacySetting, error)
+	// Creates the mute or replaces its scope and expiry
+	UpsertUserMute(ctx context.Context, arg UpsertUserMuteParams) (UserMute, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -19,18 +19,22 @@ INSERT INTO reports (
   target_user_id,
   target_story_id,
   reason,
-  description
+  description,
+  target_type,
+  target_message_id
 ) VALUES (
-  $1, $2, $3, $4, $5
-) RETURNING id, reporter_id, target_user_id, target_story_id, reason, description, is_resolved, created_at
+  $1, $2, $3, $4, $5, $6, $7
+) RETURNING id, reporter_id, target_user_id, target_story_id, reason, description, is_resolved, created_at, source, status, priority, assignee_id, closed_at, updated_at, target_type, target_message_id
 `
 
 type CreateReportParams struct {
-	ReporterID    uuid.UUID      `json:"reporter_id"`
-	TargetUserID  uuid.NullUUID  `json:"target_user_id"`
-	TargetStoryID uuid.NullUUID  `json:"target_story_id"`
-	Reason        ReportReason   `json:"reason"`
-	Description   sql.NullString `json:"description"`
+	ReporterID      uuid.NullUUID  `json:"reporter_id"`
+	TargetUserID    uuid.NullUUID  `json:"target_user_id"`
+	TargetStoryID   uuid.NullUUID  `json:"target_story_id"`
+	Reason          ReportReason   `json:"reason"`
+	Description     sql.NullString `json:"description"`
+	TargetType      string         `json:"target_type"`
+	TargetMessageID uuid.NullUUID  `json:"target_message_id"`
 }
 
 func (q *Queries) CreateReport(ctx context.Context, arg CreateReportParams) (Report, error) {
@@ -40,6 +44,8 @@ func (q *Queries) CreateReport(ctx context.Context, arg CreateReportParams) (Rep
 		arg.TargetStoryID,
 		arg.Reason,
 		arg.Description,
+		arg.TargetType,
+		arg.TargetMessageID,
 	)
 	var i Report
 	err := row.Scan(
@@ -51,44 +57,285 @@ func (q *Queries) CreateReport(ctx context.Context, arg CreateReportParams) (Rep
 		&i.Description,
 		&i.IsResolved,
 		&i.CreatedAt,
+		&i.Source,
+		&i.Status,
+		&i.Priority,
+		&i.AssigneeID,
+		&i.ClosedAt,
+		&i.UpdatedAt,
+		&i.TargetType,
+		&i.TargetMessageID,
 	)
 	return i, err
 }
 
+const createReportNote = `-- name: CreateReportNote :one
+INSERT INTO report_notes (
+  report_id,
+  author_id,
+  body
+) VALUES (
+  $1, $2, $3
+) RETURNING id, report_id, author_id, body, created_at
+`
+
+type CreateReportNoteParams struct {
+	ReportID uuid.UUID     `json:"report_id"`
+	AuthorID uuid.NullUUID `json:"author_id"`
+	Body     string        `json:"body"`
+}
+
+func (q *Queries) CreateReportNote(ctx context.Context, arg CreateReportNoteParams) (ReportNote, error) {
+	row := q.db.QueryRowContext(ctx, createReportNote, arg.ReportID, arg.AuthorID, arg.Body)
+	var i ReportNote
+	err := row.Scan(
+		&i.ID,
+		&i.ReportID,
+		&i.AuthorID,
+		&i.Body,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createReportOutcome = `-- name: CreateReportOutcome :one
+INSERT INTO report_outcomes (
+  report_id,
+  action,
+  actor_id
+) VALUES (
+  $1, $2, $3
+) RETURNING id, report_id, action, actor_id, created_at
+`
+
+type CreateReportOutcomeParams struct {
+	ReportID uuid.UUID     `json:"report_id"`
+	Action   string        `json:"action"`
+	ActorID  uuid.NullUUID `json:"actor_id"`
+}
+
+func (q *Queries) CreateReportOutcome(ctx context.Context, arg CreateReportOutcomeParams) (ReportOutcome, error) {
+	row := q.db.QueryRowContext(ctx, createReportOutcome, arg.ReportID, arg.Action, arg.ActorID)
+	var i ReportOutcome
+	err := row.Scan(
+		&i.ID,
+		&i.ReportID,
+		&i.Action,
+		&i.ActorID,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createSystemReport = `-- name: CreateSystemReport :one
+INSERT INTO reports (
+  target_user_id,
+  reason,
+  description,
+  source
+) VALUES (
+  $1, $2, $3, 'system'
+) RETURNING id, reporter_id, target_user_id, target_story_id, reason, description, is_resolved, created_at, source, status, priority, assignee_id, closed_at, updated_at, target_type, target_message_id
+`
+
+type CreateSystemReportParams struct {
+	TargetUserID uuid.NullUUID  `json:"target_user_id"`
+	Reason       ReportReason   `json:"reason"`
+	Description  sql.NullString `json:"description"`
+}
+
+// Automatic report without a human reporter
+func (q *Queries) CreateSystemReport(ctx context.Context, arg CreateSystemReportParams) (Report, error) {
+	row := q.db.QueryRowContext(ctx, createSystemReport, arg.TargetUserID, arg.Reason, arg.Description)
+	var i Report
+	err := row.Scan(
+		&i.ID,
+		&i.ReporterID,
+		&i.TargetUserID,
+		&i.TargetStoryID,
+		&i.Reason,
+		&i.Description,
+		&i.IsResolved,
+		&i.CreatedAt,
+		&i.Source,
+		&i.Status,
+		&i.Priority,
+		&i.AssigneeID,
+		&i.ClosedAt,
+		&i.UpdatedAt,
+		&i.TargetType,
+		&i.TargetMessageID,
+	)
+	return i, err
+}
+
+const getReport = `-- name: GetReport :one
+SELECT id, reporter_id, target_user_id, target_story_id, reason, description, is_resolved, created_at, source, status, priority, assignee_id, closed_at, updated_at, target_type, target_message_id FROM reports
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetReport(ctx context.Context, id uuid.UUID) (Report, error) {
+	row := q.db.QueryRowContext(ctx, getReport, id)
+	var i Report
+	err := row.Scan(
+		&i.ID,
+		&i.ReporterID,
+		&i.TargetUserID,
+		&i.TargetStoryID,
+		&i.Reason,
+		&i.Description,
+		&i.IsResolved,
+		&i.CreatedAt,
+		&i.Source,
+		&i.Status,
+		&i.Priority,
+		&i.AssigneeID,
+		&i.ClosedAt,
+		&i.UpdatedAt,
+		&i.TargetType,
+		&i.TargetMessageID,
+	)
+	return i, err
+}
+
+const listReportNotes = `-- name: ListReportNotes :many
+SELECT id, report_id, author_id, body, created_at FROM report_notes
+WHERE report_id = $1
+ORDER BY created_at
+`
+
+func (q *Queries) ListReportNotes(ctx context.Context, reportID uuid.UUID) ([]ReportNote, error) {
+	rows, err := q.db.QueryContext(ctx, listReportNotes, reportID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ReportNote
+	for rows.Next() {
+		var i ReportNote
+		if err := rows.Scan(
+			&i.ID,
+			&i.ReportID,
+			&i.AuthorID,
+			&i.Body,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listReportOutcomes = `-- name: ListReportOutcomes :many
+SELECT id, report_id, action, actor_id, created_at FROM report_outcomes
+WHERE report_id = $1
+ORDER BY created_at
+`
+
+func (q *Queries) ListReportOutcomes(ctx context.Context, reportID uuid.UUID) ([]ReportOutcome, error) {
+	rows, err := q.db.QueryContext(ctx, listReportOutcomes, reportID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ReportOutcome
+	for rows.Next() {
+		var i ReportOutcome
+		if err := rows.Scan(
+			&i.ID,
+			&i.ReportID,
+			&i.Action,
+			&i.ActorID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listReports = `-- name: ListReports :many
-SELECT r.id, r.reporter_id, r.target_user_id, r.target_story_id, r.reason, r.description, r.is_resolved, r.created_at, 
+SELECT r.id, r.reporter_id, r.target_user_id, r.target_story_id, r.reason, r.description, r.is_resolved, r.created_at, r.source, r.status, r.priority, r.assignee_id, r.closed_at, r.updated_at, r.target_type, r.target_message_id,
   u1.username as reporter_username,
-  u2.username as target_username
+  u2.username as target_username,
+  u3.username as assignee_username
 FROM reports r
 LEFT JOIN users u1 ON r.reporter_id = u1.id
 LEFT JOIN users u2 ON r.target_user_id = u2.id
-WHERE is_resolved = $1
-ORDER BY r.created_at DESC
-LIMIT $2 OFFSET $3
+LEFT JOIN users u3 ON r.assignee_id = u3.id
+WHERE ($1::text IS NULL OR r.status = $1)
+  AND ($2::report_reason IS NULL OR r.reason = $2)
+  AND ($3::text IS NULL OR r.target_type = $3)
+  AND ($4::uuid IS NULL OR r.assignee_id = $4)
+  AND (NOT $5::boolean OR r.assignee_id IS NULL)
+  AND ($6::timestamptz IS NULL OR r.created_at < $6)
+  AND ($7::timestamptz IS NULL OR r.created_at >= $7)
+ORDER BY CASE r.priority WHEN 'urgent' THEN 0 WHEN 'high' THEN 1 WHEN 'normal' THEN 2 ELSE 3 END,
+  r.created_at DESC
+LIMIT $8 OFFSET $9
 `
 
 type ListReportsParams struct {
-	IsResolved bool  `json:"is_resolved"`
-	Limit      int32 `json:"limit"`
-	Offset     int32 `json:"offset"`
+	Status        sql.NullString   `json:"status"`
+	Reason        NullReportReason `json:"reason"`
+	TargetType    sql.NullString   `json:"target_type"`
+	AssigneeID    uuid.NullUUID    `json:"assignee_id"`
+	Unassigned    bool             `json:"unassigned"`
+	CreatedBefore sql.NullTime     `json:"created_before"`
+	CreatedAfter  sql.NullTime     `json:"created_after"`
+	Limit         int32            `json:"limit"`
+	Offset        int32            `json:"offset"`
 }
 
 type ListReportsRow struct {
 	ID               uuid.UUID      `json:"id"`
-	ReporterID       uuid.UUID      `json:"reporter_id"`
+	ReporterID       uuid.NullUUID  `json:"reporter_id"`
 	TargetUserID     uuid.NullUUID  `json:"target_user_id"`
 	TargetStoryID    uuid.NullUUID  `json:"target_story_id"`
 	Reason           ReportReason   `json:"reason"`
 	Description      sql.NullString `json:"description"`
 	IsResolved       bool           `json:"is_resolved"`
 	CreatedAt        time.Time      `json:"created_at"`
+	Source           string         `json:"source"`
+	Status           string         `json:"status"`
+	Priority         string         `json:"priority"`
+	AssigneeID       uuid.NullUUID  `json:"assignee_id"`
+	ClosedAt         sql.NullTime   `json:"closed_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+	TargetType       string         `json:"target_type"`
+	TargetMessageID  uuid.NullUUID  `json:"target_message_id"`
 	ReporterUsername sql.NullString `json:"reporter_username"`
 	TargetUsername   sql.NullString `json:"target_username"`
+	AssigneeUsername sql.NullString `json:"assignee_username"`
 }
 
-// Admin: List all reports
+// Admin: Report queue with optional filters, most urgent first
 func (q *Queries) ListReports(ctx context.Context, arg ListReportsParams) ([]ListReportsRow, error) {
-	rows, err := q.db.QueryContext(ctx, listReports, arg.IsResolved, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, listReports,
+		arg.Status,
+		arg.Reason,
+		arg.TargetType,
+		arg.AssigneeID,
+		arg.Unassigned,
+		arg.CreatedBefore,
+		arg.CreatedAfter,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -105,8 +352,17 @@ func (q *Queries) ListReports(ctx context.Context, arg ListReportsParams) ([]Lis
 			&i.Description,
 			&i.IsResolved,
 			&i.CreatedAt,
+			&i.Source,
+			&i.Status,
+			&i.Priority,
+			&i.AssigneeID,
+			&i.ClosedAt,
+			&i.UpdatedAt,
+			&i.TargetType,
+			&i.TargetMessageID,
 			&i.ReporterUsername,
 			&i.TargetUsername,
+			&i.AssigneeUsername,
 		); err != nil {
 			return nil, err
 		}
@@ -123,14 +379,72 @@ func (q *Queries) ListReports(ctx context.Context, arg ListReportsParams) ([]Lis
 
 const resolveReport = `-- name: ResolveReport :one
 UPDATE reports
-SET is_resolved = true
-WHERE id = $1
-RETURNING id, reporter_id, target_user_id, target_story_id, reason, description, is_resolved, created_at
+SET status = $2,
+  is_resolved = true,
+  closed_at = NOW(),
+  updated_at = NOW()
+WHERE id = $1 AND status IN ('open', 'in_review')
+RETURNING id, reporter_id, target_user_id, target_story_id, reason, description, is_resolved, created_at, source, status, priority, assignee_id, closed_at, updated_at, target_type, target_message_id
 `
 
-// Admin: Resolve report
-func (q *Queries) ResolveReport(ctx context.Context, id uuid.UUID) (Report, error) {
-	row := q.db.QueryRowContext(ctx, resolveReport, id)
+type ResolveReportParams struct {
+	ID     uuid.UUID `json:"id"`
+	Status string    `json:"status"`
+}
+
+// Admin: Close a case as actioned or dismissed
+func (q *Queries) ResolveReport(ctx context.Context, arg ResolveReportParams) (Report, error) {
+	row := q.db.QueryRowContext(ctx, resolveReport, arg.ID, arg.Status)
+	var i Report
+	err := row.Scan(
+		&i.ID,
+		&i.ReporterID,
+		&i.TargetUserID,
+		&i.TargetStoryID,
+		&i.Reason,
+		&i.Description,
+		&i.IsResolved,
+		&i.CreatedAt,
+		&i.Source,
+		&i.Status,
+		&i.Priority,
+		&i.AssigneeID,
+		&i.ClosedAt,
+		&i.UpdatedAt,
+		&i.TargetType,
+		&i.TargetMessageID,
+	)
+	return i, err
+}
+
+const updateReportTriage = `-- name: UpdateReportTriage :one
+UPDATE reports
+SET status = COALESCE($1, status),
+  priority = COALESCE($2, priority),
+  assignee_id = CASE WHEN $3::boolean THEN NULL
+    ELSE COALESCE($4, assignee_id) END,
+  updated_at = NOW()
+WHERE id = $5 AND status IN ('open', 'in_review')
+RETURNING id, reporter_id, target_user_id, target_story_id, reason, description, is_resolved, created_at, source, status, priority, assignee_id, closed_at, updated_at, target_type, target_message_id
+`
+
+type UpdateReportTriageParams struct {
+	Status        sql.NullString `json:"status"`
+	Priority      sql.NullString `json:"priority"`
+	ClearAssignee bool           `json:"clear_assignee"`
+	AssigneeID    uuid.NullUUID  `json:"assignee_id"`
+	ID            uuid.UUID      `json:"id"`
+}
+
+// Admin: Change status, priority or assignee of an open case
+func (q *Queries) UpdateReportTriage(ctx context.Context, arg UpdateReportTriageParams) (Report, error) {
+	row := q.db.QueryRowContext(ctx, updateReportTriage,
+		arg.Status,
+		arg.Priority,
+		arg.ClearAssignee,
+		arg.AssigneeID,
+		arg.ID,
+	)
 	var i Report
 	err := row.Scan(
 		&i.ID,
@@ -141,6 +455,14 @@ func (q *Queries) ResolveReport(ctx context.Context, id uuid.UUID) (Report, erro
 		&i.Description,
 		&i.IsResolved,
 		&i.CreatedAt,
+		&i.Source,
+		&i.Status,
+		&i.Priority,
+		&i.AssigneeID,
+		&i.ClosedAt,
+		&i.UpdatedAt,
+		&i.TargetType,
+		&i.TargetMessageID,
 	)
 	return i, err
 }
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 const createStory = `-- name: CreateStory :one
@@ -24,24 +25,28 @@ INSERT INTO stories (
   is_anonymous,
   show_location,
   is_premium,
-  expires_at
+  expires_at,
+  visibility,
+  place_label
 ) VALUES (
-  $1, $2, $3, $4, $5, ST_SetSRID(ST_MakePoint($6::float8, $7::float8), 4326), $8, $9, $10, $11
-) RETURNING id, user_id, media_url, media_type, thumbnail_url, caption, geohash, geom, visibility, expires_at, created_at, is_anonymous, is_premium, show_location, ST_Y(geom::geometry) as lat, ST_X(geom::geometry) as lng
+  $1, $2, $3, $4, $5, ST_SetSRID(ST_MakePoint($6::float8, $7::float8), 4326), $8, $9, $10, $11, $12, $13
+) RETURNING id, user_id, media_url, media_type, thumbnail_url, caption, geohash, geom, visibility, expires_at, created_at, is_anonymous, is_premium, show_location, place_label, ST_Y(geom::geometry) as lat, ST_X(geom::geometry) as lng
 `
 
 type CreateStoryParams struct {
-	UserID       uuid.UUID      `json:"user_id"`
-	MediaUrl     string         `json:"media_url"`
-	MediaType    string         `json:"media_type"`
-	Caption      sql.NullString `json:"caption"`
-	Geohash      string         `json:"geohash"`
-	Lng          float64        `json:"lng"`
-	Lat          float64        `json:"lat"`
-	IsAnonymous  bool           `json:"is_anonymous"`
-	ShowLocation bool           `json:"show_location"`
-	IsPremium    sql.NullBool   `json:"is_premium"`
-	ExpiresAt    time.Time      `json:"expires_at"`
+	UserID       uuid.UUID         `json:"user_id"`
+	MediaUrl     string            `json:"media_url"`
+	MediaType    string            `json:"media_type"`
+	Caption      sql.NullString    `json:"caption"`
+	Geohash      string            `json:"geohash"`
+	Lng          float64           `json:"lng"`
+	Lat          float64           `json:"lat"`
+	IsAnonymous  bool              `json:"is_anonymous"`
+	ShowLocation bool              `json:"show_location"`
+	IsPremium    sql.NullBool      `json:"is_premium"`
+	ExpiresAt    time.Time         `json:"expires_at"`
+	Visibility   StoryAvailability `json:"visibility"`
+	PlaceLabel   sql.NullString    `json:"place_label"`
 }
 
 type CreateStoryRow struct {
@@ -59,6 +64,7 @@ type CreateStoryRow struct {
 	IsAnonymous  bool              `json:"is_anonymous"`
 	IsPremium    sql.NullBool      `json:"is_premium"`
 	ShowLocation bool              `json:"show_location"`
+	PlaceLabel   sql.NullString    `json:"place_label"`
 	Lat          interface{}       `json:"lat"`
 	Lng          interface{}       `json:"lng"`
 }
@@ -76,6 +82,8 @@ func (q *Queries) CreateStory(ctx context.Context, arg CreateStoryParams) (Creat
 		arg.ShowLocation,
 		arg.IsPremium,
 		arg.ExpiresAt,
+		arg.Visibility,
+		arg.PlaceLabel,
 	)
 	var i CreateStoryRow
 	err := row.Scan(
@@ -93,6 +101,7 @@ func (q *Queries) CreateStory(ctx context.Context, arg CreateStoryParams) (Creat
 		&i.IsAnonymous,
 		&i.IsPremium,
 		&i.ShowLocation,
+		&i.PlaceLabel,
 		&i.Lat,
 		&i.Lng,
 	)
@@ -100,12 +109,31 @@ func (q *Queries) CreateStory(ctx context.Context, arg CreateStoryParams) (Creat
 }
 
 const deleteExpiredStories = `-- name: DeleteExpiredStories :exec
-DELETE FROM stories
-WHERE expires_at < now()
+-- name: DeleteExpiredStories :exec
+DELETE FROM stories s
+WHERE s.expires_at < $1
+  AND (
+    NOT EXISTS (
+      SELECT 1 FROM privacy_settings ps
+      WHERE ps.user_id = s.user_id AND ps.auto_archive = true
+    )
+    OR EXISTS (
+      SELECT 1 FROM archived_stories a
+      WHERE a.user_id = s.user_id AND a.story_id = s.id
+    )
+    -- Held or removed by moderators, so never archived
+    OR EXISTS (
+      SELECT 1 FROM content_flags cf
+      WHERE cf.content_type = 'story' AND cf.content_id = s.id AND cf.status IN ('pending', 'removed')
+    )
+  )
 `
 
-func (q *Queries) DeleteExpiredStories(ctx context.Context) error {
-	_, err := q.db.ExecContext(ctx, deleteExpiredStories)
+// Deletes stories expired before the cutoff. Stories of users with auto-archive
+// on stay until AutoArchiveExpiredStories has copied them, unless moderators
+// held or removed them.
+func (q *Queries) DeleteExpiredStories(ctx context.Context, expiredBefore time.Time) error {
+	_, err := q.db.ExecContext(ctx, deleteExpiredStories, expiredBefore)
 	return err
 }
 
@@ -121,8 +149,9 @@ func (q *Queries) DeleteStory(ctx context.Context, id uuid.UUID) error {
 }
 
 const getConnectionStories = `-- name: GetConnectionStories :many
-SELECT s.id, s.user_id, s.media_url, s.media_type, s.thumbnail_url, s.caption, s.geohash, s.geom, s.visibility, s.expires_at, s.created_at, s.is_anonymous, s.is_premium, s.show_location, u.username, u.avatar_url, u.is_premium,
-       ST_Y(s.geom::geometry) as lat, ST_X(s.geom::geometry) as lng
+SELECT s.id, s.user_id, s.media_url, s.media_type, s.thumbnail_url, s.caption, s.geohash, s.geom, s.visibility, s.expires_at, s.created_at, s.is_anonymous, s.is_premium, s.show_location, s.place_label, u.username, u.avatar_url, u.is_premium,
+       ST_Y(s.geom::geometry) as lat, ST_X(s.geom::geometry) as lng,
+       (SELECT COUNT(*) FROM story_items si WHERE si.story_id = s.id) AS item_count
 FROM stories s
 JOIN users u ON s.user_id = u.id
 JOIN connections c ON 
@@ -131,6 +160,13 @@ JOIN connections c ON
 WHERE 
   c.status = 'accepted'
   AND s.expires_at > now()
+  -- Moderation: hide content held for review
+  AND NOT EXISTS (
+    SELECT 1 FROM content_flags cf
+    WHERE cf.content_type = 'story' AND cf.content_id = s.id AND cf.status = 'pending'
+  )
+  -- Scheduled stories stay hidden until published
+  AND NOT EXISTS (SELECT 1 FROM scheduled_stories ss WHERE ss.story_id = s.id)
   AND u.is_shadow_banned = false
   AND u.is_shadow_banned = false
   -- strict streak rule (DISABLED)
@@ -141,6 +177,45 @@ WHERE
     WHERE (bu.blocker_id = $1 AND bu.blocked_id = s.user_id)
        OR (bu.blocker_id = s.user_id AND bu.blocked_id = $1)
   )
+  -- Mutes: stories from authors the viewer muted
+  AND NOT EXISTS (
+    SELECT 1 FROM user_mutes um
+    WHERE um.muter_id = $1 AND um.muted_id = s.user_id
+      AND um.mute_stories = true
+      AND (um.expires_at IS NULL OR um.expires_at > now())
+  )
+  -- Story visibility: connections-only and close friends audiences
+  AND (
+    s.user_id = $1
+    OR s.visibility = 'public'
+    OR (s.visibility = 'connections' AND EXISTS (
+      SELECT 1 FROM connections cn
+      WHERE (cn.requester_id = $1 AND cn.target_id = s.user_id OR cn.requester_id = s.user_id AND cn.target_id = $1)
+        AND cn.status = 'accepted'
+    ))
+    OR (s.visibility = 'close_friends' AND EXISTS (
+      SELECT 1 FROM close_friends clf
+      WHERE clf.user_id = s.user_id AND clf.friend_id = $1
+    ))
+  )
+  -- Story audience: per-story allow/exclude lists and the author's hide-from list
+  AND (
+    s.user_id = $1
+    OR (
+      NOT EXISTS (
+        SELECT 1 FROM story_hidden_from shf
+        WHERE shf.user_id = s.user_id AND shf.hidden_user_id = $1
+      )
+      AND NOT EXISTS (
+        SELECT 1 FROM story_audience sa
+        WHERE sa.story_id = s.id AND sa.user_id = $1 AND sa.rule = 'exclude'
+      )
+      AND (
+        NOT EXISTS (SELECT 1 FROM story_audience sa WHERE sa.story_id = s.id AND sa.rule = 'allow')
+        OR EXISTS (SELECT 1 FROM story_audience sa WHERE sa.story_id = s.id AND sa.user_id = $1 AND sa.rule = 'allow')
+      )
+    )
+  )
 ORDER BY s.created_at DESC
 `
 
@@ -159,11 +234,13 @@ type GetConnectionStoriesRow struct {
 	IsAnonymous  bool              `json:"is_anonymous"`
 	IsPremium    sql.NullBool      `json:"is_premium"`
 	ShowLocation bool              `json:"show_location"`
+	PlaceLabel   sql.NullString    `json:"place_label"`
 	Username     string            `json:"username"`
 	AvatarUrl    sql.NullString    `json:"avatar_url"`
 	IsPremium_2  sql.NullBool      `json:"is_premium_2"`
 	Lat          interface{}       `json:"lat"`
 	Lng          interface{}       `json:"lng"`
+	ItemCount    int64             `json:"item_count"`
 }
 
 // Get stories from connected users (not limited by radius)
@@ -191,11 +268,13 @@ func (q *Queries) GetConnectionStories(ctx context.Context, userID uuid.UUID) ([
 			&i.IsAnonymous,
 			&i.IsPremium,
 			&i.ShowLocation,
+			&i.PlaceLabel,
 			&i.Username,
 			&i.AvatarUrl,
 			&i.IsPremium_2,
 			&i.Lat,
 			&i.Lng,
+			&i.ItemCount,
 		); err != nil {
 			return nil, err
 		}
@@ -211,12 +290,20 @@ func (q *Queries) GetConnectionStories(ctx context.Context, userID uuid.UUID) ([
 }
 
 const getStoriesInBounds = `-- name: GetStoriesInBounds :many
-SELECT s.id, s.user_id, s.media_url, s.media_type, s.thumbnail_url, s.caption, s.geohash, s.geom, s.visibility, s.expires_at, s.created_at, s.is_anonymous, s.is_premium, s.show_location, u.username, u.avatar_url,
-       ST_Y(s.geom::geometry) as lat, ST_X(s.geom::geometry) as lng
+SELECT s.id, s.user_id, s.media_url, s.media_type, s.thumbnail_url, s.caption, s.geohash, s.geom, s.visibility, s.expires_at, s.created_at, s.is_anonymous, s.is_premium, s.show_location, s.place_label, u.username, u.avatar_url,
+       ST_Y(s.geom::geometry) as lat, ST_X(s.geom::geometry) as lng,
+       (SELECT COUNT(*) FROM story_items si WHERE si.story_id = s.id) AS item_count
 FROM stories s
 JOIN users u ON s.user_id = u.id
 WHERE s.geom && ST_MakeEnvelope($1::float8, $2::float8, $3::float8, $4::float8, 4326)
 AND s.expires_at > now()
+-- Moderation: hide content held for review
+AND NOT EXISTS (
+  SELECT 1 FROM content_flags cf
+  WHERE cf.content_type = 'story' AND cf.content_id = s.id AND cf.status = 'pending'
+)
+-- Scheduled stories stay hidden until published
+AND NOT EXISTS (SELECT 1 FROM scheduled_stories ss WHERE ss.story_id = s.id)
 AND u.is_shadow_banned = false
 AND u.is_ghost_mode = false
 AND NOT EXISTS (
@@ -224,6 +311,45 @@ AND NOT EXISTS (
     WHERE (bu.blocker_id = $5 AND bu.blocked_id = s.user_id)
        OR (bu.blocker_id = s.user_id AND bu.blocked_id = $5)
 )
+-- Mutes: stories from authors the viewer muted
+AND NOT EXISTS (
+    SELECT 1 FROM user_mutes um
+    WHERE um.muter_id = $5 AND um.muted_id = s.user_id
+      AND um.mute_stories = true
+      AND (um.expires_at IS NULL OR um.expires_at > now())
+)
+-- Story visibility: connections-only and close friends audiences
+AND (
+  s.user_id = $5
+  OR s.visibility = 'public'
+  OR (s.visibility = 'connections' AND EXISTS (
+    SELECT 1 FROM connections cn
+    WHERE (cn.requester_id = $5 AND cn.target_id = s.user_id OR cn.requester_id = s.user_id AND cn.target_id = $5)
+      AND cn.status = 'accepted'
+  ))
+  OR (s.visibility = 'close_friends' AND EXISTS (
+    SELECT 1 FROM close_friends clf
+    WHERE clf.user_id = s.user_id AND clf.friend_id = $5
+  ))
+)
+-- Story audience: per-story allow/exclude lists and the author's hide-from list
+AND (
+  s.user_id = $5
+  OR (
+    NOT EXISTS (
+      SELECT 1 FROM story_hidden_from shf
+      WHERE shf.user_id = s.user_id AND shf.hidden_user_id = $5
+    )
+    AND NOT EXISTS (
+      SELECT 1 FROM story_audience sa
+      WHERE sa.story_id = s.id AND sa.user_id = $5 AND sa.rule = 'exclude'
+    )
+    AND (
+      NOT EXISTS (SELECT 1 FROM story_audience sa WHERE sa.story_id = s.id AND sa.rule = 'allow')
+      OR EXISTS (SELECT 1 FROM story_audience sa WHERE sa.story_id = s.id AND sa.user_id = $5 AND sa.rule = 'allow')
+    )
+  )
+)
 AND (
     s.user_id = $5
     OR
@@ -272,10 +398,12 @@ type GetStoriesInBoundsRow struct {
 	IsAnonymous  bool              `json:"is_anonymous"`
 	IsPremium    sql.NullBool      `json:"is_premium"`
 	ShowLocation bool              `json:"show_location"`
+	PlaceLabel   sql.NullString    `json:"place_label"`
 	Username     string            `json:"username"`
 	AvatarUrl    sql.NullString    `json:"avatar_url"`
 	Lat          interface{}       `json:"lat"`
 	Lng          interface{}       `json:"lng"`
+	ItemCount    int64             `json:"item_count"`
 }
 
 // Get stories within a bounding box for map view
@@ -310,147 +438,12 @@ func (q *Queries) GetStoriesInBounds(ctx context.Context, arg GetStoriesInBounds
 			&i.IsAnonymous,
 			&i.IsPremium,
 			&i.ShowLocation,
+			&i.PlaceLabel,
 			&i.Username,
 			&i.AvatarUrl,
 			&i.Lat,
 			&i.Lng,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Close(); err != nil {
-		return nil, err
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
-}
-
-const getStoriesWithinRadius = `-- name: GetStoriesWithinRadius :many
-SELECT s.id, s.user_id, s.media_url, s.media_type, s.thumbnail_url, s.caption, s.geohash, s.geom, s.visibility, s.expires_at, s.created_at, s.is_anonymous, s.is_premium, s.show_location, u.username, u.avatar_url, u.is_premium,
-       ST_Y(s.geom::geometry) as lat, ST_X(s.geom::geometry) as lng
-FROM stories s
-JOIN users u ON s.user_id = u.id
-WHERE 
-    ST_DWithin(
-    s.geom::geography,
-    ST_MakePoint($1::float8, $2::float8)::geography,
-    $3
-  )
-  AND s.expires_at > now()
-  -- Allow anonymous stories (handled in presentation)
-  -- AND (s.is_anonymous = false OR s.user_id = @user_id)
-  AND u.is_shadow_banned = false
-  AND u.is_ghost_mode = false
-  -- Strict Streak Rule (DISABLED)
-  -- AND DATE(u.last_active_at) >= CURRENT_DATE - INTERVAL '1 day'
-  -- Block Logic: Exclude if blocked by either party (using blocked_users table)
-  AND NOT EXISTS (
-    SELECT 1 FROM blocked_users bu 
-    WHERE (bu.blocker_id = $4 AND bu.blocked_id = s.user_id)
-       OR (bu.blocker_id = s.user_id AND bu.blocked_id = $4)
-  )
-  -- Privacy Settings Logic --
-  AND (
-    -- Case 1: My own stories (always visible)
-    s.user_id = $4
-    OR
-    (
-      -- Case 2: User is NOT in Ghost Mode (using privacy_settings)
-      EXISTS (
-        SELECT 1 FROM privacy_settings ps 
-        WHERE ps.user_id = s.user_id 
-        AND ps.show_location = true -- If false (Ghost Mode), don't show in radius feed
-        AND (
-          -- Visibility Rules
-          ps.who_can_see_stories = 'everyone'
-          OR
-          (ps.who_can_see_stories = 'connections' AND EXISTS (
-             SELECT 1 FROM connections c 
-             WHERE (c.requester_id = $4 AND c.target_id = s.user_id OR c.requester_id = s.user_id AND c.target_id = $4)
-             AND c.status = 'accepted'
-          ))
-        )
-       )
-       OR
-       -- Fallback: If no privacy settings exist, default to PUBLIC
-       NOT EXISTS (SELECT 1 FROM privacy_settings ps WHERE ps.user_id = s.user_id)
-      -- Fallback: If no privacy settings exist, assume strictly public/default behaviour? 
-      -- Ideally, every user has settings. If not, default to 'everyone' + 'show_location'.
-      -- But simpler to rely on LEFT JOIN or EXISTS logic assuming rows exist.
-    )
-  )
-ORDER BY 
-  (u.boost_expires_at > now()) DESC NULLS LAST,
-  u.is_premium DESC,
-  s.created_at DESC
-`
-
-type GetStoriesWithinRadiusParams struct {
-	Lng          float64     `json:"lng"`
-	Lat          float64     `json:"lat"`
-	RadiusMeters interface{} `json:"radius_meters"`
-	UserID       uuid.UUID   `json:"user_id"`
-}
-
-type GetStoriesWithinRadiusRow struct {
-	ID           uuid.UUID         `json:"id"`
-	UserID       uuid.UUID         `json:"user_id"`
-	MediaUrl     string            `json:"media_url"`
-	MediaType    string            `json:"media_type"`
-	ThumbnailUrl sql.NullString    `json:"thumbnail_url"`
-	Caption      sql.NullString    `json:"caption"`
-	Geohash      string            `json:"geohash"`
-	Geom         interface{}       `json:"geom"`
-	Visibility   StoryAvailability `json:"visibility"`
-	ExpiresAt    time.Time         `json:"expires_at"`
-	CreatedAt    time.Time         `json:"created_at"`
-	IsAnonymous  bool              `json:"is_anonymous"`
-	IsPremium    sql.NullBool      `json:"is_premium"`
-	ShowLocation bool              `json:"show_location"`
-	Username     string            `json:"username"`
-	AvatarUrl    sql.NullString    `json:"avatar_url"`
-	IsPremium_2  sql.NullBool      `json:"is_premium_2"`
-	Lat          interface{}       `json:"lat"`
-	Lng          interface{}       `json:"lng"`
-}
-
-func (q *Queries) GetStoriesWithinRadius(ctx context.Context, arg GetStoriesWithinRadiusParams) ([]GetStoriesWithinRadiusRow, error) {
-	rows, err := q.db.QueryContext(ctx, getStoriesWithinRadius,
-		arg.Lng,
-		arg.Lat,
-		arg.RadiusMeters,
-		arg.UserID,
-	)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	var items []GetStoriesWithinRadiusRow
-	for rows.Next() {
-		var i GetStoriesWithinRadiusRow
-		if err := rows.Scan(
-			&i.ID,
-			&i.UserID,
-			&i.MediaUrl,
-			&i.MediaType,
-			&i.ThumbnailUrl,
-			&i.Caption,
-			&i.Geohash,
-			&i.Geom,
-			&i.Visibility,
-			&i.ExpiresAt,
-			&i.CreatedAt,
-			&i.IsAnonymous,
-			&i.IsPremium,
-			&i.ShowLocation,
-			&i.Username,
-			&i.AvatarUrl,
-			&i.IsPremium_2,
-			&i.Lat,
-			&i.Lng,
+			&i.ItemCount,
 		); err != nil {
 			return nil, err
 		}
@@ -466,7 +459,7 @@ func (q *Queries) GetStoriesWithinRadius(ctx context.Context, arg GetStoriesWith
 }
 
 const getStoryByID = `-- name: GetStoryByID :one
-SELECT id, user_id, media_url, media_type, thumbnail_url, caption, geohash, geom, visibility, expires_at, created_at, is_anonymous, is_premium, show_location, ST_Y(geom::geometry) as lat, ST_X(geom::geometry) as lng FROM stories
+SELECT id, user_id, media_url, media_type, thumbnail_url, caption, geohash, geom, visibility, expires_at, created_at, is_anonymous, is_premium, show_location, place_label, ST_Y(geom::geometry) as lat, ST_X(geom::geometry) as lng FROM stories
 WHERE id = $1 LIMIT 1
 `
 
@@ -485,6 +478,7 @@ type GetStoryByIDRow struct {
 	IsAnonymous  bool              `json:"is_anonymous"`
 	IsPremium    sql.NullBool      `json:"is_premium"`
 	ShowLocation bool              `json:"show_location"`
+	PlaceLabel   sql.NullString    `json:"place_label"`
 	Lat          interface{}       `json:"lat"`
 	Lng          interface{}       `json:"lng"`
 }
@@ -507,6 +501,7 @@ func (q *Queries) GetStoryByID(ctx context.Context, id uuid.UUID) (GetStoryByIDR
 		&i.IsAnonymous,
 		&i.IsPremium,
 		&i.ShowLocation,
+		&i.PlaceLabel,
 		&i.Lat,
 		&i.Lng,
 	)
@@ -540,6 +535,7 @@ SELECT EXISTS (
     SELECT 1 FROM stories 
     WHERE user_id = $1 
     AND expires_at > now()
+    AND NOT EXISTS (SELECT 1 FROM scheduled_stories ss WHERE ss.story_id = stories.id)
 )
 `
 
@@ -551,7 +547,7 @@ func (q *Queries) HasValidStory(ctx context.Context, userID uuid.UUID) (bool, er
 }
 
 const listAllStories = `-- name: ListAllStories :many
-SELECT s.id, s.user_id, s.media_url, s.media_type, s.thumbnail_url, s.caption, s.geohash, s.geom, s.visibility, s.expires_at, s.created_at, s.is_anonymous, s.is_premium, s.show_location, u.username
+SELECT s.id, s.user_id, s.media_url, s.media_type, s.thumbnail_url, s.caption, s.geohash, s.geom, s.visibility, s.expires_at, s.created_at, s.is_anonymous, s.is_premium, s.show_location, s.place_label, u.username
 FROM stories s
 JOIN users u ON s.user_id = u.id
 ORDER BY s.created_at DESC
@@ -578,6 +574,7 @@ type ListAllStoriesRow struct {
 	IsAnonymous  bool              `json:"is_anonymous"`
 	IsPremium    sql.NullBool      `json:"is_premium"`
 	ShowLocation bool              `json:"show_location"`
+	PlaceLabel   sql.NullString    `json:"place_label"`
 	Username     string            `json:"username"`
 }
 
@@ -606,7 +603,256 @@ func (q *Queries) ListAllStories(ctx context.Context, arg ListAllStoriesParams)
 			&i.IsAnonymous,
 			&i.IsPremium,
 			&i.ShowLocation,
+			&i.PlaceLabel,
+			&i.Username,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listFeedCandidates = `-- name: ListFeedCandidates :many
+SELECT s.id
+FROM stories s
+JOIN users u ON s.user_id = u.id
+WHERE
+  ST_DWithin(
+    s.geom::geography,
+    ST_MakePoint($1::float8, $2::float8)::geography,
+    $3
+  )
+  AND s.expires_at > now()
+  -- Moderation: hide content held for review
+  AND NOT EXISTS (
+    SELECT 1 FROM content_flags cf
+    WHERE cf.content_type = 'story' AND cf.content_id = s.id AND cf.status = 'pending'
+  )
+  -- Scheduled stories stay hidden until published
+  AND NOT EXISTS (SELECT 1 FROM scheduled_stories ss WHERE ss.story_id = s.id)
+  -- Anonymous stories are allowed (handled in presentation)
+  AND u.is_shadow_banned = false
+  AND u.is_ghost_mode = false
+ORDER BY floor(ST_Distance(s.geom::geography, ST_MakePoint($1::float8, $2::float8)::geography) / $4::float8),
+         s.created_at DESC
+LIMIT $5
+`
+
+type ListFeedCandidatesParams struct {
+	Lng           float64     `json:"lng"`
+	Lat           float64     `json:"lat"`
+	RadiusMeters  interface{} `json:"radius_meters"`
+	BandMeters    float64     `json:"band_meters"`
+	MaxCandidates int32       `json:"max_candidates"`
+}
+
+// Stories near a point that some viewer may be shown. Nothing here depends
+// on who is looking: the result is cached per area and shared between
+// viewers, and ListFeedStories applies each viewer's rules to it.
+func (q *Queries) ListFeedCandidates(ctx context.Context, arg ListFeedCandidatesParams) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, listFeedCandidates,
+		arg.Lng,
+		arg.Lat,
+		arg.RadiusMeters,
+		arg.BandMeters,
+		arg.MaxCandidates,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listFeedStories = `-- name: ListFeedStories :many
+SELECT s.id, s.user_id, s.media_url, s.media_type, s.thumbnail_url, s.caption, s.geohash, s.geom, s.visibility, s.expires_at, s.created_at, s.is_anonymous, s.is_premium, s.show_location, s.place_label, u.username, u.avatar_url, u.is_premium,
+       ST_Y(s.geom::geometry) as lat, ST_X(s.geom::geometry) as lng,
+       (SELECT COUNT(*) FROM story_items si WHERE si.story_id = s.id) AS item_count,
+       ST_Distance(s.geom::geography, ST_MakePoint($1::float8, $2::float8)::geography)::float8 AS distance_meters
+FROM stories s
+JOIN users u ON s.user_id = u.id
+WHERE s.id = ANY($3::uuid[])
+  AND s.expires_at > now()
+  AND NOT EXISTS (
+    SELECT 1 FROM content_flags cf
+    WHERE cf.content_type = 'story' AND cf.content_id = s.id AND cf.status = 'pending'
+  )
+  AND NOT EXISTS (SELECT 1 FROM scheduled_stories ss WHERE ss.story_id = s.id)
+  AND u.is_shadow_banned = false
+  AND u.is_ghost_mode = false
+  -- Block Logic: Exclude if blocked by either party (using blocked_users table)
+  AND NOT EXISTS (
+    SELECT 1 FROM blocked_users bu 
+    WHERE (bu.blocker_id = $4 AND bu.blocked_id = s.user_id)
+       OR (bu.blocker_id = s.user_id AND bu.blocked_id = $4)
+  )
+  -- Mutes: stories from authors the viewer muted, before the search radius is chosen
+  AND NOT EXISTS (
+    SELECT 1 FROM user_mutes um
+    WHERE um.muter_id = $4 AND um.muted_id = s.user_id
+      AND um.mute_stories = true
+      AND (um.expires_at IS NULL OR um.expires_at > now())
+  )
+  -- Story visibility: connections-only and close friends audiences
+  AND (
+    s.user_id = $4
+    OR s.visibility = 'public'
+    OR (s.visibility = 'connections' AND EXISTS (
+      SELECT 1 FROM connections cn
+      WHERE (cn.requester_id = $4 AND cn.target_id = s.user_id OR cn.requester_id = s.user_id AND cn.target_id = $4)
+        AND cn.status = 'accepted'
+    ))
+    OR (s.visibility = 'close_friends' AND EXISTS (
+      SELECT 1 FROM close_friends clf
+      WHERE clf.user_id = s.user_id AND clf.friend_id = $4
+    ))
+  )
+  -- Story audience: per-story allow/exclude lists and the author's hide-from list
+  AND (
+    s.user_id = $4
+    OR (
+      NOT EXISTS (
+        SELECT 1 FROM story_hidden_from shf
+        WHERE shf.user_id = s.user_id AND shf.hidden_user_id = $4
+      )
+      AND NOT EXISTS (
+        SELECT 1 FROM story_audience sa
+        WHERE sa.story_id = s.id AND sa.user_id = $4 AND sa.rule = 'exclude'
+      )
+      AND (
+        NOT EXISTS (SELECT 1 FROM story_audience sa WHERE sa.story_id = s.id AND sa.rule = 'allow')
+        OR EXISTS (SELECT 1 FROM story_audience sa WHERE sa.story_id = s.id AND sa.user_id = $4 AND sa.rule = 'allow')
+      )
+    )
+  )
+  -- Privacy Settings Logic --
+  AND (
+    -- Case 1: My own stories (always visible)
+    s.user_id = $4
+    OR
+    (
+      -- Case 2: User is NOT in Ghost Mode (using privacy_settings)
+      EXISTS (
+        SELECT 1 FROM privacy_settings ps 
+        WHERE ps.user_id = s.user_id 
+        AND ps.show_location = true -- If false (Ghost Mode), don't show in radius feed
+        AND (
+          -- Visibility Rules
+          ps.who_can_see_stories = 'everyone'
+          OR
+          (ps.who_can_see_stories = 'connections' AND EXISTS (
+             SELECT 1 FROM connections c 
+             WHERE (c.requester_id = $4 AND c.target_id = s.user_id OR c.requester_id = s.user_id AND c.target_id = $4)
+             AND c.status = 'accepted'
+          ))
+        )
+       )
+       OR
+       -- Fallback: If no privacy settings exist, default to PUBLIC
+       NOT EXISTS (SELECT 1 FROM privacy_settings ps WHERE ps.user_id = s.user_id)
+      -- Fallback: If no privacy settings exist, assume strictly public/default behaviour? 
+      -- Ideally, every user has settings. If not, default to 'everyone' + 'show_location'.
+      -- But simpler to rely on LEFT JOIN or EXISTS logic assuming rows exist.
+    )
+  )
+ORDER BY
+  (u.boost_expires_at > now()) DESC NULLS LAST,
+  u.is_premium DESC,
+  s.created_at DESC
+`
+
+type ListFeedStoriesParams struct {
+	Lng      float64     `json:"lng"`
+	Lat      float64     `json:"lat"`
+	StoryIds []uuid.UUID `json:"story_ids"`
+	ViewerID uuid.UUID   `json:"viewer_id"`
+}
+
+type ListFeedStoriesRow struct {
+	ID             uuid.UUID         `json:"id"`
+	UserID         uuid.UUID         `json:"user_id"`
+	MediaUrl       string            `json:"media_url"`
+	MediaType      string            `json:"media_type"`
+	ThumbnailUrl   sql.NullString    `json:"thumbnail_url"`
+	Caption        sql.NullString    `json:"caption"`
+	Geohash        string            `json:"geohash"`
+	Geom           interface{}       `json:"geom"`
+	Visibility     StoryAvailability `json:"visibility"`
+	ExpiresAt      time.Time         `json:"expires_at"`
+	CreatedAt      time.Time         `json:"created_at"`
+	IsAnonymous    bool              `json:"is_anonymous"`
+	IsPremium      sql.NullBool      `json:"is_premium"`
+	ShowLocation   bool              `json:"show_location"`
+	PlaceLabel     sql.NullString    `json:"place_label"`
+	Username       string            `json:"username"`
+	AvatarUrl      sql.NullString    `json:"avatar_url"`
+	IsPremium_2    sql.NullBool      `json:"is_premium_2"`
+	Lat            interface{}       `json:"lat"`
+	Lng            interface{}       `json:"lng"`
+	ItemCount      int64             `json:"item_count"`
+	DistanceMeters float64           `json:"distance_meters"`
+}
+
+// The feed candidates one viewer may see, with their distance from the viewer.
+// Expiry and moderation are checked again as the candidates may be cached.
+func (q *Queries) ListFeedStories(ctx context.Context, arg ListFeedStoriesParams) ([]ListFeedStoriesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listFeedStories,
+		arg.Lng,
+		arg.Lat,
+		pq.Array(arg.StoryIds),
+		arg.ViewerID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListFeedStoriesRow
+	for rows.Next() {
+		var i ListFeedStoriesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.MediaUrl,
+			&i.MediaType,
+			&i.ThumbnailUrl,
+			&i.Caption,
+			&i.Geohash,
+			&i.Geom,
+			&i.Visibility,
+			&i.ExpiresAt,
+			&i.CreatedAt,
+			&i.IsAnonymous,
+			&i.IsPremium,
+			&i.ShowLocation,
+			&i.PlaceLabel,
 			&i.Username,
+			&i.AvatarUrl,
+			&i.IsPremium_2,
+			&i.Lat,
+			&i.Lng,
+			&i.ItemCount,
+			&i.DistanceMeters,
 		); err != nil {
 			return nil, err
 		}
@@ -621,17 +867,86 @@ func (q *Queries) ListAllStories(ctx context.Context, arg ListAllStoriesParams)
 	return items, nil
 }
 
+const listFeedViewerCandidates = `-- name: ListFeedViewerCandidates :many
+SELECT s.id
+FROM stories s
+WHERE
+  ST_DWithin(
+    s.geom::geography,
+    ST_MakePoint($1::float8, $2::float8)::geography,
+    $3
+  )
+  AND s.expires_at > now()
+  AND (
+    s.user_id = $4
+    OR EXISTS (
+      SELECT 1 FROM connections cn
+      WHERE (cn.requester_id = $4 AND cn.target_id = s.user_id OR cn.requester_id = s.user_id AND cn.target_id = $4)
+        AND cn.status = 'accepted'
+    )
+    OR EXISTS (
+      SELECT 1 FROM close_friends clf
+      WHERE clf.user_id = s.user_id AND clf.friend_id = $4
+    )
+  )
+ORDER BY s.created_at DESC
+LIMIT $5
+`
+
+type ListFeedViewerCandidatesParams struct {
+	Lng           float64     `json:"lng"`
+	Lat           float64     `json:"lat"`
+	RadiusMeters  interface{} `json:"radius_meters"`
+	ViewerID      uuid.UUID   `json:"viewer_id"`
+	MaxCandidates int32       `json:"max_candidates"`
+}
+
+// Stories near the viewer by the viewer, their connections and users who have
+// them as a close friend. Merged with the shared candidates, so the cap on
+// those never drops them. ListFeedStories applies the viewer's rules to both.
+func (q *Queries) ListFeedViewerCandidates(ctx context.Context, arg ListFeedViewerCandidatesParams) ([]uuid.UUID, error) {
+	rows, err := q.db.QueryContext(ctx, listFeedViewerCandidates,
+		arg.Lng,
+		arg.Lat,
+		arg.RadiusMeters,
+		arg.ViewerID,
+		arg.MaxCandidates,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateStory = `-- name: UpdateStory :one
 UPDATE stories
 SET 
   caption = COALESCE($3, caption),
   is_anonymous = COALESCE($4, is_anonymous),
-  show_location = COALESCE($5, show_location)
+  show_location = COALESCE($5, show_location),
+  -- Dropped when the location is hidden
+  place_label = CASE WHEN COALESCE($5, show_location)
+    THEN COALESCE($6, place_label) END
 WHERE id = $1 
   AND user_id = $2
   AND created_at > NOW() - INTERVAL '15 minutes'
   AND expires_at > NOW()
-RETURNING id, user_id, media_url, media_type, thumbnail_url, caption, geohash, geom, visibility, expires_at, created_at, is_anonymous, is_premium, show_location, ST_Y(geom::geometry) as lat, ST_X(geom::geometry) as lng
+RETURNING id, user_id, media_url, media_type, thumbnail_url, caption, geohash, geom, visibility, expires_at, created_at, is_anonymous, is_premium, show_location, place_label, ST_Y(geom::geometry) as lat, ST_X(geom::geometry) as lng
 `
 
 type UpdateStoryParams struct {
@@ -640,6 +955,7 @@ type UpdateStoryParams struct {
 	Caption      sql.NullString `json:"caption"`
 	IsAnonymous  sql.NullBool   `json:"is_anonymous"`
 	ShowLocation sql.NullBool   `json:"show_location"`
+	PlaceLabel   sql.NullString `json:"place_label"`
 }
 
 type UpdateStoryRow struct {
@@ -657,6 +973,7 @@ type UpdateStoryRow struct {
 	IsAnonymous  bool              `json:"is_anonymous"`
 	IsPremium    sql.NullBool      `json:"is_premium"`
 	ShowLocation bool              `json:"show_location"`
+	PlaceLabel   sql.NullString    `json:"place_label"`
 	Lat          interface{}       `json:"lat"`
 	Lng          interface{}       `json:"lng"`
 }
@@ -668,6 +985,7 @@ func (q *Queries) UpdateStory(ctx context.Context, arg UpdateStoryParams) (Updat
 		arg.Caption,
 		arg.IsAnonymous,
 		arg.ShowLocation,
+		arg.PlaceLabel,
 	)
 	var i UpdateStoryRow
 	err := row.Scan(
@@ -685,6 +1003,7 @@ func (q *Queries) UpdateStory(ctx context.Context, arg UpdateStoryParams) (Updat
 		&i.IsAnonymous,
 		&i.IsPremium,
 		&i.ShowLocation,
+		&i.PlaceLabel,
 		&i.Lat,
 		&i.Lng,
 	)
@@ -14,6 +14,7 @@ import (
 	sql "database/sql"
 	db "privacy-social-backend/internal/repository/db"
 	reflect "reflect"
+	time "time"
 
 	uuid "github.com/google/uuid"
 	gomock "go.uber.org/mock/gomock"
@@ -43,6 +44,79 @@ func (m *MockStore) EXPECT() *MockStoreMockRecorder {
 	return m.recorder
 }
 
+// AddCloseFriend mocks base method.
+func (m *MockStore) AddCloseFriend(ctx context.Context, arg db.AddCloseFriendParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCloseFriend", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddCloseFriend indicates an expected call of AddCloseFriend.
+func (mr *MockStoreMockRecorder) AddCloseFriend(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCloseFriend", reflect.TypeOf((*MockStore)(nil).AddCloseFriend), ctx, arg)
+}
+
+// AddHighlightItem mocks base method.
+func (m *MockStore) AddHighlightItem(ctx context.Context, arg db.AddHighlightItemParams) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddHighlightItem", ctx, arg)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddHighlightItem indicates an expected call of AddHighlightItem.
+func (mr *MockStoreMockRecorder) AddHighlightItem(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddHighlightItem", reflect.TypeOf((*MockStore)(nil).AddHighlightItem), ctx, arg)
+}
+
+// AddMediaBlocklistEntry mocks base method.
+func (m *MockStore) AddMediaBlocklistEntry(ctx context.Context, arg db.AddMediaBlocklistEntryParams) (db.MediaBlocklist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMediaBlocklistEntry", ctx, arg)
+	ret0, _ := ret[0].(db.MediaBlocklist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddMediaBlocklistEntry indicates an expected call of AddMediaBlocklistEntry.
+func (mr *MockStoreMockRecorder) AddMediaBlocklistEntry(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMediaBlocklistEntry", reflect.TypeOf((*MockStore)(nil).AddMediaBlocklistEntry), ctx, arg)
+}
+
+// AddReportToEscalation mocks base method.
+func (m *MockStore) AddReportToEscalation(ctx context.Context, arg db.AddReportToEscalationParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddReportToEscalation", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddReportToEscalation indicates an expected call of AddReportToEscalation.
+func (mr *MockStoreMockRecorder) AddReportToEscalation(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReportToEscalation", reflect.TypeOf((*MockStore)(nil).AddReportToEscalation), ctx, arg)
+}
+
+// AdjustUserTrust mocks base method.
+func (m *MockStore) AdjustUserTrust(ctx context.Context, arg db.AdjustUserTrustParams) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdjustUserTrust", ctx, arg)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdjustUserTrust indicates an expected call of AdjustUserTrust.
+func (mr *MockStoreMockRecorder) AdjustUserTrust(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdjustUserTrust", reflect.TypeOf((*MockStore)(nil).AdjustUserTrust), ctx, arg)
+}
+
 // ArchiveStory mocks base method.
 func (m *MockStore) ArchiveStory(ctx context.Context, arg db.ArchiveStoryParams) (db.ArchivedStory, error) {
 	m.ctrl.T.Helper()
@@ -58,6 +132,36 @@ func (mr *MockStoreMockRecorder) ArchiveStory(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveStory", reflect.TypeOf((*MockStore)(nil).ArchiveStory), ctx, arg)
 }
 
+// ArchiveStoryItems mocks base method.
+func (m *MockStore) ArchiveStoryItems(ctx context.Context, id uuid.UUID) ([]db.ArchivedStoryItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveStoryItems", ctx, id)
+	ret0, _ := ret[0].([]db.ArchivedStoryItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ArchiveStoryItems indicates an expected call of ArchiveStoryItems.
+func (mr *MockStoreMockRecorder) ArchiveStoryItems(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveStoryItems", reflect.TypeOf((*MockStore)(nil).ArchiveStoryItems), ctx, id)
+}
+
+// AutoArchiveExpiredStories mocks base method.
+func (m *MockStore) AutoArchiveExpiredStories(ctx context.Context, arg db.AutoArchiveExpiredStoriesParams) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AutoArchiveExpiredStories", ctx, arg)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AutoArchiveExpiredStories indicates an expected call of AutoArchiveExpiredStories.
+func (mr *MockStoreMockRecorder) AutoArchiveExpiredStories(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AutoArchiveExpiredStories", reflect.TypeOf((*MockStore)(nil).AutoArchiveExpiredStories), ctx, arg)
+}
+
 // BanUser mocks base method.
 func (m *MockStore) BanUser(ctx context.Context, arg db.BanUserParams) (db.User, error) {
 	m.ctrl.T.Helper()
@@ -103,6 +207,66 @@ func (mr *MockStoreMockRecorder) BoostUser(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BoostUser", reflect.TypeOf((*MockStore)(nil).BoostUser), ctx, arg)
 }
 
+// CanViewStory mocks base method.
+func (m *MockStore) CanViewStory(ctx context.Context, arg db.CanViewStoryParams) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CanViewStory", ctx, arg)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CanViewStory indicates an expected call of CanViewStory.
+func (mr *MockStoreMockRecorder) CanViewStory(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanViewStory", reflect.TypeOf((*MockStore)(nil).CanViewStory), ctx, arg)
+}
+
+// CancelScheduledStory mocks base method.
+func (m *MockStore) CancelScheduledStory(ctx context.Context, arg db.CancelScheduledStoryParams) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelScheduledStory", ctx, arg)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelScheduledStory indicates an expected call of CancelScheduledStory.
+func (mr *MockStoreMockRecorder) CancelScheduledStory(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelScheduledStory", reflect.TypeOf((*MockStore)(nil).CancelScheduledStory), ctx, arg)
+}
+
+// ClaimOverdueMeetupCheckins mocks base method.
+func (m *MockStore) ClaimOverdueMeetupCheckins(ctx context.Context, limit int32) ([]db.MeetupCheckin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimOverdueMeetupCheckins", ctx, limit)
+	ret0, _ := ret[0].([]db.MeetupCheckin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimOverdueMeetupCheckins indicates an expected call of ClaimOverdueMeetupCheckins.
+func (mr *MockStoreMockRecorder) ClaimOverdueMeetupCheckins(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimOverdueMeetupCheckins", reflect.TypeOf((*MockStore)(nil).ClaimOverdueMeetupCheckins), ctx, limit)
+}
+
+// CloseMeetupCheckin mocks base method.
+func (m *MockStore) CloseMeetupCheckin(ctx context.Context, arg db.CloseMeetupCheckinParams) (db.MeetupCheckin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseMeetupCheckin", ctx, arg)
+	ret0, _ := ret[0].(db.MeetupCheckin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloseMeetupCheckin indicates an expected call of CloseMeetupCheckin.
+func (mr *MockStoreMockRecorder) CloseMeetupCheckin(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseMeetupCheckin", reflect.TypeOf((*MockStore)(nil).CloseMeetupCheckin), ctx, arg)
+}
+
 // CountArchivedStories mocks base method.
 func (m *MockStore) CountArchivedStories(ctx context.Context, userID uuid.UUID) (int64, error) {
 	m.ctrl.T.Helper()
@@ -148,6 +312,36 @@ func (mr *MockStoreMockRecorder) CountCrossingsToday(ctx, userID1 any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCrossingsToday", reflect.TypeOf((*MockStore)(nil).CountCrossingsToday), ctx, userID1)
 }
 
+// CountEmergencyContacts mocks base method.
+func (m *MockStore) CountEmergencyContacts(ctx context.Context, userID uuid.UUID) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountEmergencyContacts", ctx, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountEmergencyContacts indicates an expected call of CountEmergencyContacts.
+func (mr *MockStoreMockRecorder) CountEmergencyContacts(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountEmergencyContacts", reflect.TypeOf((*MockStore)(nil).CountEmergencyContacts), ctx, userID)
+}
+
+// CountLocationStrikesSince mocks base method.
+func (m *MockStore) CountLocationStrikesSince(ctx context.Context, arg db.CountLocationStrikesSinceParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountLocationStrikesSince", ctx, arg)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountLocationStrikesSince indicates an expected call of CountLocationStrikesSince.
+func (mr *MockStoreMockRecorder) CountLocationStrikesSince(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountLocationStrikesSince", reflect.TypeOf((*MockStore)(nil).CountLocationStrikesSince), ctx, arg)
+}
+
 // CountStoryReactions mocks base method.
 func (m *MockStore) CountStoryReactions(ctx context.Context, storyID uuid.UUID) (int64, error) {
 	m.ctrl.T.Helper()
@@ -208,6 +402,21 @@ func (mr *MockStoreMockRecorder) CountUsers(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUsers", reflect.TypeOf((*MockStore)(nil).CountUsers), ctx)
 }
 
+// CreateBanAppeal mocks base method.
+func (m *MockStore) CreateBanAppeal(ctx context.Context, arg db.CreateBanAppealParams) (db.BanAppeal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBanAppeal", ctx, arg)
+	ret0, _ := ret[0].(db.BanAppeal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBanAppeal indicates an expected call of CreateBanAppeal.
+func (mr *MockStoreMockRecorder) CreateBanAppeal(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBanAppeal", reflect.TypeOf((*MockStore)(nil).CreateBanAppeal), ctx, arg)
+}
+
 // CreateConnectionRequest mocks base method.
 func (m *MockStore) CreateConnectionRequest(ctx context.Context, arg db.CreateConnectionRequestParams) (db.Connection, error) {
 	m.ctrl.T.Helper()
@@ -223,6 +432,21 @@ func (mr *MockStoreMockRecorder) CreateConnectionRequest(ctx, arg any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateConnectionRequest", reflect.TypeOf((*MockStore)(nil).CreateConnectionRequest), ctx, arg)
 }
 
+// CreateContentFlag mocks base method.
+func (m *MockStore) CreateContentFlag(ctx context.Context, arg db.CreateContentFlagParams) (db.ContentFlag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateContentFlag", ctx, arg)
+	ret0, _ := ret[0].(db.ContentFlag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateContentFlag indicates an expected call of CreateContentFlag.
+func (mr *MockStoreMockRecorder) CreateContentFlag(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateContentFlag", reflect.TypeOf((*MockStore)(nil).CreateContentFlag), ctx, arg)
+}
+
 // CreateCrossing mocks base method.
 func (m *MockStore) CreateCrossing(ctx context.Context, arg db.CreateCrossingParams) (db.Crossing, error) {
 	m.ctrl.T.Helper()
@@ -238,6 +462,51 @@ func (mr *MockStoreMockRecorder) CreateCrossing(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCrossing", reflect.TypeOf((*MockStore)(nil).CreateCrossing), ctx, arg)
 }
 
+// CreateEmergencyContact mocks base method.
+func (m *MockStore) CreateEmergencyContact(ctx context.Context, arg db.CreateEmergencyContactParams) (db.EmergencyContact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateEmergencyContact", ctx, arg)
+	ret0, _ := ret[0].(db.EmergencyContact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateEmergencyContact indicates an expected call of CreateEmergencyContact.
+func (mr *MockStoreMockRecorder) CreateEmergencyContact(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateEmergencyContact", reflect.TypeOf((*MockStore)(nil).CreateEmergencyContact), ctx, arg)
+}
+
+// CreateHiddenWord mocks base method.
+func (m *MockStore) CreateHiddenWord(ctx context.Context, arg db.CreateHiddenWordParams) (db.HiddenWord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHiddenWord", ctx, arg)
+	ret0, _ := ret[0].(db.HiddenWord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateHiddenWord indicates an expected call of CreateHiddenWord.
+func (mr *MockStoreMockRecorder) CreateHiddenWord(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHiddenWord", reflect.TypeOf((*MockStore)(nil).CreateHiddenWord), ctx, arg)
+}
+
+// CreateHighlight mocks base method.
+func (m *MockStore) CreateHighlight(ctx context.Context, arg db.CreateHighlightParams) (db.Highlight, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateHighlight", ctx, arg)
+	ret0, _ := ret[0].(db.Highlight)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateHighlight indicates an expected call of CreateHighlight.
+func (mr *MockStoreMockRecorder) CreateHighlight(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateHighlight", reflect.TypeOf((*MockStore)(nil).CreateHighlight), ctx, arg)
+}
+
 // CreateLocation mocks base method.
 func (m *MockStore) CreateLocation(ctx context.Context, arg db.CreateLocationParams) (db.Location, error) {
 	m.ctrl.T.Helper()
@@ -253,6 +522,50 @@ func (mr *MockStoreMockRecorder) CreateLocation(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLocation", reflect.TypeOf((*MockStore)(nil).CreateLocation), ctx, arg)
 }
 
+// CreateLocationStrike mocks base method.
+func (m *MockStore) CreateLocationStrike(ctx context.Context, arg db.CreateLocationStrikeParams) (db.LocationStrike, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLocationStrike", ctx, arg)
+	ret0, _ := ret[0].(db.LocationStrike)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLocationStrike indicates an expected call of CreateLocationStrike.
+func (mr *MockStoreMockRecorder) CreateLocationStrike(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLocationStrike", reflect.TypeOf((*MockStore)(nil).CreateLocationStrike), ctx, arg)
+}
+
+// CreateMediaHash mocks base method.
+func (m *MockStore) CreateMediaHash(ctx context.Context, arg db.CreateMediaHashParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMediaHash", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateMediaHash indicates an expected call of CreateMediaHash.
+func (mr *MockStoreMockRecorder) CreateMediaHash(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMediaHash", reflect.TypeOf((*MockStore)(nil).CreateMediaHash), ctx, arg)
+}
+
+// CreateMeetupCheckin mocks base method.
+func (m *MockStore) CreateMeetupCheckin(ctx context.Context, arg db.CreateMeetupCheckinParams) (db.MeetupCheckin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMeetupCheckin", ctx, arg)
+	ret0, _ := ret[0].(db.MeetupCheckin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMeetupCheckin indicates an expected call of CreateMeetupCheckin.
+func (mr *MockStoreMockRecorder) CreateMeetupCheckin(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMeetupCheckin", reflect.TypeOf((*MockStore)(nil).CreateMeetupCheckin), ctx, arg)
+}
+
 // CreateMessage mocks base method.
 func (m *MockStore) CreateMessage(ctx context.Context, arg db.CreateMessageParams) (db.Message, error) {
 	m.ctrl.T.Helper()
@@ -283,6 +596,50 @@ func (mr *MockStoreMockRecorder) CreateMessageReaction(ctx, arg any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMessageReaction", reflect.TypeOf((*MockStore)(nil).CreateMessageReaction), ctx, arg)
 }
 
+// CreateMessageStoryRef mocks base method.
+func (m *MockStore) CreateMessageStoryRef(ctx context.Context, arg db.CreateMessageStoryRefParams) (db.MessageStoryRef, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateMessageStoryRef", ctx, arg)
+	ret0, _ := ret[0].(db.MessageStoryRef)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateMessageStoryRef indicates an expected call of CreateMessageStoryRef.
+func (mr *MockStoreMockRecorder) CreateMessageStoryRef(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateMessageStoryRef", reflect.TypeOf((*MockStore)(nil).CreateMessageStoryRef), ctx, arg)
+}
+
+// CreateModerationAction mocks base method.
+func (m *MockStore) CreateModerationAction(ctx context.Context, arg db.CreateModerationActionParams) (db.ModerationAction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateModerationAction", ctx, arg)
+	ret0, _ := ret[0].(db.ModerationAction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateModerationAction indicates an expected call of CreateModerationAction.
+func (mr *MockStoreMockRecorder) CreateModerationAction(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateModerationAction", reflect.TypeOf((*MockStore)(nil).CreateModerationAction), ctx, arg)
+}
+
+// CreateModerationAudit mocks base method.
+func (m *MockStore) CreateModerationAudit(ctx context.Context, arg db.CreateModerationAuditParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateModerationAudit", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateModerationAudit indicates an expected call of CreateModerationAudit.
+func (mr *MockStoreMockRecorder) CreateModerationAudit(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateModerationAudit", reflect.TypeOf((*MockStore)(nil).CreateModerationAudit), ctx, arg)
+}
+
 // CreateNotification mocks base method.
 func (m *MockStore) CreateNotification(ctx context.Context, arg db.CreateNotificationParams) (db.Notification, error) {
 	m.ctrl.T.Helper()
@@ -313,6 +670,66 @@ func (mr *MockStoreMockRecorder) CreateReport(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReport", reflect.TypeOf((*MockStore)(nil).CreateReport), ctx, arg)
 }
 
+// CreateReportEscalation mocks base method.
+func (m *MockStore) CreateReportEscalation(ctx context.Context, arg db.CreateReportEscalationParams) (db.ReportEscalation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReportEscalation", ctx, arg)
+	ret0, _ := ret[0].(db.ReportEscalation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReportEscalation indicates an expected call of CreateReportEscalation.
+func (mr *MockStoreMockRecorder) CreateReportEscalation(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReportEscalation", reflect.TypeOf((*MockStore)(nil).CreateReportEscalation), ctx, arg)
+}
+
+// CreateReportEvidence mocks base method.
+func (m *MockStore) CreateReportEvidence(ctx context.Context, arg db.CreateReportEvidenceParams) (db.ReportEvidence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReportEvidence", ctx, arg)
+	ret0, _ := ret[0].(db.ReportEvidence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReportEvidence indicates an expected call of CreateReportEvidence.
+func (mr *MockStoreMockRecorder) CreateReportEvidence(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReportEvidence", reflect.TypeOf((*MockStore)(nil).CreateReportEvidence), ctx, arg)
+}
+
+// CreateReportNote mocks base method.
+func (m *MockStore) CreateReportNote(ctx context.Context, arg db.CreateReportNoteParams) (db.ReportNote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReportNote", ctx, arg)
+	ret0, _ := ret[0].(db.ReportNote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReportNote indicates an expected call of CreateReportNote.
+func (mr *MockStoreMockRecorder) CreateReportNote(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReportNote", reflect.TypeOf((*MockStore)(nil).CreateReportNote), ctx, arg)
+}
+
+// CreateReportOutcome mocks base method.
+func (m *MockStore) CreateReportOutcome(ctx context.Context, arg db.CreateReportOutcomeParams) (db.ReportOutcome, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateReportOutcome", ctx, arg)
+	ret0, _ := ret[0].(db.ReportOutcome)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateReportOutcome indicates an expected call of CreateReportOutcome.
+func (mr *MockStoreMockRecorder) CreateReportOutcome(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateReportOutcome", reflect.TypeOf((*MockStore)(nil).CreateReportOutcome), ctx, arg)
+}
+
 // CreateSession mocks base method.
 func (m *MockStore) CreateSession(ctx context.Context, arg db.CreateSessionParams) (db.Session, error) {
 	m.ctrl.T.Helper()
@@ -328,6 +745,21 @@ func (mr *MockStoreMockRecorder) CreateSession(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockStore)(nil).CreateSession), ctx, arg)
 }
 
+// CreateStickerResponse mocks base method.
+func (m *MockStore) CreateStickerResponse(ctx context.Context, arg db.CreateStickerResponseParams) (db.StoryStickerResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStickerResponse", ctx, arg)
+	ret0, _ := ret[0].(db.StoryStickerResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateStickerResponse indicates an expected call of CreateStickerResponse.
+func (mr *MockStoreMockRecorder) CreateStickerResponse(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStickerResponse", reflect.TypeOf((*MockStore)(nil).CreateStickerResponse), ctx, arg)
+}
+
 // CreateStory mocks base method.
 func (m *MockStore) CreateStory(ctx context.Context, arg db.CreateStoryParams) (db.CreateStoryRow, error) {
 	m.ctrl.T.Helper()
@@ -343,6 +775,36 @@ func (mr *MockStoreMockRecorder) CreateStory(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStory", reflect.TypeOf((*MockStore)(nil).CreateStory), ctx, arg)
 }
 
+// CreateStoryExpiryNotices mocks base method.
+func (m *MockStore) CreateStoryExpiryNotices(ctx context.Context, expiresBefore time.Time) ([]uuid.NullUUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStoryExpiryNotices", ctx, expiresBefore)
+	ret0, _ := ret[0].([]uuid.NullUUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateStoryExpiryNotices indicates an expected call of CreateStoryExpiryNotices.
+func (mr *MockStoreMockRecorder) CreateStoryExpiryNotices(ctx, expiresBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStoryExpiryNotices", reflect.TypeOf((*MockStore)(nil).CreateStoryExpiryNotices), ctx, expiresBefore)
+}
+
+// CreateStoryItem mocks base method.
+func (m *MockStore) CreateStoryItem(ctx context.Context, arg db.CreateStoryItemParams) (db.StoryItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStoryItem", ctx, arg)
+	ret0, _ := ret[0].(db.StoryItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateStoryItem indicates an expected call of CreateStoryItem.
+func (mr *MockStoreMockRecorder) CreateStoryItem(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStoryItem", reflect.TypeOf((*MockStore)(nil).CreateStoryItem), ctx, arg)
+}
+
 // CreateStoryMention mocks base method.
 func (m *MockStore) CreateStoryMention(ctx context.Context, arg db.CreateStoryMentionParams) (db.StoryMention, error) {
 	m.ctrl.T.Helper()
@@ -373,21 +835,51 @@ func (mr *MockStoreMockRecorder) CreateStoryReaction(ctx, arg any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStoryReaction", reflect.TypeOf((*MockStore)(nil).CreateStoryReaction), ctx, arg)
 }
 
-// CreateStoryView mocks base method.
-func (m *MockStore) CreateStoryView(ctx context.Context, arg db.CreateStoryViewParams) (db.StoryView, error) {
+// CreateStorySticker mocks base method.
+func (m *MockStore) CreateStorySticker(ctx context.Context, arg db.CreateStoryStickerParams) (db.StorySticker, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateStoryView", ctx, arg)
-	ret0, _ := ret[0].(db.StoryView)
+	ret := m.ctrl.Call(m, "CreateStorySticker", ctx, arg)
+	ret0, _ := ret[0].(db.StorySticker)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateStoryView indicates an expected call of CreateStoryView.
+// CreateStorySticker indicates an expected call of CreateStorySticker.
+func (mr *MockStoreMockRecorder) CreateStorySticker(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStorySticker", reflect.TypeOf((*MockStore)(nil).CreateStorySticker), ctx, arg)
+}
+
+// CreateStoryView mocks base method.
+func (m *MockStore) CreateStoryView(ctx context.Context, arg db.CreateStoryViewParams) (db.StoryView, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStoryView", ctx, arg)
+	ret0, _ := ret[0].(db.StoryView)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateStoryView indicates an expected call of CreateStoryView.
 func (mr *MockStoreMockRecorder) CreateStoryView(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStoryView", reflect.TypeOf((*MockStore)(nil).CreateStoryView), ctx, arg)
 }
 
+// CreateSystemReport mocks base method.
+func (m *MockStore) CreateSystemReport(ctx context.Context, arg db.CreateSystemReportParams) (db.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSystemReport", ctx, arg)
+	ret0, _ := ret[0].(db.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSystemReport indicates an expected call of CreateSystemReport.
+func (mr *MockStoreMockRecorder) CreateSystemReport(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSystemReport", reflect.TypeOf((*MockStore)(nil).CreateSystemReport), ctx, arg)
+}
+
 // CreateUser mocks base method.
 func (m *MockStore) CreateUser(ctx context.Context, arg db.CreateUserParams) (db.User, error) {
 	m.ctrl.T.Helper()
@@ -403,6 +895,36 @@ func (mr *MockStoreMockRecorder) CreateUser(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStore)(nil).CreateUser), ctx, arg)
 }
 
+// CreateUserRestriction mocks base method.
+func (m *MockStore) CreateUserRestriction(ctx context.Context, arg db.CreateUserRestrictionParams) (db.UserRestriction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUserRestriction", ctx, arg)
+	ret0, _ := ret[0].(db.UserRestriction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUserRestriction indicates an expected call of CreateUserRestriction.
+func (mr *MockStoreMockRecorder) CreateUserRestriction(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserRestriction", reflect.TypeOf((*MockStore)(nil).CreateUserRestriction), ctx, arg)
+}
+
+// DecideBanAppeal mocks base method.
+func (m *MockStore) DecideBanAppeal(ctx context.Context, arg db.DecideBanAppealParams) (db.BanAppeal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecideBanAppeal", ctx, arg)
+	ret0, _ := ret[0].(db.BanAppeal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DecideBanAppeal indicates an expected call of DecideBanAppeal.
+func (mr *MockStoreMockRecorder) DecideBanAppeal(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecideBanAppeal", reflect.TypeOf((*MockStore)(nil).DecideBanAppeal), ctx, arg)
+}
+
 // DeleteAllUserData mocks base method.
 func (m *MockStore) DeleteAllUserData(ctx context.Context, id uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -431,6 +953,20 @@ func (mr *MockStoreMockRecorder) DeleteArchivedStory(ctx, arg any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteArchivedStory", reflect.TypeOf((*MockStore)(nil).DeleteArchivedStory), ctx, arg)
 }
 
+// DeleteCloseFriendPair mocks base method.
+func (m *MockStore) DeleteCloseFriendPair(ctx context.Context, arg db.DeleteCloseFriendPairParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCloseFriendPair", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCloseFriendPair indicates an expected call of DeleteCloseFriendPair.
+func (mr *MockStoreMockRecorder) DeleteCloseFriendPair(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCloseFriendPair", reflect.TypeOf((*MockStore)(nil).DeleteCloseFriendPair), ctx, arg)
+}
+
 // DeleteConnection mocks base method.
 func (m *MockStore) DeleteConnection(ctx context.Context, arg db.DeleteConnectionParams) error {
 	m.ctrl.T.Helper()
@@ -459,6 +995,35 @@ func (mr *MockStoreMockRecorder) DeleteConversation(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteConversation", reflect.TypeOf((*MockStore)(nil).DeleteConversation), ctx, arg)
 }
 
+// DeleteEmergencyContact mocks base method.
+func (m *MockStore) DeleteEmergencyContact(ctx context.Context, arg db.DeleteEmergencyContactParams) (db.EmergencyContact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEmergencyContact", ctx, arg)
+	ret0, _ := ret[0].(db.EmergencyContact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteEmergencyContact indicates an expected call of DeleteEmergencyContact.
+func (mr *MockStoreMockRecorder) DeleteEmergencyContact(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEmergencyContact", reflect.TypeOf((*MockStore)(nil).DeleteEmergencyContact), ctx, arg)
+}
+
+// DeleteEmergencyContactPair mocks base method.
+func (m *MockStore) DeleteEmergencyContactPair(ctx context.Context, arg db.DeleteEmergencyContactPairParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEmergencyContactPair", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteEmergencyContactPair indicates an expected call of DeleteEmergencyContactPair.
+func (mr *MockStoreMockRecorder) DeleteEmergencyContactPair(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEmergencyContactPair", reflect.TypeOf((*MockStore)(nil).DeleteEmergencyContactPair), ctx, arg)
+}
+
 // DeleteExpiredLocations mocks base method.
 func (m *MockStore) DeleteExpiredLocations(ctx context.Context) error {
 	m.ctrl.T.Helper()
@@ -487,18 +1052,92 @@ func (mr *MockStoreMockRecorder) DeleteExpiredMessages(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredMessages", reflect.TypeOf((*MockStore)(nil).DeleteExpiredMessages), ctx)
 }
 
+// DeleteExpiredReportEvidence mocks base method.
+func (m *MockStore) DeleteExpiredReportEvidence(ctx context.Context) ([]sql.NullString, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredReportEvidence", ctx)
+	ret0, _ := ret[0].([]sql.NullString)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredReportEvidence indicates an expected call of DeleteExpiredReportEvidence.
+func (mr *MockStoreMockRecorder) DeleteExpiredReportEvidence(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredReportEvidence", reflect.TypeOf((*MockStore)(nil).DeleteExpiredReportEvidence), ctx)
+}
+
 // DeleteExpiredStories mocks base method.
-func (m *MockStore) DeleteExpiredStories(ctx context.Context) error {
+func (m *MockStore) DeleteExpiredStories(ctx context.Context, expiredBefore time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteExpiredStories", ctx)
+	ret := m.ctrl.Call(m, "DeleteExpiredStories", ctx, expiredBefore)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteExpiredStories indicates an expected call of DeleteExpiredStories.
-func (mr *MockStoreMockRecorder) DeleteExpiredStories(ctx any) *gomock.Call {
+func (mr *MockStoreMockRecorder) DeleteExpiredStories(ctx, expiredBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredStories", reflect.TypeOf((*MockStore)(nil).DeleteExpiredStories), ctx, expiredBefore)
+}
+
+// DeleteExpiredUserMutes mocks base method.
+func (m *MockStore) DeleteExpiredUserMutes(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredUserMutes", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteExpiredUserMutes indicates an expected call of DeleteExpiredUserMutes.
+func (mr *MockStoreMockRecorder) DeleteExpiredUserMutes(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredUserMutes", reflect.TypeOf((*MockStore)(nil).DeleteExpiredUserMutes), ctx)
+}
+
+// DeleteHiddenWord mocks base method.
+func (m *MockStore) DeleteHiddenWord(ctx context.Context, arg db.DeleteHiddenWordParams) (db.HiddenWord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteHiddenWord", ctx, arg)
+	ret0, _ := ret[0].(db.HiddenWord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteHiddenWord indicates an expected call of DeleteHiddenWord.
+func (mr *MockStoreMockRecorder) DeleteHiddenWord(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteHiddenWord", reflect.TypeOf((*MockStore)(nil).DeleteHiddenWord), ctx, arg)
+}
+
+// DeleteHighlight mocks base method.
+func (m *MockStore) DeleteHighlight(ctx context.Context, arg db.DeleteHighlightParams) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteHighlight", ctx, arg)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteHighlight indicates an expected call of DeleteHighlight.
+func (mr *MockStoreMockRecorder) DeleteHighlight(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteHighlight", reflect.TypeOf((*MockStore)(nil).DeleteHighlight), ctx, arg)
+}
+
+// DeleteMediaBlocklistEntry mocks base method.
+func (m *MockStore) DeleteMediaBlocklistEntry(ctx context.Context, id uuid.UUID) (db.MediaBlocklist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMediaBlocklistEntry", ctx, id)
+	ret0, _ := ret[0].(db.MediaBlocklist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteMediaBlocklistEntry indicates an expected call of DeleteMediaBlocklistEntry.
+func (mr *MockStoreMockRecorder) DeleteMediaBlocklistEntry(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredStories", reflect.TypeOf((*MockStore)(nil).DeleteExpiredStories), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMediaBlocklistEntry", reflect.TypeOf((*MockStore)(nil).DeleteMediaBlocklistEntry), ctx, id)
 }
 
 // DeleteMessage mocks base method.
@@ -571,6 +1210,20 @@ func (mr *MockStoreMockRecorder) DeleteStory(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStory", reflect.TypeOf((*MockStore)(nil).DeleteStory), ctx, id)
 }
 
+// DeleteStoryArchives mocks base method.
+func (m *MockStore) DeleteStoryArchives(ctx context.Context, storyID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteStoryArchives", ctx, storyID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteStoryArchives indicates an expected call of DeleteStoryArchives.
+func (mr *MockStoreMockRecorder) DeleteStoryArchives(ctx, storyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteStoryArchives", reflect.TypeOf((*MockStore)(nil).DeleteStoryArchives), ctx, storyID)
+}
+
 // DeleteStoryMentions mocks base method.
 func (m *MockStore) DeleteStoryMentions(ctx context.Context, storyID uuid.UUID) error {
 	m.ctrl.T.Helper()
@@ -613,6 +1266,20 @@ func (mr *MockStoreMockRecorder) DeleteUser(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockStore)(nil).DeleteUser), ctx, id)
 }
 
+// DeleteUserMute mocks base method.
+func (m *MockStore) DeleteUserMute(ctx context.Context, arg db.DeleteUserMuteParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserMute", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserMute indicates an expected call of DeleteUserMute.
+func (mr *MockStoreMockRecorder) DeleteUserMute(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserMute", reflect.TypeOf((*MockStore)(nil).DeleteUserMute), ctx, arg)
+}
+
 // ExecTx mocks base method.
 func (m *MockStore) ExecTx(ctx context.Context, fn func(*db.Queries) error) error {
 	m.ctrl.T.Helper()
@@ -642,6 +1309,21 @@ func (mr *MockStoreMockRecorder) FindPotentialCrossings(ctx, arg any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindPotentialCrossings", reflect.TypeOf((*MockStore)(nil).FindPotentialCrossings), ctx, arg)
 }
 
+// GetActiveMeetupCheckin mocks base method.
+func (m *MockStore) GetActiveMeetupCheckin(ctx context.Context, userID uuid.UUID) (db.MeetupCheckin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveMeetupCheckin", ctx, userID)
+	ret0, _ := ret[0].(db.MeetupCheckin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveMeetupCheckin indicates an expected call of GetActiveMeetupCheckin.
+func (mr *MockStoreMockRecorder) GetActiveMeetupCheckin(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveMeetupCheckin", reflect.TypeOf((*MockStore)(nil).GetActiveMeetupCheckin), ctx, userID)
+}
+
 // GetArchivedStories mocks base method.
 func (m *MockStore) GetArchivedStories(ctx context.Context, arg db.GetArchivedStoriesParams) ([]db.ArchivedStory, error) {
 	m.ctrl.T.Helper()
@@ -672,6 +1354,21 @@ func (mr *MockStoreMockRecorder) GetArchivedStory(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArchivedStory", reflect.TypeOf((*MockStore)(nil).GetArchivedStory), ctx, arg)
 }
 
+// GetBanAppeal mocks base method.
+func (m *MockStore) GetBanAppeal(ctx context.Context, id uuid.UUID) (db.BanAppeal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBanAppeal", ctx, id)
+	ret0, _ := ret[0].(db.BanAppeal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBanAppeal indicates an expected call of GetBanAppeal.
+func (mr *MockStoreMockRecorder) GetBanAppeal(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBanAppeal", reflect.TypeOf((*MockStore)(nil).GetBanAppeal), ctx, id)
+}
+
 // GetBlockedUsers mocks base method.
 func (m *MockStore) GetBlockedUsers(ctx context.Context, blockerID uuid.UUID) ([]db.GetBlockedUsersRow, error) {
 	m.ctrl.T.Helper()
@@ -717,6 +1414,21 @@ func (mr *MockStoreMockRecorder) GetConnectionStories(ctx, userID any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConnectionStories", reflect.TypeOf((*MockStore)(nil).GetConnectionStories), ctx, userID)
 }
 
+// GetContentFlag mocks base method.
+func (m *MockStore) GetContentFlag(ctx context.Context, id uuid.UUID) (db.ContentFlag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContentFlag", ctx, id)
+	ret0, _ := ret[0].(db.ContentFlag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContentFlag indicates an expected call of GetContentFlag.
+func (mr *MockStoreMockRecorder) GetContentFlag(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContentFlag", reflect.TypeOf((*MockStore)(nil).GetContentFlag), ctx, id)
+}
+
 // GetConversationList mocks base method.
 func (m *MockStore) GetConversationList(ctx context.Context, receiverID uuid.UUID) ([]db.GetConversationListRow, error) {
 	m.ctrl.T.Helper()
@@ -777,6 +1489,81 @@ func (mr *MockStoreMockRecorder) GetEngagementStats(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEngagementStats", reflect.TypeOf((*MockStore)(nil).GetEngagementStats), ctx)
 }
 
+// GetHighlightForViewer mocks base method.
+func (m *MockStore) GetHighlightForViewer(ctx context.Context, arg db.GetHighlightForViewerParams) (db.Highlight, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHighlightForViewer", ctx, arg)
+	ret0, _ := ret[0].(db.Highlight)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHighlightForViewer indicates an expected call of GetHighlightForViewer.
+func (mr *MockStoreMockRecorder) GetHighlightForViewer(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHighlightForViewer", reflect.TypeOf((*MockStore)(nil).GetHighlightForViewer), ctx, arg)
+}
+
+// GetLatestBanAppeal mocks base method.
+func (m *MockStore) GetLatestBanAppeal(ctx context.Context, userID uuid.UUID) (db.BanAppeal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestBanAppeal", ctx, userID)
+	ret0, _ := ret[0].(db.BanAppeal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestBanAppeal indicates an expected call of GetLatestBanAppeal.
+func (mr *MockStoreMockRecorder) GetLatestBanAppeal(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestBanAppeal", reflect.TypeOf((*MockStore)(nil).GetLatestBanAppeal), ctx, userID)
+}
+
+// GetLatestModerationAction mocks base method.
+func (m *MockStore) GetLatestModerationAction(ctx context.Context, arg db.GetLatestModerationActionParams) (db.ModerationAction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestModerationAction", ctx, arg)
+	ret0, _ := ret[0].(db.ModerationAction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestModerationAction indicates an expected call of GetLatestModerationAction.
+func (mr *MockStoreMockRecorder) GetLatestModerationAction(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestModerationAction", reflect.TypeOf((*MockStore)(nil).GetLatestModerationAction), ctx, arg)
+}
+
+// GetLatestReportEscalation mocks base method.
+func (m *MockStore) GetLatestReportEscalation(ctx context.Context, arg db.GetLatestReportEscalationParams) (db.ReportEscalation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestReportEscalation", ctx, arg)
+	ret0, _ := ret[0].(db.ReportEscalation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestReportEscalation indicates an expected call of GetLatestReportEscalation.
+func (mr *MockStoreMockRecorder) GetLatestReportEscalation(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestReportEscalation", reflect.TypeOf((*MockStore)(nil).GetLatestReportEscalation), ctx, arg)
+}
+
+// GetMediaHash mocks base method.
+func (m *MockStore) GetMediaHash(ctx context.Context, mediaUrl string) (db.MediaHash, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMediaHash", ctx, mediaUrl)
+	ret0, _ := ret[0].(db.MediaHash)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMediaHash indicates an expected call of GetMediaHash.
+func (mr *MockStoreMockRecorder) GetMediaHash(ctx, mediaUrl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMediaHash", reflect.TypeOf((*MockStore)(nil).GetMediaHash), ctx, mediaUrl)
+}
+
 // GetMessage mocks base method.
 func (m *MockStore) GetMessage(ctx context.Context, id uuid.UUID) (db.Message, error) {
 	m.ctrl.T.Helper()
@@ -867,6 +1654,51 @@ func (mr *MockStoreMockRecorder) GetRecentProfileVisitors(ctx, viewedUserID any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecentProfileVisitors", reflect.TypeOf((*MockStore)(nil).GetRecentProfileVisitors), ctx, viewedUserID)
 }
 
+// GetReport mocks base method.
+func (m *MockStore) GetReport(ctx context.Context, id uuid.UUID) (db.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReport", ctx, id)
+	ret0, _ := ret[0].(db.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReport indicates an expected call of GetReport.
+func (mr *MockStoreMockRecorder) GetReport(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReport", reflect.TypeOf((*MockStore)(nil).GetReport), ctx, id)
+}
+
+// GetReportEvidence mocks base method.
+func (m *MockStore) GetReportEvidence(ctx context.Context, arg db.GetReportEvidenceParams) (db.ReportEvidence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReportEvidence", ctx, arg)
+	ret0, _ := ret[0].(db.ReportEvidence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReportEvidence indicates an expected call of GetReportEvidence.
+func (mr *MockStoreMockRecorder) GetReportEvidence(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReportEvidence", reflect.TypeOf((*MockStore)(nil).GetReportEvidence), ctx, arg)
+}
+
+// GetScheduledStory mocks base method.
+func (m *MockStore) GetScheduledStory(ctx context.Context, arg db.GetScheduledStoryParams) (db.GetScheduledStoryRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScheduledStory", ctx, arg)
+	ret0, _ := ret[0].(db.GetScheduledStoryRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScheduledStory indicates an expected call of GetScheduledStory.
+func (mr *MockStoreMockRecorder) GetScheduledStory(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheduledStory", reflect.TypeOf((*MockStore)(nil).GetScheduledStory), ctx, arg)
+}
+
 // GetSession mocks base method.
 func (m *MockStore) GetSession(ctx context.Context, id uuid.UUID) (db.Session, error) {
 	m.ctrl.T.Helper()
@@ -882,34 +1714,49 @@ func (mr *MockStoreMockRecorder) GetSession(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockStore)(nil).GetSession), ctx, id)
 }
 
-// GetStoriesInBounds mocks base method.
-func (m *MockStore) GetStoriesInBounds(ctx context.Context, arg db.GetStoriesInBoundsParams) ([]db.GetStoriesInBoundsRow, error) {
+// GetStickerResponse mocks base method.
+func (m *MockStore) GetStickerResponse(ctx context.Context, arg db.GetStickerResponseParams) (db.StoryStickerResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetStoriesInBounds", ctx, arg)
-	ret0, _ := ret[0].([]db.GetStoriesInBoundsRow)
+	ret := m.ctrl.Call(m, "GetStickerResponse", ctx, arg)
+	ret0, _ := ret[0].(db.StoryStickerResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetStoriesInBounds indicates an expected call of GetStoriesInBounds.
-func (mr *MockStoreMockRecorder) GetStoriesInBounds(ctx, arg any) *gomock.Call {
+// GetStickerResponse indicates an expected call of GetStickerResponse.
+func (mr *MockStoreMockRecorder) GetStickerResponse(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStoriesInBounds", reflect.TypeOf((*MockStore)(nil).GetStoriesInBounds), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStickerResponse", reflect.TypeOf((*MockStore)(nil).GetStickerResponse), ctx, arg)
+}
+
+// GetStickerTally mocks base method.
+func (m *MockStore) GetStickerTally(ctx context.Context, stickerID uuid.UUID) (db.GetStickerTallyRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStickerTally", ctx, stickerID)
+	ret0, _ := ret[0].(db.GetStickerTallyRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStickerTally indicates an expected call of GetStickerTally.
+func (mr *MockStoreMockRecorder) GetStickerTally(ctx, stickerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStickerTally", reflect.TypeOf((*MockStore)(nil).GetStickerTally), ctx, stickerID)
 }
 
-// GetStoriesWithinRadius mocks base method.
-func (m *MockStore) GetStoriesWithinRadius(ctx context.Context, arg db.GetStoriesWithinRadiusParams) ([]db.GetStoriesWithinRadiusRow, error) {
+// GetStoriesInBounds mocks base method.
+func (m *MockStore) GetStoriesInBounds(ctx context.Context, arg db.GetStoriesInBoundsParams) ([]db.GetStoriesInBoundsRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetStoriesWithinRadius", ctx, arg)
-	ret0, _ := ret[0].([]db.GetStoriesWithinRadiusRow)
+	ret := m.ctrl.Call(m, "GetStoriesInBounds", ctx, arg)
+	ret0, _ := ret[0].([]db.GetStoriesInBoundsRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetStoriesWithinRadius indicates an expected call of GetStoriesWithinRadius.
-func (mr *MockStoreMockRecorder) GetStoriesWithinRadius(ctx, arg any) *gomock.Call {
+// GetStoriesInBounds indicates an expected call of GetStoriesInBounds.
+func (mr *MockStoreMockRecorder) GetStoriesInBounds(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStoriesWithinRadius", reflect.TypeOf((*MockStore)(nil).GetStoriesWithinRadius), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStoriesInBounds", reflect.TypeOf((*MockStore)(nil).GetStoriesInBounds), ctx, arg)
 }
 
 // GetStoryByID mocks base method.
@@ -927,6 +1774,21 @@ func (mr *MockStoreMockRecorder) GetStoryByID(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStoryByID", reflect.TypeOf((*MockStore)(nil).GetStoryByID), ctx, id)
 }
 
+// GetStoryItemStats mocks base method.
+func (m *MockStore) GetStoryItemStats(ctx context.Context, storyID uuid.UUID) ([]db.GetStoryItemStatsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStoryItemStats", ctx, storyID)
+	ret0, _ := ret[0].([]db.GetStoryItemStatsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStoryItemStats indicates an expected call of GetStoryItemStats.
+func (mr *MockStoreMockRecorder) GetStoryItemStats(ctx, storyID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStoryItemStats", reflect.TypeOf((*MockStore)(nil).GetStoryItemStats), ctx, storyID)
+}
+
 // GetStoryMentions mocks base method.
 func (m *MockStore) GetStoryMentions(ctx context.Context, storyID uuid.UUID) ([]db.GetStoryMentionsRow, error) {
 	m.ctrl.T.Helper()
@@ -972,6 +1834,21 @@ func (mr *MockStoreMockRecorder) GetStoryStats(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStoryStats", reflect.TypeOf((*MockStore)(nil).GetStoryStats), ctx)
 }
 
+// GetStorySticker mocks base method.
+func (m *MockStore) GetStorySticker(ctx context.Context, arg db.GetStoryStickerParams) (db.StorySticker, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStorySticker", ctx, arg)
+	ret0, _ := ret[0].(db.StorySticker)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStorySticker indicates an expected call of GetStorySticker.
+func (mr *MockStoreMockRecorder) GetStorySticker(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStorySticker", reflect.TypeOf((*MockStore)(nil).GetStorySticker), ctx, arg)
+}
+
 // GetStoryViewers mocks base method.
 func (m *MockStore) GetStoryViewers(ctx context.Context, storyID uuid.UUID) ([]db.GetStoryViewersRow, error) {
 	m.ctrl.T.Helper()
@@ -1032,6 +1909,21 @@ func (mr *MockStoreMockRecorder) GetSystemStats(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSystemStats", reflect.TypeOf((*MockStore)(nil).GetSystemStats), ctx)
 }
 
+// GetTrustInputs mocks base method.
+func (m *MockStore) GetTrustInputs(ctx context.Context, id uuid.UUID) (db.GetTrustInputsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrustInputs", ctx, id)
+	ret0, _ := ret[0].(db.GetTrustInputsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrustInputs indicates an expected call of GetTrustInputs.
+func (mr *MockStoreMockRecorder) GetTrustInputs(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrustInputs", reflect.TypeOf((*MockStore)(nil).GetTrustInputs), ctx, id)
+}
+
 // GetUnreadMessageCount mocks base method.
 func (m *MockStore) GetUnreadMessageCount(ctx context.Context, receiverID uuid.UUID) (int64, error) {
 	m.ctrl.T.Helper()
@@ -1095,211 +1987,868 @@ func (mr *MockStoreMockRecorder) GetUserByID(ctx, id any) *gomock.Call {
 // GetUserByPhone mocks base method.
 func (m *MockStore) GetUserByPhone(ctx context.Context, phone string) (db.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserByPhone", ctx, phone)
-	ret0, _ := ret[0].(db.User)
+	ret := m.ctrl.Call(m, "GetUserByPhone", ctx, phone)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByPhone indicates an expected call of GetUserByPhone.
+func (mr *MockStoreMockRecorder) GetUserByPhone(ctx, phone any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByPhone", reflect.TypeOf((*MockStore)(nil).GetUserByPhone), ctx, phone)
+}
+
+// GetUserByUsername mocks base method.
+func (m *MockStore) GetUserByUsername(ctx context.Context, username string) (db.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByUsername", ctx, username)
+	ret0, _ := ret[0].(db.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByUsername indicates an expected call of GetUserByUsername.
+func (mr *MockStoreMockRecorder) GetUserByUsername(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUsername", reflect.TypeOf((*MockStore)(nil).GetUserByUsername), ctx, username)
+}
+
+// GetUserEngagementStats mocks base method.
+func (m *MockStore) GetUserEngagementStats(ctx context.Context, userID uuid.UUID) (db.GetUserEngagementStatsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserEngagementStats", ctx, userID)
+	ret0, _ := ret[0].(db.GetUserEngagementStatsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserEngagementStats indicates an expected call of GetUserEngagementStats.
+func (mr *MockStoreMockRecorder) GetUserEngagementStats(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserEngagementStats", reflect.TypeOf((*MockStore)(nil).GetUserEngagementStats), ctx, userID)
+}
+
+// GetUserMentions mocks base method.
+func (m *MockStore) GetUserMentions(ctx context.Context, arg db.GetUserMentionsParams) ([]db.GetUserMentionsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserMentions", ctx, arg)
+	ret0, _ := ret[0].([]db.GetUserMentionsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserMentions indicates an expected call of GetUserMentions.
+func (mr *MockStoreMockRecorder) GetUserMentions(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserMentions", reflect.TypeOf((*MockStore)(nil).GetUserMentions), ctx, arg)
+}
+
+// GetUserProfile mocks base method.
+func (m *MockStore) GetUserProfile(ctx context.Context, id uuid.UUID) (db.GetUserProfileRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserProfile", ctx, id)
+	ret0, _ := ret[0].(db.GetUserProfileRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserProfile indicates an expected call of GetUserProfile.
+func (mr *MockStoreMockRecorder) GetUserProfile(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserProfile", reflect.TypeOf((*MockStore)(nil).GetUserProfile), ctx, id)
+}
+
+// HasActiveRestriction mocks base method.
+func (m *MockStore) HasActiveRestriction(ctx context.Context, arg db.HasActiveRestrictionParams) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasActiveRestriction", ctx, arg)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasActiveRestriction indicates an expected call of HasActiveRestriction.
+func (mr *MockStoreMockRecorder) HasActiveRestriction(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasActiveRestriction", reflect.TypeOf((*MockStore)(nil).HasActiveRestriction), ctx, arg)
+}
+
+// HasPendingContentFlag mocks base method.
+func (m *MockStore) HasPendingContentFlag(ctx context.Context, arg db.HasPendingContentFlagParams) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasPendingContentFlag", ctx, arg)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasPendingContentFlag indicates an expected call of HasPendingContentFlag.
+func (mr *MockStoreMockRecorder) HasPendingContentFlag(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasPendingContentFlag", reflect.TypeOf((*MockStore)(nil).HasPendingContentFlag), ctx, arg)
+}
+
+// HasStoryReplyThread mocks base method.
+func (m *MockStore) HasStoryReplyThread(ctx context.Context, arg db.HasStoryReplyThreadParams) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasStoryReplyThread", ctx, arg)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasStoryReplyThread indicates an expected call of HasStoryReplyThread.
+func (mr *MockStoreMockRecorder) HasStoryReplyThread(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasStoryReplyThread", reflect.TypeOf((*MockStore)(nil).HasStoryReplyThread), ctx, arg)
+}
+
+// HasValidStory mocks base method.
+func (m *MockStore) HasValidStory(ctx context.Context, userID uuid.UUID) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasValidStory", ctx, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasValidStory indicates an expected call of HasValidStory.
+func (mr *MockStoreMockRecorder) HasValidStory(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasValidStory", reflect.TypeOf((*MockStore)(nil).HasValidStory), ctx, userID)
+}
+
+// HideMessage mocks base method.
+func (m *MockStore) HideMessage(ctx context.Context, arg db.HideMessageParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HideMessage", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HideMessage indicates an expected call of HideMessage.
+func (mr *MockStoreMockRecorder) HideMessage(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HideMessage", reflect.TypeOf((*MockStore)(nil).HideMessage), ctx, arg)
+}
+
+// HideStoriesFrom mocks base method.
+func (m *MockStore) HideStoriesFrom(ctx context.Context, arg db.HideStoriesFromParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HideStoriesFrom", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HideStoriesFrom indicates an expected call of HideStoriesFrom.
+func (mr *MockStoreMockRecorder) HideStoriesFrom(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HideStoriesFrom", reflect.TypeOf((*MockStore)(nil).HideStoriesFrom), ctx, arg)
+}
+
+// IsConversationMuted mocks base method.
+func (m *MockStore) IsConversationMuted(ctx context.Context, arg db.IsConversationMutedParams) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsConversationMuted", ctx, arg)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsConversationMuted indicates an expected call of IsConversationMuted.
+func (mr *MockStoreMockRecorder) IsConversationMuted(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsConversationMuted", reflect.TypeOf((*MockStore)(nil).IsConversationMuted), ctx, arg)
+}
+
+// IsUserBlocked mocks base method.
+func (m *MockStore) IsUserBlocked(ctx context.Context, arg db.IsUserBlockedParams) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsUserBlocked", ctx, arg)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsUserBlocked indicates an expected call of IsUserBlocked.
+func (mr *MockStoreMockRecorder) IsUserBlocked(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsUserBlocked", reflect.TypeOf((*MockStore)(nil).IsUserBlocked), ctx, arg)
+}
+
+// LiftUserRestrictionsBySource mocks base method.
+func (m *MockStore) LiftUserRestrictionsBySource(ctx context.Context, arg db.LiftUserRestrictionsBySourceParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LiftUserRestrictionsBySource", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LiftUserRestrictionsBySource indicates an expected call of LiftUserRestrictionsBySource.
+func (mr *MockStoreMockRecorder) LiftUserRestrictionsBySource(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LiftUserRestrictionsBySource", reflect.TypeOf((*MockStore)(nil).LiftUserRestrictionsBySource), ctx, arg)
+}
+
+// ListAllStories mocks base method.
+func (m *MockStore) ListAllStories(ctx context.Context, arg db.ListAllStoriesParams) ([]db.ListAllStoriesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAllStories", ctx, arg)
+	ret0, _ := ret[0].([]db.ListAllStoriesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAllStories indicates an expected call of ListAllStories.
+func (mr *MockStoreMockRecorder) ListAllStories(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllStories", reflect.TypeOf((*MockStore)(nil).ListAllStories), ctx, arg)
+}
+
+// ListArchivedStoryItems mocks base method.
+func (m *MockStore) ListArchivedStoryItems(ctx context.Context, archiveIds []uuid.UUID) ([]db.ArchivedStoryItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListArchivedStoryItems", ctx, archiveIds)
+	ret0, _ := ret[0].([]db.ArchivedStoryItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListArchivedStoryItems indicates an expected call of ListArchivedStoryItems.
+func (mr *MockStoreMockRecorder) ListArchivedStoryItems(ctx, archiveIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListArchivedStoryItems", reflect.TypeOf((*MockStore)(nil).ListArchivedStoryItems), ctx, archiveIds)
+}
+
+// ListBanAppeals mocks base method.
+func (m *MockStore) ListBanAppeals(ctx context.Context, arg db.ListBanAppealsParams) ([]db.ListBanAppealsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBanAppeals", ctx, arg)
+	ret0, _ := ret[0].([]db.ListBanAppealsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBanAppeals indicates an expected call of ListBanAppeals.
+func (mr *MockStoreMockRecorder) ListBanAppeals(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBanAppeals", reflect.TypeOf((*MockStore)(nil).ListBanAppeals), ctx, arg)
+}
+
+// ListCloseFriends mocks base method.
+func (m *MockStore) ListCloseFriends(ctx context.Context, userID uuid.UUID) ([]db.ListCloseFriendsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCloseFriends", ctx, userID)
+	ret0, _ := ret[0].([]db.ListCloseFriendsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCloseFriends indicates an expected call of ListCloseFriends.
+func (mr *MockStoreMockRecorder) ListCloseFriends(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCloseFriends", reflect.TypeOf((*MockStore)(nil).ListCloseFriends), ctx, userID)
+}
+
+// ListConnections mocks base method.
+func (m *MockStore) ListConnections(ctx context.Context, requesterID uuid.UUID) ([]db.ListConnectionsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListConnections", ctx, requesterID)
+	ret0, _ := ret[0].([]db.ListConnectionsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListConnections indicates an expected call of ListConnections.
+func (mr *MockStoreMockRecorder) ListConnections(ctx, requesterID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListConnections", reflect.TypeOf((*MockStore)(nil).ListConnections), ctx, requesterID)
+}
+
+// ListContentFlags mocks base method.
+func (m *MockStore) ListContentFlags(ctx context.Context, arg db.ListContentFlagsParams) ([]db.ListContentFlagsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListContentFlags", ctx, arg)
+	ret0, _ := ret[0].([]db.ListContentFlagsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListContentFlags indicates an expected call of ListContentFlags.
+func (mr *MockStoreMockRecorder) ListContentFlags(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListContentFlags", reflect.TypeOf((*MockStore)(nil).ListContentFlags), ctx, arg)
+}
+
+// ListEmergencyContacts mocks base method.
+func (m *MockStore) ListEmergencyContacts(ctx context.Context, userID uuid.UUID) ([]db.EmergencyContact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEmergencyContacts", ctx, userID)
+	ret0, _ := ret[0].([]db.EmergencyContact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEmergencyContacts indicates an expected call of ListEmergencyContacts.
+func (mr *MockStoreMockRecorder) ListEmergencyContacts(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEmergencyContacts", reflect.TypeOf((*MockStore)(nil).ListEmergencyContacts), ctx, userID)
+}
+
+// ListFeedCandidates mocks base method.
+func (m *MockStore) ListFeedCandidates(ctx context.Context, arg db.ListFeedCandidatesParams) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFeedCandidates", ctx, arg)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFeedCandidates indicates an expected call of ListFeedCandidates.
+func (mr *MockStoreMockRecorder) ListFeedCandidates(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFeedCandidates", reflect.TypeOf((*MockStore)(nil).ListFeedCandidates), ctx, arg)
+}
+
+// ListFeedRankingSignals mocks base method.
+func (m *MockStore) ListFeedRankingSignals(ctx context.Context, arg db.ListFeedRankingSignalsParams) ([]db.ListFeedRankingSignalsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFeedRankingSignals", ctx, arg)
+	ret0, _ := ret[0].([]db.ListFeedRankingSignalsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFeedRankingSignals indicates an expected call of ListFeedRankingSignals.
+func (mr *MockStoreMockRecorder) ListFeedRankingSignals(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFeedRankingSignals", reflect.TypeOf((*MockStore)(nil).ListFeedRankingSignals), ctx, arg)
+}
+
+// ListFeedStories mocks base method.
+func (m *MockStore) ListFeedStories(ctx context.Context, arg db.ListFeedStoriesParams) ([]db.ListFeedStoriesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFeedStories", ctx, arg)
+	ret0, _ := ret[0].([]db.ListFeedStoriesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFeedStories indicates an expected call of ListFeedStories.
+func (mr *MockStoreMockRecorder) ListFeedStories(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFeedStories", reflect.TypeOf((*MockStore)(nil).ListFeedStories), ctx, arg)
+}
+
+// ListFeedViewerCandidates mocks base method.
+func (m *MockStore) ListFeedViewerCandidates(ctx context.Context, arg db.ListFeedViewerCandidatesParams) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFeedViewerCandidates", ctx, arg)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFeedViewerCandidates indicates an expected call of ListFeedViewerCandidates.
+func (mr *MockStoreMockRecorder) ListFeedViewerCandidates(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFeedViewerCandidates", reflect.TypeOf((*MockStore)(nil).ListFeedViewerCandidates), ctx, arg)
+}
+
+// ListHiddenMessages mocks base method.
+func (m *MockStore) ListHiddenMessages(ctx context.Context, userID uuid.UUID) ([]db.ListHiddenMessagesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHiddenMessages", ctx, userID)
+	ret0, _ := ret[0].([]db.ListHiddenMessagesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListHiddenMessages indicates an expected call of ListHiddenMessages.
+func (mr *MockStoreMockRecorder) ListHiddenMessages(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHiddenMessages", reflect.TypeOf((*MockStore)(nil).ListHiddenMessages), ctx, userID)
+}
+
+// ListHiddenPhrases mocks base method.
+func (m *MockStore) ListHiddenPhrases(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHiddenPhrases", ctx, userID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListHiddenPhrases indicates an expected call of ListHiddenPhrases.
+func (mr *MockStoreMockRecorder) ListHiddenPhrases(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHiddenPhrases", reflect.TypeOf((*MockStore)(nil).ListHiddenPhrases), ctx, userID)
+}
+
+// ListHiddenWords mocks base method.
+func (m *MockStore) ListHiddenWords(ctx context.Context, userID uuid.UUID) ([]db.HiddenWord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHiddenWords", ctx, userID)
+	ret0, _ := ret[0].([]db.HiddenWord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListHiddenWords indicates an expected call of ListHiddenWords.
+func (mr *MockStoreMockRecorder) ListHiddenWords(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHiddenWords", reflect.TypeOf((*MockStore)(nil).ListHiddenWords), ctx, userID)
+}
+
+// ListHighlightItems mocks base method.
+func (m *MockStore) ListHighlightItems(ctx context.Context, highlightID uuid.UUID) ([]db.ListHighlightItemsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListHighlightItems", ctx, highlightID)
+	ret0, _ := ret[0].([]db.ListHighlightItemsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListHighlightItems indicates an expected call of ListHighlightItems.
+func (mr *MockStoreMockRecorder) ListHighlightItems(ctx, highlightID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListHighlightItems", reflect.TypeOf((*MockStore)(nil).ListHighlightItems), ctx, highlightID)
+}
+
+// ListLocationStrikes mocks base method.
+func (m *MockStore) ListLocationStrikes(ctx context.Context, arg db.ListLocationStrikesParams) ([]db.LocationStrike, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLocationStrikes", ctx, arg)
+	ret0, _ := ret[0].([]db.LocationStrike)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLocationStrikes indicates an expected call of ListLocationStrikes.
+func (mr *MockStoreMockRecorder) ListLocationStrikes(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLocationStrikes", reflect.TypeOf((*MockStore)(nil).ListLocationStrikes), ctx, arg)
+}
+
+// ListMediaBlocklist mocks base method.
+func (m *MockStore) ListMediaBlocklist(ctx context.Context, arg db.ListMediaBlocklistParams) ([]db.MediaBlocklist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMediaBlocklist", ctx, arg)
+	ret0, _ := ret[0].([]db.MediaBlocklist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMediaBlocklist indicates an expected call of ListMediaBlocklist.
+func (mr *MockStoreMockRecorder) ListMediaBlocklist(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMediaBlocklist", reflect.TypeOf((*MockStore)(nil).ListMediaBlocklist), ctx, arg)
+}
+
+// ListMediaBlocklistHashes mocks base method.
+func (m *MockStore) ListMediaBlocklistHashes(ctx context.Context) ([]db.ListMediaBlocklistHashesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMediaBlocklistHashes", ctx)
+	ret0, _ := ret[0].([]db.ListMediaBlocklistHashesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMediaBlocklistHashes indicates an expected call of ListMediaBlocklistHashes.
+func (mr *MockStoreMockRecorder) ListMediaBlocklistHashes(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMediaBlocklistHashes", reflect.TypeOf((*MockStore)(nil).ListMediaBlocklistHashes), ctx)
+}
+
+// ListMessageContext mocks base method.
+func (m *MockStore) ListMessageContext(ctx context.Context, arg db.ListMessageContextParams) ([]db.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMessageContext", ctx, arg)
+	ret0, _ := ret[0].([]db.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMessageContext indicates an expected call of ListMessageContext.
+func (mr *MockStoreMockRecorder) ListMessageContext(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMessageContext", reflect.TypeOf((*MockStore)(nil).ListMessageContext), ctx, arg)
+}
+
+// ListMessageStoryRefs mocks base method.
+func (m *MockStore) ListMessageStoryRefs(ctx context.Context, messageIds []uuid.UUID) ([]db.MessageStoryRef, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMessageStoryRefs", ctx, messageIds)
+	ret0, _ := ret[0].([]db.MessageStoryRef)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMessageStoryRefs indicates an expected call of ListMessageStoryRefs.
+func (mr *MockStoreMockRecorder) ListMessageStoryRefs(ctx, messageIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMessageStoryRefs", reflect.TypeOf((*MockStore)(nil).ListMessageStoryRefs), ctx, messageIds)
+}
+
+// ListMessages mocks base method.
+func (m *MockStore) ListMessages(ctx context.Context, arg db.ListMessagesParams) ([]db.ListMessagesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMessages", ctx, arg)
+	ret0, _ := ret[0].([]db.ListMessagesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMessages indicates an expected call of ListMessages.
+func (mr *MockStoreMockRecorder) ListMessages(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMessages", reflect.TypeOf((*MockStore)(nil).ListMessages), ctx, arg)
+}
+
+// ListModerationActions mocks base method.
+func (m *MockStore) ListModerationActions(ctx context.Context, userID uuid.UUID) ([]db.ModerationAction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListModerationActions", ctx, userID)
+	ret0, _ := ret[0].([]db.ModerationAction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListModerationActions indicates an expected call of ListModerationActions.
+func (mr *MockStoreMockRecorder) ListModerationActions(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListModerationActions", reflect.TypeOf((*MockStore)(nil).ListModerationActions), ctx, userID)
+}
+
+// ListModerationAudit mocks base method.
+func (m *MockStore) ListModerationAudit(ctx context.Context, arg db.ListModerationAuditParams) ([]db.ModerationAudit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListModerationAudit", ctx, arg)
+	ret0, _ := ret[0].([]db.ModerationAudit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListModerationAudit indicates an expected call of ListModerationAudit.
+func (mr *MockStoreMockRecorder) ListModerationAudit(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListModerationAudit", reflect.TypeOf((*MockStore)(nil).ListModerationAudit), ctx, arg)
+}
+
+// ListNotifications mocks base method.
+func (m *MockStore) ListNotifications(ctx context.Context, arg db.ListNotificationsParams) ([]db.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNotifications", ctx, arg)
+	ret0, _ := ret[0].([]db.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNotifications indicates an expected call of ListNotifications.
+func (mr *MockStoreMockRecorder) ListNotifications(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotifications", reflect.TypeOf((*MockStore)(nil).ListNotifications), ctx, arg)
+}
+
+// ListPendingProfileFlags mocks base method.
+func (m *MockStore) ListPendingProfileFlags(ctx context.Context, userID uuid.UUID) ([]db.ContentFlag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingProfileFlags", ctx, userID)
+	ret0, _ := ret[0].([]db.ContentFlag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingProfileFlags indicates an expected call of ListPendingProfileFlags.
+func (mr *MockStoreMockRecorder) ListPendingProfileFlags(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingProfileFlags", reflect.TypeOf((*MockStore)(nil).ListPendingProfileFlags), ctx, userID)
+}
+
+// ListPendingRequests mocks base method.
+func (m *MockStore) ListPendingRequests(ctx context.Context, targetID uuid.UUID) ([]db.ListPendingRequestsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingRequests", ctx, targetID)
+	ret0, _ := ret[0].([]db.ListPendingRequestsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingRequests indicates an expected call of ListPendingRequests.
+func (mr *MockStoreMockRecorder) ListPendingRequests(ctx, targetID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingRequests", reflect.TypeOf((*MockStore)(nil).ListPendingRequests), ctx, targetID)
+}
+
+// ListPollVotes mocks base method.
+func (m *MockStore) ListPollVotes(ctx context.Context, stickerID uuid.UUID) ([]db.ListPollVotesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPollVotes", ctx, stickerID)
+	ret0, _ := ret[0].([]db.ListPollVotesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPollVotes indicates an expected call of ListPollVotes.
+func (mr *MockStoreMockRecorder) ListPollVotes(ctx, stickerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPollVotes", reflect.TypeOf((*MockStore)(nil).ListPollVotes), ctx, stickerID)
+}
+
+// ListProfileHighlights mocks base method.
+func (m *MockStore) ListProfileHighlights(ctx context.Context, arg db.ListProfileHighlightsParams) ([]db.ListProfileHighlightsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListProfileHighlights", ctx, arg)
+	ret0, _ := ret[0].([]db.ListProfileHighlightsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListProfileHighlights indicates an expected call of ListProfileHighlights.
+func (mr *MockStoreMockRecorder) ListProfileHighlights(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListProfileHighlights", reflect.TypeOf((*MockStore)(nil).ListProfileHighlights), ctx, arg)
+}
+
+// ListRecentlyActiveUserIDs mocks base method.
+func (m *MockStore) ListRecentlyActiveUserIDs(ctx context.Context, arg db.ListRecentlyActiveUserIDsParams) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRecentlyActiveUserIDs", ctx, arg)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRecentlyActiveUserIDs indicates an expected call of ListRecentlyActiveUserIDs.
+func (mr *MockStoreMockRecorder) ListRecentlyActiveUserIDs(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRecentlyActiveUserIDs", reflect.TypeOf((*MockStore)(nil).ListRecentlyActiveUserIDs), ctx, arg)
+}
+
+// ListReportEscalations mocks base method.
+func (m *MockStore) ListReportEscalations(ctx context.Context, arg db.ListReportEscalationsParams) ([]db.ListReportEscalationsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReportEscalations", ctx, arg)
+	ret0, _ := ret[0].([]db.ListReportEscalationsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReportEscalations indicates an expected call of ListReportEscalations.
+func (mr *MockStoreMockRecorder) ListReportEscalations(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReportEscalations", reflect.TypeOf((*MockStore)(nil).ListReportEscalations), ctx, arg)
+}
+
+// ListReportEvidence mocks base method.
+func (m *MockStore) ListReportEvidence(ctx context.Context, reportID uuid.UUID) ([]db.ReportEvidence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReportEvidence", ctx, reportID)
+	ret0, _ := ret[0].([]db.ReportEvidence)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserByPhone indicates an expected call of GetUserByPhone.
-func (mr *MockStoreMockRecorder) GetUserByPhone(ctx, phone any) *gomock.Call {
+// ListReportEvidence indicates an expected call of ListReportEvidence.
+func (mr *MockStoreMockRecorder) ListReportEvidence(ctx, reportID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByPhone", reflect.TypeOf((*MockStore)(nil).GetUserByPhone), ctx, phone)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReportEvidence", reflect.TypeOf((*MockStore)(nil).ListReportEvidence), ctx, reportID)
 }
 
-// GetUserByUsername mocks base method.
-func (m *MockStore) GetUserByUsername(ctx context.Context, username string) (db.User, error) {
+// ListReportNotes mocks base method.
+func (m *MockStore) ListReportNotes(ctx context.Context, reportID uuid.UUID) ([]db.ReportNote, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserByUsername", ctx, username)
-	ret0, _ := ret[0].(db.User)
+	ret := m.ctrl.Call(m, "ListReportNotes", ctx, reportID)
+	ret0, _ := ret[0].([]db.ReportNote)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserByUsername indicates an expected call of GetUserByUsername.
-func (mr *MockStoreMockRecorder) GetUserByUsername(ctx, username any) *gomock.Call {
+// ListReportNotes indicates an expected call of ListReportNotes.
+func (mr *MockStoreMockRecorder) ListReportNotes(ctx, reportID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUsername", reflect.TypeOf((*MockStore)(nil).GetUserByUsername), ctx, username)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReportNotes", reflect.TypeOf((*MockStore)(nil).ListReportNotes), ctx, reportID)
 }
 
-// GetUserEngagementStats mocks base method.
-func (m *MockStore) GetUserEngagementStats(ctx context.Context, userID uuid.UUID) (db.GetUserEngagementStatsRow, error) {
+// ListReportOutcomes mocks base method.
+func (m *MockStore) ListReportOutcomes(ctx context.Context, reportID uuid.UUID) ([]db.ReportOutcome, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserEngagementStats", ctx, userID)
-	ret0, _ := ret[0].(db.GetUserEngagementStatsRow)
+	ret := m.ctrl.Call(m, "ListReportOutcomes", ctx, reportID)
+	ret0, _ := ret[0].([]db.ReportOutcome)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserEngagementStats indicates an expected call of GetUserEngagementStats.
-func (mr *MockStoreMockRecorder) GetUserEngagementStats(ctx, userID any) *gomock.Call {
+// ListReportOutcomes indicates an expected call of ListReportOutcomes.
+func (mr *MockStoreMockRecorder) ListReportOutcomes(ctx, reportID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserEngagementStats", reflect.TypeOf((*MockStore)(nil).GetUserEngagementStats), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReportOutcomes", reflect.TypeOf((*MockStore)(nil).ListReportOutcomes), ctx, reportID)
 }
 
-// GetUserMentions mocks base method.
-func (m *MockStore) GetUserMentions(ctx context.Context, arg db.GetUserMentionsParams) ([]db.GetUserMentionsRow, error) {
+// ListReports mocks base method.
+func (m *MockStore) ListReports(ctx context.Context, arg db.ListReportsParams) ([]db.ListReportsRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserMentions", ctx, arg)
-	ret0, _ := ret[0].([]db.GetUserMentionsRow)
+	ret := m.ctrl.Call(m, "ListReports", ctx, arg)
+	ret0, _ := ret[0].([]db.ListReportsRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserMentions indicates an expected call of GetUserMentions.
-func (mr *MockStoreMockRecorder) GetUserMentions(ctx, arg any) *gomock.Call {
+// ListReports indicates an expected call of ListReports.
+func (mr *MockStoreMockRecorder) ListReports(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserMentions", reflect.TypeOf((*MockStore)(nil).GetUserMentions), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReports", reflect.TypeOf((*MockStore)(nil).ListReports), ctx, arg)
 }
 
-// GetUserProfile mocks base method.
-func (m *MockStore) GetUserProfile(ctx context.Context, id uuid.UUID) (db.GetUserProfileRow, error) {
+// ListScheduledStories mocks base method.
+func (m *MockStore) ListScheduledStories(ctx context.Context, userID uuid.UUID) ([]db.ListScheduledStoriesRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserProfile", ctx, id)
-	ret0, _ := ret[0].(db.GetUserProfileRow)
+	ret := m.ctrl.Call(m, "ListScheduledStories", ctx, userID)
+	ret0, _ := ret[0].([]db.ListScheduledStoriesRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUserProfile indicates an expected call of GetUserProfile.
-func (mr *MockStoreMockRecorder) GetUserProfile(ctx, id any) *gomock.Call {
+// ListScheduledStories indicates an expected call of ListScheduledStories.
+func (mr *MockStoreMockRecorder) ListScheduledStories(ctx, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserProfile", reflect.TypeOf((*MockStore)(nil).GetUserProfile), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListScheduledStories", reflect.TypeOf((*MockStore)(nil).ListScheduledStories), ctx, userID)
 }
 
-// HasValidStory mocks base method.
-func (m *MockStore) HasValidStory(ctx context.Context, userID uuid.UUID) (bool, error) {
+// ListSentConnectionRequests mocks base method.
+func (m *MockStore) ListSentConnectionRequests(ctx context.Context, requesterID uuid.UUID) ([]db.ListSentConnectionRequestsRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "HasValidStory", ctx, userID)
-	ret0, _ := ret[0].(bool)
+	ret := m.ctrl.Call(m, "ListSentConnectionRequests", ctx, requesterID)
+	ret0, _ := ret[0].([]db.ListSentConnectionRequestsRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// HasValidStory indicates an expected call of HasValidStory.
-func (mr *MockStoreMockRecorder) HasValidStory(ctx, userID any) *gomock.Call {
+// ListSentConnectionRequests indicates an expected call of ListSentConnectionRequests.
+func (mr *MockStoreMockRecorder) ListSentConnectionRequests(ctx, requesterID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasValidStory", reflect.TypeOf((*MockStore)(nil).HasValidStory), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSentConnectionRequests", reflect.TypeOf((*MockStore)(nil).ListSentConnectionRequests), ctx, requesterID)
 }
 
-// IsUserBlocked mocks base method.
-func (m *MockStore) IsUserBlocked(ctx context.Context, arg db.IsUserBlockedParams) (bool, error) {
+// ListStickerResponses mocks base method.
+func (m *MockStore) ListStickerResponses(ctx context.Context, stickerID uuid.UUID) ([]db.ListStickerResponsesRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IsUserBlocked", ctx, arg)
-	ret0, _ := ret[0].(bool)
+	ret := m.ctrl.Call(m, "ListStickerResponses", ctx, stickerID)
+	ret0, _ := ret[0].([]db.ListStickerResponsesRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// IsUserBlocked indicates an expected call of IsUserBlocked.
-func (mr *MockStoreMockRecorder) IsUserBlocked(ctx, arg any) *gomock.Call {
+// ListStickerResponses indicates an expected call of ListStickerResponses.
+func (mr *MockStoreMockRecorder) ListStickerResponses(ctx, stickerID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsUserBlocked", reflect.TypeOf((*MockStore)(nil).IsUserBlocked), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStickerResponses", reflect.TypeOf((*MockStore)(nil).ListStickerResponses), ctx, stickerID)
 }
 
-// ListAllStories mocks base method.
-func (m *MockStore) ListAllStories(ctx context.Context, arg db.ListAllStoriesParams) ([]db.ListAllStoriesRow, error) {
+// ListStoriesHiddenFrom mocks base method.
+func (m *MockStore) ListStoriesHiddenFrom(ctx context.Context, userID uuid.UUID) ([]db.ListStoriesHiddenFromRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListAllStories", ctx, arg)
-	ret0, _ := ret[0].([]db.ListAllStoriesRow)
+	ret := m.ctrl.Call(m, "ListStoriesHiddenFrom", ctx, userID)
+	ret0, _ := ret[0].([]db.ListStoriesHiddenFromRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListAllStories indicates an expected call of ListAllStories.
-func (mr *MockStoreMockRecorder) ListAllStories(ctx, arg any) *gomock.Call {
+// ListStoriesHiddenFrom indicates an expected call of ListStoriesHiddenFrom.
+func (mr *MockStoreMockRecorder) ListStoriesHiddenFrom(ctx, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllStories", reflect.TypeOf((*MockStore)(nil).ListAllStories), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStoriesHiddenFrom", reflect.TypeOf((*MockStore)(nil).ListStoriesHiddenFrom), ctx, userID)
 }
 
-// ListConnections mocks base method.
-func (m *MockStore) ListConnections(ctx context.Context, requesterID uuid.UUID) ([]db.ListConnectionsRow, error) {
+// ListStoryAudience mocks base method.
+func (m *MockStore) ListStoryAudience(ctx context.Context, storyID uuid.UUID) ([]db.ListStoryAudienceRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListConnections", ctx, requesterID)
-	ret0, _ := ret[0].([]db.ListConnectionsRow)
+	ret := m.ctrl.Call(m, "ListStoryAudience", ctx, storyID)
+	ret0, _ := ret[0].([]db.ListStoryAudienceRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListConnections indicates an expected call of ListConnections.
-func (mr *MockStoreMockRecorder) ListConnections(ctx, requesterID any) *gomock.Call {
+// ListStoryAudience indicates an expected call of ListStoryAudience.
+func (mr *MockStoreMockRecorder) ListStoryAudience(ctx, storyID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListConnections", reflect.TypeOf((*MockStore)(nil).ListConnections), ctx, requesterID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStoryAudience", reflect.TypeOf((*MockStore)(nil).ListStoryAudience), ctx, storyID)
 }
 
-// ListMessages mocks base method.
-func (m *MockStore) ListMessages(ctx context.Context, arg db.ListMessagesParams) ([]db.ListMessagesRow, error) {
+// ListStoryItems mocks base method.
+func (m *MockStore) ListStoryItems(ctx context.Context, storyID uuid.UUID) ([]db.StoryItem, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListMessages", ctx, arg)
-	ret0, _ := ret[0].([]db.ListMessagesRow)
+	ret := m.ctrl.Call(m, "ListStoryItems", ctx, storyID)
+	ret0, _ := ret[0].([]db.StoryItem)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListMessages indicates an expected call of ListMessages.
-func (mr *MockStoreMockRecorder) ListMessages(ctx, arg any) *gomock.Call {
+// ListStoryItems indicates an expected call of ListStoryItems.
+func (mr *MockStoreMockRecorder) ListStoryItems(ctx, storyID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMessages", reflect.TypeOf((*MockStore)(nil).ListMessages), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStoryItems", reflect.TypeOf((*MockStore)(nil).ListStoryItems), ctx, storyID)
 }
 
-// ListNotifications mocks base method.
-func (m *MockStore) ListNotifications(ctx context.Context, arg db.ListNotificationsParams) ([]db.Notification, error) {
+// ListStoryReportWeights mocks base method.
+func (m *MockStore) ListStoryReportWeights(ctx context.Context, arg db.ListStoryReportWeightsParams) ([]db.ListStoryReportWeightsRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListNotifications", ctx, arg)
-	ret0, _ := ret[0].([]db.Notification)
+	ret := m.ctrl.Call(m, "ListStoryReportWeights", ctx, arg)
+	ret0, _ := ret[0].([]db.ListStoryReportWeightsRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListNotifications indicates an expected call of ListNotifications.
-func (mr *MockStoreMockRecorder) ListNotifications(ctx, arg any) *gomock.Call {
+// ListStoryReportWeights indicates an expected call of ListStoryReportWeights.
+func (mr *MockStoreMockRecorder) ListStoryReportWeights(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotifications", reflect.TypeOf((*MockStore)(nil).ListNotifications), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStoryReportWeights", reflect.TypeOf((*MockStore)(nil).ListStoryReportWeights), ctx, arg)
 }
 
-// ListPendingRequests mocks base method.
-func (m *MockStore) ListPendingRequests(ctx context.Context, targetID uuid.UUID) ([]db.ListPendingRequestsRow, error) {
+// ListStoryStickers mocks base method.
+func (m *MockStore) ListStoryStickers(ctx context.Context, storyID uuid.UUID) ([]db.StorySticker, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListPendingRequests", ctx, targetID)
-	ret0, _ := ret[0].([]db.ListPendingRequestsRow)
+	ret := m.ctrl.Call(m, "ListStoryStickers", ctx, storyID)
+	ret0, _ := ret[0].([]db.StorySticker)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListPendingRequests indicates an expected call of ListPendingRequests.
-func (mr *MockStoreMockRecorder) ListPendingRequests(ctx, targetID any) *gomock.Call {
+// ListStoryStickers indicates an expected call of ListStoryStickers.
+func (mr *MockStoreMockRecorder) ListStoryStickers(ctx, storyID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingRequests", reflect.TypeOf((*MockStore)(nil).ListPendingRequests), ctx, targetID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListStoryStickers", reflect.TypeOf((*MockStore)(nil).ListStoryStickers), ctx, storyID)
 }
 
-// ListReports mocks base method.
-func (m *MockStore) ListReports(ctx context.Context, arg db.ListReportsParams) ([]db.ListReportsRow, error) {
+// ListUserMutes mocks base method.
+func (m *MockStore) ListUserMutes(ctx context.Context, muterID uuid.UUID) ([]db.ListUserMutesRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListReports", ctx, arg)
-	ret0, _ := ret[0].([]db.ListReportsRow)
+	ret := m.ctrl.Call(m, "ListUserMutes", ctx, muterID)
+	ret0, _ := ret[0].([]db.ListUserMutesRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListReports indicates an expected call of ListReports.
-func (mr *MockStoreMockRecorder) ListReports(ctx, arg any) *gomock.Call {
+// ListUserMutes indicates an expected call of ListUserMutes.
+func (mr *MockStoreMockRecorder) ListUserMutes(ctx, muterID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReports", reflect.TypeOf((*MockStore)(nil).ListReports), ctx, arg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserMutes", reflect.TypeOf((*MockStore)(nil).ListUserMutes), ctx, muterID)
 }
 
-// ListSentConnectionRequests mocks base method.
-func (m *MockStore) ListSentConnectionRequests(ctx context.Context, requesterID uuid.UUID) ([]db.ListSentConnectionRequestsRow, error) {
+// ListUserReportWeights mocks base method.
+func (m *MockStore) ListUserReportWeights(ctx context.Context, arg db.ListUserReportWeightsParams) ([]db.ListUserReportWeightsRow, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListSentConnectionRequests", ctx, requesterID)
-	ret0, _ := ret[0].([]db.ListSentConnectionRequestsRow)
+	ret := m.ctrl.Call(m, "ListUserReportWeights", ctx, arg)
+	ret0, _ := ret[0].([]db.ListUserReportWeightsRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListSentConnectionRequests indicates an expected call of ListSentConnectionRequests.
-func (mr *MockStoreMockRecorder) ListSentConnectionRequests(ctx, requesterID any) *gomock.Call {
+// ListUserReportWeights indicates an expected call of ListUserReportWeights.
+func (mr *MockStoreMockRecorder) ListUserReportWeights(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSentConnectionRequests", reflect.TypeOf((*MockStore)(nil).ListSentConnectionRequests), ctx, requesterID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserReportWeights", reflect.TypeOf((*MockStore)(nil).ListUserReportWeights), ctx, arg)
 }
 
 // ListUsers mocks base method.
@@ -1345,6 +2894,20 @@ func (mr *MockStoreMockRecorder) MarkConversationRead(ctx, arg any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkConversationRead", reflect.TypeOf((*MockStore)(nil).MarkConversationRead), ctx, arg)
 }
 
+// MarkMeetupCheckinAlerted mocks base method.
+func (m *MockStore) MarkMeetupCheckinAlerted(ctx context.Context, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkMeetupCheckinAlerted", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkMeetupCheckinAlerted indicates an expected call of MarkMeetupCheckinAlerted.
+func (mr *MockStoreMockRecorder) MarkMeetupCheckinAlerted(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkMeetupCheckinAlerted", reflect.TypeOf((*MockStore)(nil).MarkMeetupCheckinAlerted), ctx, id)
+}
+
 // MarkMessageRead mocks base method.
 func (m *MockStore) MarkMessageRead(ctx context.Context, arg db.MarkMessageReadParams) (db.Message, error) {
 	m.ctrl.T.Helper()
@@ -1375,19 +2938,149 @@ func (mr *MockStoreMockRecorder) MarkNotificationAsRead(ctx, arg any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNotificationAsRead", reflect.TypeOf((*MockStore)(nil).MarkNotificationAsRead), ctx, arg)
 }
 
+// PublishDueStories mocks base method.
+func (m *MockStore) PublishDueStories(ctx context.Context, limit int32) ([]db.PublishDueStoriesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishDueStories", ctx, limit)
+	ret0, _ := ret[0].([]db.PublishDueStoriesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PublishDueStories indicates an expected call of PublishDueStories.
+func (mr *MockStoreMockRecorder) PublishDueStories(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishDueStories", reflect.TypeOf((*MockStore)(nil).PublishDueStories), ctx, limit)
+}
+
+// RecordStoryItemView mocks base method.
+func (m *MockStore) RecordStoryItemView(ctx context.Context, arg db.RecordStoryItemViewParams) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordStoryItemView", ctx, arg)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordStoryItemView indicates an expected call of RecordStoryItemView.
+func (mr *MockStoreMockRecorder) RecordStoryItemView(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordStoryItemView", reflect.TypeOf((*MockStore)(nil).RecordStoryItemView), ctx, arg)
+}
+
+// RemoveCloseFriend mocks base method.
+func (m *MockStore) RemoveCloseFriend(ctx context.Context, arg db.RemoveCloseFriendParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveCloseFriend", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveCloseFriend indicates an expected call of RemoveCloseFriend.
+func (mr *MockStoreMockRecorder) RemoveCloseFriend(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveCloseFriend", reflect.TypeOf((*MockStore)(nil).RemoveCloseFriend), ctx, arg)
+}
+
+// RemoveHighlightItem mocks base method.
+func (m *MockStore) RemoveHighlightItem(ctx context.Context, arg db.RemoveHighlightItemParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveHighlightItem", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveHighlightItem indicates an expected call of RemoveHighlightItem.
+func (mr *MockStoreMockRecorder) RemoveHighlightItem(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveHighlightItem", reflect.TypeOf((*MockStore)(nil).RemoveHighlightItem), ctx, arg)
+}
+
+// ReorderHighlightItems mocks base method.
+func (m *MockStore) ReorderHighlightItems(ctx context.Context, arg db.ReorderHighlightItemsParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderHighlightItems", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReorderHighlightItems indicates an expected call of ReorderHighlightItems.
+func (mr *MockStoreMockRecorder) ReorderHighlightItems(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderHighlightItems", reflect.TypeOf((*MockStore)(nil).ReorderHighlightItems), ctx, arg)
+}
+
+// ReorderHighlights mocks base method.
+func (m *MockStore) ReorderHighlights(ctx context.Context, arg db.ReorderHighlightsParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReorderHighlights", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReorderHighlights indicates an expected call of ReorderHighlights.
+func (mr *MockStoreMockRecorder) ReorderHighlights(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReorderHighlights", reflect.TypeOf((*MockStore)(nil).ReorderHighlights), ctx, arg)
+}
+
+// RescheduleStory mocks base method.
+func (m *MockStore) RescheduleStory(ctx context.Context, arg db.RescheduleStoryParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RescheduleStory", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RescheduleStory indicates an expected call of RescheduleStory.
+func (mr *MockStoreMockRecorder) RescheduleStory(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RescheduleStory", reflect.TypeOf((*MockStore)(nil).RescheduleStory), ctx, arg)
+}
+
 // ResolveReport mocks base method.
-func (m *MockStore) ResolveReport(ctx context.Context, id uuid.UUID) (db.Report, error) {
+func (m *MockStore) ResolveReport(ctx context.Context, arg db.ResolveReportParams) (db.Report, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ResolveReport", ctx, id)
+	ret := m.ctrl.Call(m, "ResolveReport", ctx, arg)
 	ret0, _ := ret[0].(db.Report)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ResolveReport indicates an expected call of ResolveReport.
-func (mr *MockStoreMockRecorder) ResolveReport(ctx, id any) *gomock.Call {
+func (mr *MockStoreMockRecorder) ResolveReport(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveReport", reflect.TypeOf((*MockStore)(nil).ResolveReport), ctx, arg)
+}
+
+// RevertReportEscalation mocks base method.
+func (m *MockStore) RevertReportEscalation(ctx context.Context, arg db.RevertReportEscalationParams) (db.ReportEscalation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevertReportEscalation", ctx, arg)
+	ret0, _ := ret[0].(db.ReportEscalation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevertReportEscalation indicates an expected call of RevertReportEscalation.
+func (mr *MockStoreMockRecorder) RevertReportEscalation(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevertReportEscalation", reflect.TypeOf((*MockStore)(nil).RevertReportEscalation), ctx, arg)
+}
+
+// ReviewContentFlag mocks base method.
+func (m *MockStore) ReviewContentFlag(ctx context.Context, arg db.ReviewContentFlagParams) (db.ContentFlag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReviewContentFlag", ctx, arg)
+	ret0, _ := ret[0].(db.ContentFlag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReviewContentFlag indicates an expected call of ReviewContentFlag.
+func (mr *MockStoreMockRecorder) ReviewContentFlag(ctx, arg any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveReport", reflect.TypeOf((*MockStore)(nil).ResolveReport), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReviewContentFlag", reflect.TypeOf((*MockStore)(nil).ReviewContentFlag), ctx, arg)
 }
 
 // SaveMessage mocks base method.
@@ -1405,6 +3098,20 @@ func (mr *MockStoreMockRecorder) SaveMessage(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveMessage", reflect.TypeOf((*MockStore)(nil).SaveMessage), ctx, id)
 }
 
+// ScheduleStory mocks base method.
+func (m *MockStore) ScheduleStory(ctx context.Context, arg db.ScheduleStoryParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScheduleStory", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ScheduleStory indicates an expected call of ScheduleStory.
+func (mr *MockStoreMockRecorder) ScheduleStory(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleStory", reflect.TypeOf((*MockStore)(nil).ScheduleStory), ctx, arg)
+}
+
 // SearchUsers mocks base method.
 func (m *MockStore) SearchUsers(ctx context.Context, query string) ([]db.SearchUsersRow, error) {
 	m.ctrl.T.Helper()
@@ -1420,6 +3127,20 @@ func (mr *MockStoreMockRecorder) SearchUsers(ctx, query any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchUsers", reflect.TypeOf((*MockStore)(nil).SearchUsers), ctx, query)
 }
 
+// SetStoryAudienceRule mocks base method.
+func (m *MockStore) SetStoryAudienceRule(ctx context.Context, arg db.SetStoryAudienceRuleParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStoryAudienceRule", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetStoryAudienceRule indicates an expected call of SetStoryAudienceRule.
+func (mr *MockStoreMockRecorder) SetStoryAudienceRule(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStoryAudienceRule", reflect.TypeOf((*MockStore)(nil).SetStoryAudienceRule), ctx, arg)
+}
+
 // ToggleGhostMode mocks base method.
 func (m *MockStore) ToggleGhostMode(ctx context.Context, arg db.ToggleGhostModeParams) (db.User, error) {
 	m.ctrl.T.Helper()
@@ -1464,6 +3185,35 @@ func (mr *MockStoreMockRecorder) UnblockUser(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnblockUser", reflect.TypeOf((*MockStore)(nil).UnblockUser), ctx, arg)
 }
 
+// UnhideMessage mocks base method.
+func (m *MockStore) UnhideMessage(ctx context.Context, arg db.UnhideMessageParams) (uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnhideMessage", ctx, arg)
+	ret0, _ := ret[0].(uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnhideMessage indicates an expected call of UnhideMessage.
+func (mr *MockStoreMockRecorder) UnhideMessage(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnhideMessage", reflect.TypeOf((*MockStore)(nil).UnhideMessage), ctx, arg)
+}
+
+// UnhideStoriesFrom mocks base method.
+func (m *MockStore) UnhideStoriesFrom(ctx context.Context, arg db.UnhideStoriesFromParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnhideStoriesFrom", ctx, arg)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnhideStoriesFrom indicates an expected call of UnhideStoriesFrom.
+func (mr *MockStoreMockRecorder) UnhideStoriesFrom(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnhideStoriesFrom", reflect.TypeOf((*MockStore)(nil).UnhideStoriesFrom), ctx, arg)
+}
+
 // UpdateConnectionStatus mocks base method.
 func (m *MockStore) UpdateConnectionStatus(ctx context.Context, arg db.UpdateConnectionStatusParams) (db.Connection, error) {
 	m.ctrl.T.Helper()
@@ -1479,6 +3229,21 @@ func (mr *MockStoreMockRecorder) UpdateConnectionStatus(ctx, arg any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateConnectionStatus", reflect.TypeOf((*MockStore)(nil).UpdateConnectionStatus), ctx, arg)
 }
 
+// UpdateHighlight mocks base method.
+func (m *MockStore) UpdateHighlight(ctx context.Context, arg db.UpdateHighlightParams) (db.Highlight, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateHighlight", ctx, arg)
+	ret0, _ := ret[0].(db.Highlight)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateHighlight indicates an expected call of UpdateHighlight.
+func (mr *MockStoreMockRecorder) UpdateHighlight(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateHighlight", reflect.TypeOf((*MockStore)(nil).UpdateHighlight), ctx, arg)
+}
+
 // UpdateMessage mocks base method.
 func (m *MockStore) UpdateMessage(ctx context.Context, arg db.UpdateMessageParams) (db.Message, error) {
 	m.ctrl.T.Helper()
@@ -1494,6 +3259,36 @@ func (mr *MockStoreMockRecorder) UpdateMessage(ctx, arg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMessage", reflect.TypeOf((*MockStore)(nil).UpdateMessage), ctx, arg)
 }
 
+// UpdateReportTriage mocks base method.
+func (m *MockStore) UpdateReportTriage(ctx context.Context, arg db.UpdateReportTriageParams) (db.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateReportTriage", ctx, arg)
+	ret0, _ := ret[0].(db.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateReportTriage indicates an expected call of UpdateReportTriage.
+func (mr *MockStoreMockRecorder) UpdateReportTriage(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateReportTriage", reflect.TypeOf((*MockStore)(nil).UpdateReportTriage), ctx, arg)
+}
+
+// UpdateScheduledStory mocks base method.
+func (m *MockStore) UpdateScheduledStory(ctx context.Context, arg db.UpdateScheduledStoryParams) (db.UpdateScheduledStoryRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateScheduledStory", ctx, arg)
+	ret0, _ := ret[0].(db.UpdateScheduledStoryRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateScheduledStory indicates an expected call of UpdateScheduledStory.
+func (mr *MockStoreMockRecorder) UpdateScheduledStory(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateScheduledStory", reflect.TypeOf((*MockStore)(nil).UpdateScheduledStory), ctx, arg)
+}
+
 // UpdateStory mocks base method.
 func (m *MockStore) UpdateStory(ctx context.Context, arg db.UpdateStoryParams) (db.UpdateStoryRow, error) {
 	m.ctrl.T.Helper()
@@ -1597,3 +3392,18 @@ func (mr *MockStoreMockRecorder) UpsertPrivacySettings(ctx, arg any) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertPrivacySettings", reflect.TypeOf((*MockStore)(nil).UpsertPrivacySettings), ctx, arg)
 }
+
+// UpsertUserMute mocks base method.
+func (m *MockStore) UpsertUserMute(ctx context.Context, arg db.UpsertUserMuteParams) (db.UserMute, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertUserMute", ctx, arg)
+	ret0, _ := ret[0].(db.UserMute)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertUserMute indicates an expected call of UpsertUserMute.
+func (mr *MockStoreMockRecorder) UpsertUserMute(ctx, arg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertUserMute", reflect.TypeOf((*MockStore)(nil).UpsertUserMute), ctx, arg)
+}
@@ -0,0 +1,142 @@
+// Package feedcache is the shared layer of the nearby feed cache. For each
+// geohash cell it keeps the IDs of the stories around the cell that some
+// viewer may see; nothing cached here depends on who is looking. Blocks,
+// audiences, privacy settings and mutes are applied per viewer on every
+// request.
+package feedcache
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mmcloughlin/geohash"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// Precision is the cell size: 5 chars is about 4.9km x 4.9km at the equator
+	Precision = 5
+
+	// MaxSearchRadiusMeters is the furthest the feed looks from the viewer
+	MaxSearchRadiusMeters = 25000
+
+	// CandidateRadiusMeters is searched from the cell's centre, so it adds
+	// the centre-to-corner distance of a cell to cover any viewer in it
+	CandidateRadiusMeters = MaxSearchRadiusMeters + 5000
+
+	// BandMeters is the step the feed widens its search by. Candidates are
+	// kept nearest band first, newest first within a band.
+	BandMeters = 5000
+
+	// MaxCandidates caps a cell's candidates. The viewer's own, connections'
+	// and close friends' stories are added per viewer on top.
+	MaxCandidates = 1000
+
+	TTL = 5 * time.Minute
+
+	keyPrefix     = "feed:candidates:"
+	earthRadiusM  = 6371000.0
+	metersPerDeg  = earthRadiusM * math.Pi / 180
+	minCosLatSpan = 0.01
+	// Haversine is up to ~0.5% off the spheroid PostGIS uses; err on the side of invalidating
+	sphereSlack = 1.01
+)
+
+// Candidates is the cached entry for one cell
+type Candidates struct {
+	StoryIDs []uuid.UUID `json:"story_ids"`
+}
+
+// Cell returns the cell a point is in
+func Cell(lat, lng float64) string {
+	return geohash.EncodeWithPrecision(lat, lng, Precision)
+}
+
+// Centre returns the point a cell's candidates are searched from
+func Centre(cell string) (lat, lng float64) {
+	return geohash.DecodeCenter(cell)
+}
+
+func Key(cell string) string {
+	return keyPrefix + cell
+}
+
+// Get returns the cached candidates for a cell. ok is false on a miss.
+func Get(ctx context.Context, rdb *redis.Client, cell string) (Candidates, bool) {
+	var c Candidates
+	data, err := rdb.Get(ctx, Key(cell)).Bytes()
+	if err != nil || json.Unmarshal(data, &c) != nil {
+		return Candidates{}, false
+	}
+	return c, true
+}
+
+func Set(ctx context.Context, rdb *redis.Client, cell string, c Candidates) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return
+	}
+	rdb.Set(ctx, Key(cell), data, TTL)
+}
+
+// Invalidate drops the candidates of every cell whose search area covers a
+// story, for when it's created, changed or removed
+func Invalidate(ctx context.Context, rdb *redis.Client, storyGeohash string) {
+	if storyGeohash == "" {
+		return
+	}
+	lat, lng := geohash.DecodeCenter(storyGeohash)
+	cells := CellsCovering(lat, lng)
+	keys := make([]string, len(cells))
+	for i, cell := range cells {
+		keys[i] = Key(cell)
+	}
+	rdb.Del(ctx, keys...)
+}
+
+// CellsCovering returns the cells whose candidate search reaches the point
+func CellsCovering(lat, lng float64) []string {
+	box := geohash.BoundingBox(Cell(lat, lng))
+	// Half a cell per step so no column or row of cells is skipped
+	stepLat := (box.MaxLat - box.MinLat) / 2
+	stepLng := (box.MaxLng - box.MinLng) / 2
+
+	spanLat := CandidateRadiusMeters/metersPerDeg + stepLat*2
+	spanLng := 180.0
+	if cos := math.Cos(lat * math.Pi / 180); cos > minCosLatSpan {
+		spanLng = math.Min(spanLng, CandidateRadiusMeters/(metersPerDeg*cos)+stepLng*2)
+	}
+
+	seen := make(map[string]bool)
+	var cells []string
+	for la := math.Max(lat-spanLat, -90); la <= math.Min(lat+spanLat, 90); la += stepLat {
+		for ln := lng - spanLng; ln <= lng+spanLng; ln += stepLng {
+			cell := Cell(la, wrapLng(ln))
+			if seen[cell] {
+				continue
+			}
+			seen[cell] = true
+			cLat, cLng := Centre(cell)
+			if distanceMeters(lat, lng, cLat, cLng) <= CandidateRadiusMeters*sphereSlack {
+				cells = append(cells, cell)
+			}
+		}
+	}
+	return cells
+}
+
+func wrapLng(lng float64) float64 {
+	return math.Mod(math.Mod(lng+180, 360)+360, 360) - 180
+}
+
+func distanceMeters(lat1, lng1, lat2, lng2 float64) float64 {
+	toRad := math.Pi / 180
+	dLat := (lat2 - lat1) * toRad
+	dLng := (lng2 - lng1) * toRad
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1*toRad)*math.Cos(lat2*toRad)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusM * math.Asin(math.Sqrt(a))
+}
@@ -0,0 +1,47 @@
+package feedcache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Every cell whose candidate search reaches a story must be invalidated
+// with it, or that cell keeps serving a stale candidate list
+func TestCellsCovering(t *testing.T) {
+	points := []struct {
+		name     string
+		lat, lng float64
+	}{
+		{name: "London", lat: 51.5074, lng: -0.1278},
+		{name: "Equator", lat: 0.01, lng: 10.01},
+		{name: "Antimeridian", lat: -16.5, lng: 179.95},
+		{name: "North", lat: 69.65, lng: 18.96},
+	}
+
+	for _, p := range points {
+		t.Run(p.name, func(t *testing.T) {
+			cells := CellsCovering(p.lat, p.lng)
+			got := make(map[string]bool, len(cells))
+			for _, cell := range cells {
+				got[cell] = true
+			}
+			require.True(t, got[Cell(p.lat, p.lng)])
+
+			// Brute force over a fine grid around the point
+			for dLat := -1.0; dLat <= 1.0; dLat += 0.01 {
+				for dLng := -2.0; dLng <= 2.0; dLng += 0.01 {
+					cell := Cell(p.lat+dLat, wrapLng(p.lng+dLng))
+					cLat, cLng := Centre(cell)
+					d := distanceMeters(p.lat, p.lng, cLat, cLng)
+					if d <= CandidateRadiusMeters {
+						require.True(t, got[cell], "cell %s at %.0fm not covered", cell, d)
+					}
+					if d > 2*CandidateRadiusMeters {
+						require.False(t, got[cell], "cell %s at %.0fm covered", cell, d)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package feedrank
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor marks the last story of a page. AsOf is the time the first page was
+// ranked at; later pages rank with it too, on signals as they were then
+// (freshness, engagement, stories posted since), so scores don't shift
+// between pages and stories don't repeat or get skipped.
+type Cursor struct {
+	AsOf    time.Time `json:"t"`
+	Score   float64   `json:"s"`
+	StoryID uuid.UUID `json:"id"`
+}
+
+// Encode returns the cursor as an opaque URL-safe string
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeCursor(s string) (Cursor, error) {
+	var c Cursor
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, ErrInvalidCursor
+	}
+	if err := json.Unmarshal(data, &c); err != nil || c.AsOf.IsZero() {
+		return Cursor{}, ErrInvalidCursor
+	}
+	return c, nil
+}
+
+// Page returns up to limit stories of a ranked feed after the cursor (from
+// the start when nil), and the cursor for the next page (nil on the last one)
+func Page(ranked []Ranked, asOf time.Time, after *Cursor, limit int) ([]Ranked, *Cursor) {
+	start := 0
+	if after != nil {
+		start = sort.Search(len(ranked), func(i int) bool {
+			return before(after.Score, after.StoryID, ranked[i].Score, ranked[i].StoryID)
+		})
+	}
+
+	end := start + limit
+	if end >= len(ranked) {
+		return ranked[start:], nil
+	}
+	last := ranked[end-1]
+	return ranked[start:end], &Cursor{AsOf: asOf, Score: last.Score, StoryID: last.StoryID}
+}
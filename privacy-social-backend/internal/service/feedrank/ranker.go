@@ -0,0 +1,163 @@
+// Package feedrank orders the nearby feed for one viewer. A FeedRanker scores
+// each candidate story; Rank sorts by score and Page cuts the sorted feed into
+// pages with opaque cursors.
+package feedrank
+
+import (
+	"bytes"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Candidate is a story the viewer may see, with the signals used to rank it
+type Candidate struct {
+	StoryID            uuid.UUID `json:"story_id"`
+	AuthorID           uuid.UUID `json:"-"` // Not shown in explanations: stories may be anonymous
+	CreatedAt          time.Time `json:"created_at"`
+	DistanceMeters     float64   `json:"distance_meters"`
+	IsConnection       bool      `json:"is_connection"`
+	ViewerInteractions int64     `json:"viewer_interactions"` // Viewer's views and reactions on the author's other stories
+	ViewCount          int64     `json:"view_count"`
+	ReactionCount      int64     `json:"reaction_count"`
+	Boosted            bool      `json:"boosted"`
+	Premium            bool      `json:"premium"`
+}
+
+// Explanation is a score broken into its weighted parts. The parts add up to the score.
+type Explanation struct {
+	Distance   float64 `json:"distance"`
+	Freshness  float64 `json:"freshness"`
+	Affinity   float64 `json:"affinity"`
+	Engagement float64 `json:"engagement"`
+	Boost      float64 `json:"boost"`
+}
+
+func (e Explanation) Total() float64 {
+	return e.Distance + e.Freshness + e.Affinity + e.Engagement + e.Boost
+}
+
+// FeedRanker scores a candidate as of the given time. Scores must depend only
+// on the candidate and now, so that pages ranked with the same now line up.
+type FeedRanker interface {
+	Score(now time.Time, c Candidate) Explanation
+}
+
+// Ranked is a scored candidate
+type Ranked struct {
+	Candidate
+	Score       float64     `json:"score"`
+	Explanation Explanation `json:"explanation"`
+}
+
+// Rank scores candidates and sorts them best first. Ties are broken by story
+// ID so the order is total and pages never overlap.
+func Rank(ranker FeedRanker, now time.Time, candidates []Candidate) []Ranked {
+	ranked := make([]Ranked, len(candidates))
+	for i, c := range candidates {
+		explanation := ranker.Score(now, c)
+		ranked[i] = Ranked{Candidate: c, Score: explanation.Total(), Explanation: explanation}
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		return before(ranked[i].Score, ranked[i].StoryID, ranked[j].Score, ranked[j].StoryID)
+	})
+	return ranked
+}
+
+// before reports whether (scoreA, idA) sorts ahead of (scoreB, idB)
+func before(scoreA float64, idA uuid.UUID, scoreB float64, idB uuid.UUID) bool {
+	if scoreA != scoreB {
+		return scoreA > scoreB
+	}
+	return bytes.Compare(idA[:], idB[:]) < 0
+}
+
+// Weights tune the default ranker. Each signal is first scaled to 0-1, then
+// multiplied by its weight.
+type Weights struct {
+	Distance   float64
+	Freshness  float64
+	Affinity   float64
+	Engagement float64
+	Boost      float64
+
+	DistanceHalfLifeMeters float64       // Distance at which the distance signal halves
+	FreshnessHalfLife      time.Duration // Age at which the freshness signal halves
+}
+
+var DefaultWeights = Weights{
+	Distance:   0.30,
+	Freshness:  0.30,
+	Affinity:   0.20,
+	Engagement: 0.10,
+	Boost:      0.10,
+
+	DistanceHalfLifeMeters: 5000,
+	FreshnessHalfLife:      6 * time.Hour,
+}
+
+// Saturation points: the signal is at half strength here
+const (
+	interactionsHalf = 10.0
+	engagementHalf   = 50.0
+	reactionWeight   = 3 // A reaction counts as much as this many views
+	premiumBoost     = 0.3
+)
+
+// Blended is the default ranker: distance decay, freshness, connection
+// affinity, past engagement and boost, blended linearly
+type Blended struct {
+	weights Weights
+}
+
+func NewBlended(weights Weights) *Blended {
+	return &Blended{weights: weights}
+}
+
+func (b *Blended) Score(now time.Time, c Candidate) Explanation {
+	w := b.weights
+
+	age := now.Sub(c.CreatedAt)
+	if age < 0 {
+		age = 0
+	}
+
+	affinity := 0.5 * saturate(float64(c.ViewerInteractions), interactionsHalf)
+	if c.IsConnection {
+		affinity += 0.5
+	}
+
+	boost := 0.0
+	switch {
+	case c.Boosted:
+		boost = 1
+	case c.Premium:
+		boost = premiumBoost
+	}
+
+	return Explanation{
+		Distance:   w.Distance * decay(c.DistanceMeters, w.DistanceHalfLifeMeters),
+		Freshness:  w.Freshness * decay(age.Seconds(), w.FreshnessHalfLife.Seconds()),
+		Affinity:   w.Affinity * affinity,
+		Engagement: w.Engagement * saturate(float64(c.ViewCount+reactionWeight*c.ReactionCount), engagementHalf),
+		Boost:      w.Boost * boost,
+	}
+}
+
+// decay halves every halfLife
+func decay(x, halfLife float64) float64 {
+	if halfLife <= 0 {
+		return 0
+	}
+	return math.Exp2(-math.Max(x, 0) / halfLife)
+}
+
+// saturate maps 0..inf to 0..1, reaching 0.5 at half
+func saturate(x, half float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	return x / (x + half)
+}
@@ -0,0 +1,91 @@
+package feedrank
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlendedScore(t *testing.T) {
+	now := time.Now()
+	ranker := NewBlended(DefaultWeights)
+	base := Candidate{CreatedAt: now.Add(-time.Hour), DistanceMeters: 2000}
+
+	testCases := []struct {
+		name   string
+		modify func(c *Candidate)
+	}{
+		{name: "Closer", modify: func(c *Candidate) { c.DistanceMeters = 200 }},
+		{name: "Fresher", modify: func(c *Candidate) { c.CreatedAt = now.Add(-time.Minute) }},
+		{name: "Connection", modify: func(c *Candidate) { c.IsConnection = true }},
+		{name: "ViewerInteractions", modify: func(c *Candidate) { c.ViewerInteractions = 5 }},
+		{name: "Engagement", modify: func(c *Candidate) { c.ReactionCount = 10 }},
+		{name: "Boosted", modify: func(c *Candidate) { c.Boosted = true }},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			better := base
+			tc.modify(&better)
+			require.Greater(t, ranker.Score(now, better).Total(), ranker.Score(now, base).Total())
+		})
+	}
+
+	e := ranker.Score(now, Candidate{CreatedAt: now, Boosted: true, IsConnection: true})
+	require.InDelta(t, DefaultWeights.Distance, e.Distance, 1e-9)
+	require.InDelta(t, DefaultWeights.Freshness, e.Freshness, 1e-9)
+	require.InDelta(t, DefaultWeights.Boost, e.Boost, 1e-9)
+	require.Zero(t, e.Engagement)
+}
+
+func TestPage(t *testing.T) {
+	now := time.Now()
+	ranker := NewBlended(DefaultWeights)
+
+	// Pairs of identical candidates force score ties
+	var candidates []Candidate
+	for i := 0; i < 15; i++ {
+		c := Candidate{StoryID: uuid.New(), CreatedAt: now.Add(-time.Duration(i/2) * time.Hour), DistanceMeters: 1000}
+		candidates = append(candidates, c)
+	}
+	ranked := Rank(ranker, now, candidates)
+
+	var seen []uuid.UUID
+	var cursor *Cursor
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 10)
+
+		// Each page re-ranks from scratch, as the handler does
+		var asOf time.Time
+		if cursor != nil {
+			decoded, err := DecodeCursor(cursor.Encode())
+			require.NoError(t, err)
+			cursor = &decoded
+			asOf = decoded.AsOf
+		} else {
+			asOf = now
+		}
+		page, next := Page(Rank(ranker, asOf, candidates), asOf, cursor, 4)
+		for _, r := range page {
+			seen = append(seen, r.StoryID)
+		}
+		if next == nil {
+			break
+		}
+		cursor = next
+	}
+
+	require.Len(t, seen, len(ranked))
+	for i, r := range ranked {
+		require.Equal(t, r.StoryID, seen[i])
+	}
+}
+
+func TestDecodeCursorInvalid(t *testing.T) {
+	for _, s := range []string{"", "!!", "bm90IGpzb24", Cursor{}.Encode()} {
+		_, err := DecodeCursor(s)
+		require.ErrorIs(t, err, ErrInvalidCursor, s)
+	}
+}
@@ -0,0 +1,168 @@
+// Package geocode turns coordinates into coarse place labels ("Shoreditch,
+// London", "Lyon") without calling out to a service. Places are loaded from a
+// GeoNames-style dump into an in-memory grid index. Only populated places are
+// kept, so labels never get finer than a neighbourhood.
+package geocode
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	// Neighbourhoods are only used when the point is close to one
+	neighbourhoodRadiusKm = 2.0
+	// Beyond this there's no city worth naming
+	cityRadiusKm = 25.0
+
+	cellDegrees   = 0.5
+	lngCells      = int(360 / cellDegrees)
+	earthRadiusKm = 6371.0
+)
+
+// Place is a populated place from the dataset
+type Place struct {
+	Name          string
+	Lat, Lng      float64
+	Neighbourhood bool // GeoNames feature code PPLX: section of a populated place
+}
+
+type cell struct {
+	lat, lng int
+}
+
+// Geocoder is a read-only spatial index of places. A nil Geocoder labels nothing.
+type Geocoder struct {
+	cells map[cell][]Place
+}
+
+// Load reads a GeoNames dump (e.g. cities500.txt) from a file
+func Load(path string) (*Geocoder, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Parse(f)
+}
+
+// Parse reads tab-separated GeoNames rows. Columns used: 1 name, 4 latitude,
+// 5 longitude, 6 feature class and 7 feature code. Rows that aren't
+// populated places (class P) are skipped.
+func Parse(r io.Reader) (*Geocoder, error) {
+	g := &Geocoder{cells: make(map[cell][]Place)}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024) // alternatenames can be long
+	line := 0
+	for scanner.Scan() {
+		line++
+		cols := strings.Split(scanner.Text(), "\t")
+		if len(cols) < 8 || cols[6] != "P" {
+			continue
+		}
+		lat, err := strconv.ParseFloat(cols[4], 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: latitude: %w", line, err)
+		}
+		lng, err := strconv.ParseFloat(cols[5], 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: longitude: %w", line, err)
+		}
+		g.add(Place{
+			Name:          cols[1],
+			Lat:           lat,
+			Lng:           lng,
+			Neighbourhood: cols[7] == "PPLX",
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+func (g *Geocoder) add(p Place) {
+	c := cellOf(p.Lat, p.Lng)
+	g.cells[c] = append(g.cells[c], p)
+}
+
+// Label returns "Neighbourhood, City", "City" or "" when nothing is nearby
+func (g *Geocoder) Label(lat, lng float64) string {
+	if g == nil {
+		return ""
+	}
+
+	city, ok := g.nearest(lat, lng, cityRadiusKm, false)
+	hood, hoodOK := g.nearest(lat, lng, neighbourhoodRadiusKm, true)
+
+	switch {
+	case hoodOK && ok && hood.Name != city.Name:
+		return hood.Name + ", " + city.Name
+	case hoodOK:
+		return hood.Name
+	case ok:
+		return city.Name
+	}
+	return ""
+}
+
+// nearest finds the closest place of the given kind within radiusKm
+func (g *Geocoder) nearest(lat, lng, radiusKm float64, neighbourhood bool) (Place, bool) {
+	var best Place
+	bestKm := math.Inf(1)
+
+	// Cells to scan: longitude degrees shrink towards the poles
+	dLat := radiusKm / 111.0
+	dLng := 180.0
+	if cos := math.Cos(lat * math.Pi / 180); cos > 0.01 {
+		dLng = math.Min(dLng, radiusKm/(111.0*cos))
+	}
+	minLat, maxLat := cellIndex(lat-dLat), cellIndex(lat+dLat)
+	minLng, maxLng := cellIndex(lng-dLng), cellIndex(lng+dLng)
+	if maxLng-minLng >= lngCells {
+		maxLng = minLng + lngCells - 1
+	}
+
+	for cl := minLat; cl <= maxLat; cl++ {
+		for cg := minLng; cg <= maxLng; cg++ {
+			for _, p := range g.cells[cell{cl, wrapCell(cg)}] {
+				if p.Neighbourhood != neighbourhood {
+					continue
+				}
+				km := distanceKm(lat, lng, p.Lat, p.Lng)
+				if km <= radiusKm && km < bestKm {
+					best, bestKm = p, km
+				}
+			}
+		}
+	}
+	return best, !math.IsInf(bestKm, 1)
+}
+
+func cellIndex(deg float64) int {
+	return int(math.Floor(deg / cellDegrees))
+}
+
+func cellOf(lat, lng float64) cell {
+	return cell{lat: cellIndex(lat), lng: wrapCell(cellIndex(lng))}
+}
+
+// wrapCell keeps longitude cells in range across the antimeridian
+func wrapCell(c int) int {
+	return ((c+lngCells/2)%lngCells+lngCells)%lngCells - lngCells/2
+}
+
+func distanceKm(lat1, lng1, lat2, lng2 float64) float64 {
+	toRad := math.Pi / 180
+	dLat := (lat2 - lat1) * toRad
+	dLng := (lng2 - lng1) * toRad
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1*toRad)*math.Cos(lat2*toRad)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(a))
+}
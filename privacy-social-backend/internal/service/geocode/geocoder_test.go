@@ -0,0 +1,46 @@
+package geocode
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// GeoNames rows: id, name, asciiname, alternatenames, lat, lng, class, code, ...
+const testDataset = "2643743\tLondon\tLondon\t\t51.50853\t-0.12574\tP\tPPLC\tGB\n" +
+	"2638077\tShoreditch\tShoreditch\t\t51.52599\t-0.07817\tP\tPPLX\tGB\n" +
+	"2996944\tLyon\tLyon\t\t45.74846\t4.84671\tP\tPPLA\tFR\n" +
+	"2198148\tWaiyevo\tWaiyevo\t\t-16.8\t179.95\tP\tPPL\tFJ\n" +
+	"6295630\tEarth\tEarth\t\t0\t0\tL\tAREA\t\n"
+
+func TestLabel(t *testing.T) {
+	g, err := Parse(strings.NewReader(testDataset))
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		lat, lng float64
+		label    string
+	}{
+		{"neighbourhood", 51.5265, -0.0800, "Shoreditch, London"},
+		{"city only", 51.5000, -0.1400, "London"},
+		{"other city", 45.7600, 4.8350, "Lyon"},
+		{"across the antimeridian", -16.8000, -179.9500, "Waiyevo"},
+		{"nowhere", 0.1, 0.1, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.label, g.Label(tc.lat, tc.lng))
+		})
+	}
+
+	var none *Geocoder
+	require.Empty(t, none.Label(51.5, -0.12))
+}
+
+func TestParseInvalid(t *testing.T) {
+	_, err := Parse(strings.NewReader("1\tX\tX\t\tnorth\t0\tP\tPPL\tGB\n"))
+	require.Error(t, err)
+}
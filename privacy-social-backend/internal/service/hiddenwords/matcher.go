@@ -0,0 +1,72 @@
+// Package hiddenwords matches text against a user's hidden words and phrases.
+package hiddenwords
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Wildcard matches any run of letters or digits inside a word, e.g. "idiot*"
+const Wildcard = "*"
+
+// Matcher reports whether text contains any of a user's hidden phrases.
+// Phrases match whole words, case-insensitively; a nil Matcher matches nothing.
+type Matcher struct {
+	re *regexp.Regexp
+}
+
+// NewMatcher compiles the phrases, returning nil if there are none
+func NewMatcher(phrases []string) *Matcher {
+	var parts []string
+	for _, phrase := range phrases {
+		if p := pattern(phrase); p != "" {
+			parts = append(parts, p)
+		}
+	}
+	if len(parts) == 0 {
+		return nil
+	}
+	// Every part is quoted, so the expression always compiles
+	return &Matcher{re: regexp.MustCompile("(?i)" + strings.Join(parts, "|"))}
+}
+
+// Match reports whether text contains a hidden phrase
+func (m *Matcher) Match(text string) bool {
+	if m == nil || text == "" {
+		return false
+	}
+	return m.re.MatchString(text)
+}
+
+// pattern turns a phrase into a whole-word expression: words are separated
+// by any whitespace and each wildcard stands for zero or more word characters
+func pattern(phrase string) string {
+	if strings.TrimSpace(strings.ReplaceAll(phrase, Wildcard, "")) == "" {
+		// Empty, or only wildcards that would hide everything
+		return ""
+	}
+
+	words := strings.Fields(phrase)
+	for i, word := range words {
+		pieces := strings.Split(word, Wildcard)
+		for j, piece := range pieces {
+			pieces[j] = regexp.QuoteMeta(piece)
+		}
+		words[i] = strings.Join(pieces, `\w*`)
+	}
+	expr := strings.Join(words, `\s+`)
+
+	// Word boundaries only make sense next to word characters ("@user" has none before it)
+	first, last := words[0][0], words[len(words)-1][len(words[len(words)-1])-1]
+	if isWordByte(first) || strings.HasPrefix(words[0], `\w*`) {
+		expr = `\b` + expr
+	}
+	if isWordByte(last) || strings.HasSuffix(words[len(words)-1], `\w*`) {
+		expr += `\b`
+	}
+	return expr
+}
+
+func isWordByte(b byte) bool {
+	return b == '_' || ('0' <= b && b <= '9') || ('a' <= b && b <= 'z') || ('A' <= b && b <= 'Z')
+}
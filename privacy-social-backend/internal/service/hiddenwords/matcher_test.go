@@ -0,0 +1,38 @@
+package hiddenwords
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatcher(t *testing.T) {
+	m := NewMatcher([]string{"loser", "idiot*", "go  away", "@troll", "*"})
+
+	testCases := []struct {
+		text  string
+		match bool
+	}{
+		{"see you at the park", false},
+		{"you LOSER", true},
+		{"losers are fine", false},
+		{"what an idiot", true},
+		{"such idiots", true},
+		{"just GO\naway", true},
+		{"gone away", false},
+		{"cc @troll please", true},
+		{"(idiotic)", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.text, func(t *testing.T) {
+			require.Equal(t, tc.match, m.Match(tc.text))
+		})
+	}
+}
+
+func TestMatcherEmpty(t *testing.T) {
+	m := NewMatcher([]string{"", " * "})
+	require.Nil(t, m)
+	require.False(t, m.Match("anything"))
+}
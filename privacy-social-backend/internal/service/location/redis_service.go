@@ -30,6 +30,9 @@ const (
 
 	// Radius for "crossing paths" (approx 76m to match Geohash precision)
 	crossingRadiusMeters = 80.0
+
+	// Restriction kind that suspends crossing detection (e.g. repeated fake GPS)
+	crossingRestrictionKind = "crossings"
 )
 
 type RedisLocationService struct {
@@ -197,6 +200,20 @@ func (s *RedisLocationService) validateCrossingPrivacy(ctx context.Context, u1,
 		return false, nil
 	}
 
+	// Check crossing restrictions
+	for _, uid := range []uuid.UUID{u1, u2} {
+		restricted, err := s.store.HasActiveRestriction(ctx, db.HasActiveRestrictionParams{
+			UserID: uid,
+			Kind:   crossingRestrictionKind,
+		})
+		if err != nil {
+			return false, err
+		}
+		if restricted {
+			return false, nil
+		}
+	}
+
 	return true, nil
 }
 
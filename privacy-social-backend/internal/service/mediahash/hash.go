@@ -0,0 +1,137 @@
+// Package mediahash computes perceptual hashes of images so that
+// re-encoded or resized copies of a known image can be recognised.
+package mediahash
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"io"
+	"math"
+	"math/bits"
+	"sort"
+
+	// Register decoders for image.Decode
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+)
+
+// Hashes holds both perceptual hashes of an image
+type Hashes struct {
+	DHash uint64
+	PHash uint64
+}
+
+// MaxPixels caps width×height of images that will be decoded, so a small
+// compressed file can't expand into gigabytes of pixels
+const MaxPixels = 50_000_000
+
+// ErrTooLarge is returned for images whose dimensions exceed MaxPixels
+var ErrTooLarge = errors.New("image dimensions too large")
+
+// Compute decodes an image and returns its hashes.
+// Non-image input returns image.ErrFormat; oversized images ErrTooLarge.
+func Compute(r io.Reader) (Hashes, error) {
+	// Check the header before decoding any pixels
+	var header bytes.Buffer
+	cfg, _, err := image.DecodeConfig(io.TeeReader(r, &header))
+	if err != nil {
+		return Hashes{}, err
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || int64(cfg.Width)*int64(cfg.Height) > MaxPixels {
+		return Hashes{}, ErrTooLarge
+	}
+
+	img, _, err := image.Decode(io.MultiReader(&header, r))
+	if err != nil {
+		return Hashes{}, err
+	}
+	return Hashes{DHash: DHash(img), PHash: PHash(img)}, nil
+}
+
+// Distance is the Hamming distance between two hashes
+func Distance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// Matches reports whether either hash pair is within maxDistance
+func (h Hashes) Matches(other Hashes, maxDistance int) bool {
+	return Distance(h.DHash, other.DHash) <= maxDistance || Distance(h.PHash, other.PHash) <= maxDistance
+}
+
+// DHash is the difference hash: 1 bit per horizontal gradient on a 9x8 thumbnail
+func DHash(img image.Image) uint64 {
+	px := grayscale(img, 9, 8)
+
+	var hash uint64
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			hash <<= 1
+			if px[y*9+x] < px[y*9+x+1] {
+				hash |= 1
+			}
+		}
+	}
+	return hash
+}
+
+// PHash is the DCT hash: low frequencies of a 32x32 thumbnail compared to their median
+func PHash(img image.Image) uint64 {
+	const size, low = 32, 8
+	px := grayscale(img, size, size)
+
+	// 2D DCT-II, only the low x low block is needed
+	coeffs := make([]float64, 0, low*low)
+	for v := 0; v < low; v++ {
+		for u := 0; u < low; u++ {
+			var sum float64
+			for y := 0; y < size; y++ {
+				cy := math.Cos(float64(2*y+1) * float64(v) * math.Pi / (2 * size))
+				for x := 0; x < size; x++ {
+					sum += px[y*size+x] * cy * math.Cos(float64(2*x+1)*float64(u)*math.Pi/(2*size))
+				}
+			}
+			coeffs = append(coeffs, sum)
+		}
+	}
+
+	// Median without the DC term, which only reflects overall brightness
+	sorted := append([]float64(nil), coeffs[1:]...)
+	sort.Float64s(sorted)
+	median := sorted[len(sorted)/2]
+
+	var hash uint64
+	for _, c := range coeffs {
+		hash <<= 1
+		if c > median {
+			hash |= 1
+		}
+	}
+	return hash
+}
+
+// grayscale downsamples img to w x h luminance values by area averaging
+func grayscale(img image.Image, w, h int) []float64 {
+	b := img.Bounds()
+	out := make([]float64, w*h)
+	counts := make([]int, w*h)
+
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		ty := (y - b.Min.Y) * h / b.Dy()
+		for x := b.Min.X; x < b.Max.X; x++ {
+			tx := (x - b.Min.X) * w / b.Dx()
+			r, g, bl, _ := img.At(x, y).RGBA()
+			lum := 0.299*float64(r>>8) + 0.587*float64(g>>8) + 0.114*float64(bl>>8)
+			out[ty*w+tx] += lum
+			counts[ty*w+tx]++
+		}
+	}
+
+	for i := range out {
+		if counts[i] > 0 {
+			out[i] /= float64(counts[i])
+		}
+	}
+	return out
+}
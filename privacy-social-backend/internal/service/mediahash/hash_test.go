@@ -0,0 +1,94 @@
+package mediahash
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// pattern draws a deterministic test image; seed changes the layout
+func pattern(w, h, seed int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			v := uint8((x*seed/3 + y*7/seed + (x*y)%(13*seed)) % 256)
+			if (x/(w/4)+y/(h/4))%2 == seed%2 {
+				v = 255 - v
+			}
+			img.Set(x, y, color.RGBA{R: v, G: v / 2, B: 255 - v, A: 255})
+		}
+	}
+	return img
+}
+
+// shrink halves an image by nearest-neighbour sampling
+func shrink(src *image.RGBA) *image.RGBA {
+	b := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, b.Dx()/2, b.Dy()/2))
+	for y := 0; y < b.Dy()/2; y++ {
+		for x := 0; x < b.Dx()/2; x++ {
+			dst.Set(x, y, src.At(x*2, y*2))
+		}
+	}
+	return dst
+}
+
+func encode(t *testing.T, img image.Image, asJPEG bool) []byte {
+	var buf bytes.Buffer
+	if asJPEG {
+		require.NoError(t, jpeg.Encode(&buf, img, &jpeg.Options{Quality: 60}))
+	} else {
+		require.NoError(t, png.Encode(&buf, img))
+	}
+	return buf.Bytes()
+}
+
+func TestHashesSurviveReencoding(t *testing.T) {
+	img := pattern(400, 300, 3)
+	original, err := Compute(bytes.NewReader(encode(t, img, false)))
+	require.NoError(t, err)
+
+	// Same picture, smaller and lossy
+	reencoded, err := Compute(bytes.NewReader(encode(t, shrink(img), true)))
+	require.NoError(t, err)
+	require.True(t, original.Matches(reencoded, 10))
+
+	different, err := Compute(bytes.NewReader(encode(t, pattern(400, 300, 8), false)))
+	require.NoError(t, err)
+	require.False(t, original.Matches(different, 10))
+}
+
+func TestComputeRejectsNonImages(t *testing.T) {
+	_, err := Compute(bytes.NewReader([]byte("not an image")))
+	require.ErrorIs(t, err, image.ErrFormat)
+}
+
+// pngHeader is a valid PNG signature and IHDR chunk with no pixel data
+func pngHeader(w, h uint32) []byte {
+	ihdr := make([]byte, 17)
+	copy(ihdr, "IHDR")
+	binary.BigEndian.PutUint32(ihdr[4:], w)
+	binary.BigEndian.PutUint32(ihdr[8:], h)
+	ihdr[12] = 8 // bit depth
+	ihdr[13] = 2 // truecolour
+
+	var buf bytes.Buffer
+	buf.WriteString("\x89PNG\r\n\x1a\n")
+	binary.Write(&buf, binary.BigEndian, uint32(13))
+	buf.Write(ihdr)
+	binary.Write(&buf, binary.BigEndian, crc32.ChecksumIEEE(ihdr))
+	return buf.Bytes()
+}
+
+func TestComputeRejectsOversizedImages(t *testing.T) {
+	// 100MP claimed in a header of a few dozen bytes
+	_, err := Compute(bytes.NewReader(pngHeader(10000, 10000)))
+	require.ErrorIs(t, err, ErrTooLarge)
+}
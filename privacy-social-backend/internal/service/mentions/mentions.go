@@ -0,0 +1,85 @@
+// Package mentions records @username mentions in story captions and notifies
+// the mentioned users. Stories scheduled for later are mentioned when the
+// publish worker makes them live, so it is shared with the API.
+package mentions
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"privacy-social-backend/internal/repository"
+	"privacy-social-backend/internal/repository/db"
+	"privacy-social-backend/internal/service/hiddenwords"
+)
+
+var mentionPattern = regexp.MustCompile(`@(\w+)`)
+
+// Parse extracts the distinct @username mentions from text, lowercased
+func Parse(text string) []string {
+	matches := mentionPattern.FindAllStringSubmatch(text, -1)
+
+	mentions := make([]string, 0)
+	seen := make(map[string]bool)
+
+	for _, match := range matches {
+		if len(match) > 1 {
+			username := strings.ToLower(match[1])
+			if !seen[username] {
+				mentions = append(mentions, username)
+				seen[username] = true
+			}
+		}
+	}
+
+	return mentions
+}
+
+// NotifyStory creates mention records for a story's caption and notifies the
+// mentioned users. Users outside the story's audience are skipped; users whose
+// hidden words match the caption get the mention without a notification.
+func NotifyStory(ctx context.Context, store repository.Store, storyID uuid.UUID, caption string) {
+	for _, username := range Parse(caption) {
+		user, err := store.GetUserByUsername(ctx, username)
+		if err != nil {
+			continue // Skip if user not found
+		}
+
+		canView, err := store.CanViewStory(ctx, db.CanViewStoryParams{
+			StoryID:  storyID,
+			ViewerID: user.ID,
+		})
+		if err != nil || !canView {
+			continue
+		}
+
+		_, err = store.CreateStoryMention(ctx, db.CreateStoryMentionParams{
+			StoryID:         storyID,
+			MentionedUserID: user.ID,
+		})
+		if err != nil {
+			continue
+		}
+
+		phrases, err := store.ListHiddenPhrases(ctx, user.ID)
+		if err != nil {
+			log.Error().Err(err).Str("user_id", user.ID.String()).Msg("failed to load hidden words")
+		} else if hiddenwords.NewMatcher(phrases).Match(caption) {
+			continue
+		}
+
+		_, err = store.CreateNotification(ctx, db.CreateNotificationParams{
+			UserID:         user.ID,
+			Type:           "story_mention",
+			Title:          "You were mentioned!",
+			Message:        "You were mentioned in a story",
+			RelatedStoryID: uuid.NullUUID{UUID: storyID, Valid: true},
+		})
+		if err != nil {
+			log.Error().Err(err).Str("story_id", storyID.String()).Msg("failed to notify mention")
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package moderation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Scorer rates content with a model. Scores range from 0 (benign) to 1 (abusive).
+type Scorer interface {
+	Score(ctx context.Context, content Content) (float64, error)
+}
+
+// ScoringModerator turns a Scorer into a ContentModerator using two thresholds
+type ScoringModerator struct {
+	scorer   Scorer
+	flagAt   float64
+	rejectAt float64
+}
+
+func NewScoringModerator(scorer Scorer, flagAt, rejectAt float64) *ScoringModerator {
+	return &ScoringModerator{scorer: scorer, flagAt: flagAt, rejectAt: rejectAt}
+}
+
+func (m *ScoringModerator) Moderate(ctx context.Context, content Content) (Result, error) {
+	score, err := m.scorer.Score(ctx, content)
+	if err != nil {
+		return Result{Verdict: VerdictAllow}, err
+	}
+
+	result := Result{Verdict: VerdictAllow, Rule: "model", Score: score}
+	switch {
+	case score >= m.rejectAt:
+		result.Verdict = VerdictReject
+	case score >= m.flagAt:
+		result.Verdict = VerdictFlag
+	}
+	return result, nil
+}
+
+// HTTPScorer calls a locally hosted model.
+// Request: {"kind": "...", "text": "..."}, response: {"score": 0.0-1.0}
+type HTTPScorer struct {
+	url    string
+	client *http.Client
+}
+
+func NewHTTPScorer(url string) *HTTPScorer {
+	return &HTTPScorer{
+		url:    url,
+		client: &http.Client{Timeout: 2 * time.Second},
+	}
+}
+
+func (s *HTTPScorer) Score(ctx context.Context, content Content) (float64, error) {
+	body, err := json.Marshal(map[string]string{
+		"kind": content.Kind,
+		"text": content.Text,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("moderation model returned %d", resp.StatusCode)
+	}
+
+	var out struct {
+		Score float64 `json:"score"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return 0, err
+	}
+	return out.Score, nil
+}
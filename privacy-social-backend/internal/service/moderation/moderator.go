@@ -0,0 +1,90 @@
+package moderation
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+// Verdict is the outcome of a moderation check
+type Verdict string
+
+const (
+	VerdictAllow  Verdict = "allow"
+	VerdictFlag   Verdict = "flag"   // Accept but hide from others until reviewed
+	VerdictReject Verdict = "reject" // Refuse the write
+)
+
+// severity orders verdicts so the strictest one wins
+func (v Verdict) severity() int {
+	switch v {
+	case VerdictReject:
+		return 2
+	case VerdictFlag:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Content kinds
+const (
+	KindCaption  = "caption"
+	KindMessage  = "message"
+	KindBio      = "bio"
+	KindUsername = "username"
+	KindFullName = "full_name"
+)
+
+// Content is a piece of user-generated text to screen
+type Content struct {
+	Kind     string
+	Text     string
+	AuthorID uuid.UUID
+}
+
+// Result explains a verdict
+type Result struct {
+	Verdict Verdict `json:"verdict"`
+	Rule    string  `json:"rule,omitempty"`  // Name of the rule or model that decided
+	Score   float64 `json:"score,omitempty"` // Model score, if any
+}
+
+// ContentModerator screens user-generated text synchronously
+type ContentModerator interface {
+	Moderate(ctx context.Context, content Content) (Result, error)
+}
+
+// Pipeline runs moderators in order and keeps the strictest verdict.
+// A reject short-circuits; a failing moderator is logged and skipped.
+type Pipeline struct {
+	moderators []ContentModerator
+}
+
+func NewPipeline(moderators ...ContentModerator) *Pipeline {
+	return &Pipeline{moderators: moderators}
+}
+
+func (p *Pipeline) Moderate(ctx context.Context, content Content) (Result, error) {
+	result := Result{Verdict: VerdictAllow}
+	if content.Text == "" {
+		return result, nil
+	}
+
+	for _, m := range p.moderators {
+		r, err := m.Moderate(ctx, content)
+		if err != nil {
+			log.Error().Err(err).Str("kind", content.Kind).Msg("content moderator failed")
+			continue
+		}
+		if r.Verdict.severity() > result.Verdict.severity() {
+			result = r
+		}
+		if result.Verdict == VerdictReject {
+			break
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,114 @@
+package moderation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Rule matches text by keyword or regular expression.
+// Keywords match whole words, case-insensitively. Patterns use RE2 syntax.
+type Rule struct {
+	Name     string   `json:"name"`
+	Keywords []string `json:"keywords,omitempty"`
+	Pattern  string   `json:"pattern,omitempty"`
+	Verdict  Verdict  `json:"verdict"`
+	Kinds    []string `json:"kinds,omitempty"` // Empty means every kind
+}
+
+type compiledRule struct {
+	name    string
+	re      *regexp.Regexp
+	verdict Verdict
+	kinds   map[string]bool
+}
+
+// RuleEngine is a keyword and regex ContentModerator
+type RuleEngine struct {
+	rules []compiledRule
+}
+
+// NewRuleEngine compiles the rules, failing on invalid patterns or verdicts
+func NewRuleEngine(rules []Rule) (*RuleEngine, error) {
+	engine := &RuleEngine{}
+
+	for _, r := range rules {
+		if r.Verdict != VerdictFlag && r.Verdict != VerdictReject {
+			return nil, fmt.Errorf("rule %q: verdict must be flag or reject", r.Name)
+		}
+
+		var parts []string
+		for _, kw := range r.Keywords {
+			kw = strings.TrimSpace(kw)
+			if kw != "" {
+				parts = append(parts, `\b`+regexp.QuoteMeta(kw)+`\b`)
+			}
+		}
+		if r.Pattern != "" {
+			parts = append(parts, "(?:"+r.Pattern+")")
+		}
+		if len(parts) == 0 {
+			return nil, fmt.Errorf("rule %q: needs keywords or a pattern", r.Name)
+		}
+
+		re, err := regexp.Compile("(?i)" + strings.Join(parts, "|"))
+		if err != nil {
+			return nil, fmt.Errorf("rule %q: %w", r.Name, err)
+		}
+
+		var kinds map[string]bool
+		if len(r.Kinds) > 0 {
+			kinds = make(map[string]bool, len(r.Kinds))
+			for _, k := range r.Kinds {
+				kinds[k] = true
+			}
+		}
+
+		engine.rules = append(engine.rules, compiledRule{
+			name:    r.Name,
+			re:      re,
+			verdict: r.Verdict,
+			kinds:   kinds,
+		})
+	}
+
+	return engine, nil
+}
+
+// LoadRules reads a JSON array of rules from a file
+func LoadRules(path string) ([]Rule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var rules []Rule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return rules, nil
+}
+
+func (e *RuleEngine) Moderate(ctx context.Context, content Content) (Result, error) {
+	result := Result{Verdict: VerdictAllow}
+
+	for _, r := range e.rules {
+		if r.kinds != nil && !r.kinds[content.Kind] {
+			continue
+		}
+		if !r.re.MatchString(content.Text) {
+			continue
+		}
+		if r.verdict.severity() > result.Verdict.severity() {
+			result = Result{Verdict: r.verdict, Rule: r.name}
+		}
+		if result.Verdict == VerdictReject {
+			break
+		}
+	}
+
+	return result, nil
+}
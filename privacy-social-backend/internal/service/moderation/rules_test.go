@@ -0,0 +1,50 @@
+package moderation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRuleEngine(t *testing.T) {
+	engine, err := NewRuleEngine([]Rule{
+		{Name: "slur", Keywords: []string{"badword"}, Verdict: VerdictReject},
+		{Name: "contact", Pattern: `\+?\d[\d\s-]{8,}\d`, Verdict: VerdictFlag, Kinds: []string{KindBio, KindCaption}},
+	})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name    string
+		content Content
+		verdict Verdict
+		rule    string
+	}{
+		{"Clean", Content{Kind: KindMessage, Text: "see you at the park"}, VerdictAllow, ""},
+		{"KeywordCaseInsensitive", Content{Kind: KindMessage, Text: "you BADWORD"}, VerdictReject, "slur"},
+		{"KeywordWholeWordOnly", Content{Kind: KindMessage, Text: "badwords are fine"}, VerdictAllow, ""},
+		{"PatternFlags", Content{Kind: KindBio, Text: "call me 555 123 4567"}, VerdictFlag, "contact"},
+		{"PatternOtherKind", Content{Kind: KindMessage, Text: "call me 555 123 4567"}, VerdictAllow, ""},
+		{"StrictestWins", Content{Kind: KindCaption, Text: "badword 555 123 4567"}, VerdictReject, "slur"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := NewPipeline(engine).Moderate(context.Background(), tc.content)
+			require.NoError(t, err)
+			require.Equal(t, tc.verdict, result.Verdict)
+			require.Equal(t, tc.rule, result.Rule)
+		})
+	}
+}
+
+func TestRuleEngineInvalid(t *testing.T) {
+	_, err := NewRuleEngine([]Rule{{Name: "empty", Verdict: VerdictFlag}})
+	require.Error(t, err)
+
+	_, err = NewRuleEngine([]Rule{{Name: "allow", Keywords: []string{"x"}, Verdict: VerdictAllow}})
+	require.Error(t, err)
+
+	_, err = NewRuleEngine([]Rule{{Name: "bad", Pattern: "(", Verdict: VerdictFlag}})
+	require.Error(t, err)
+}
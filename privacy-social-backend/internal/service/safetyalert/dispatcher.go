@@ -0,0 +1,180 @@
+// Package safetyalert notifies a user's emergency contacts when a meetup
+// check-in is missed or panic mode is triggered.
+package safetyalert
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+
+	"privacy-social-backend/internal/repository"
+	"privacy-social-backend/internal/repository/db"
+)
+
+// LastLocationKeyPrefix is the Redis hash holding a user's last accepted location
+const LastLocationKeyPrefix = "safety:last_loc:"
+
+// Alert reasons
+const (
+	ReasonMissedCheckin = "missed_checkin"
+	ReasonPanic         = "panic"
+)
+
+// coarseFactor rounds coordinates to two decimals (~1 km), enough to start a
+// search without pinpointing a home address
+const coarseFactor = 100
+
+// Location is a coarse last known position
+type Location struct {
+	Lat    float64   `json:"lat"`
+	Lng    float64   `json:"lng"`
+	SeenAt time.Time `json:"seen_at"`
+}
+
+// Alert is what emergency contacts are told
+type Alert struct {
+	UserID   uuid.UUID `json:"user_id"`
+	Username string    `json:"username"`
+	Reason   string    `json:"reason"`
+	Note     string    `json:"note,omitempty"` // Check-in note, e.g. where the meetup is
+	Location *Location `json:"location,omitempty"`
+	Time     time.Time `json:"time"`
+}
+
+// Message is the human-readable alert text
+func (a Alert) Message() string {
+	var msg string
+	switch a.Reason {
+	case ReasonPanic:
+		msg = fmt.Sprintf("%s triggered an emergency alert.", a.Username)
+	default:
+		msg = fmt.Sprintf("%s didn't check in after a meetup.", a.Username)
+	}
+	if a.Note != "" {
+		msg += fmt.Sprintf(" Their note: %q.", a.Note)
+	}
+	if a.Location != nil {
+		msg += fmt.Sprintf(" Last known area: %.2f, %.2f at %s.", a.Location.Lat, a.Location.Lng, a.Location.SeenAt.UTC().Format("15:04 MST"))
+	}
+	return msg
+}
+
+// CoarseLocation rounds a coordinate for sharing with contacts
+func CoarseLocation(lat, lng float64, seenAt time.Time) Location {
+	return Location{
+		Lat:    math.Round(lat*coarseFactor) / coarseFactor,
+		Lng:    math.Round(lng*coarseFactor) / coarseFactor,
+		SeenAt: seenAt,
+	}
+}
+
+// Dispatcher sends alerts: in-app notifications for contacts who are
+// connections, the Notifier for external ones. In-app contacts who are no
+// longer connections are skipped.
+type Dispatcher struct {
+	store    repository.Store
+	redis    *redis.Client
+	notifier Notifier
+}
+
+func NewDispatcher(store repository.Store, rdb *redis.Client, notifier Notifier) *Dispatcher {
+	return &Dispatcher{store: store, redis: rdb, notifier: notifier}
+}
+
+// Send alerts every emergency contact of the user and returns how many were reached.
+// A failing contact doesn't stop the others.
+func (d *Dispatcher) Send(ctx context.Context, userID uuid.UUID, reason, note string) (int, error) {
+	user, err := d.store.GetUserByID(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	contacts, err := d.store.ListEmergencyContacts(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	alert := Alert{
+		UserID:   userID,
+		Username: user.Username,
+		Reason:   reason,
+		Note:     note,
+		Location: d.lastLocation(ctx, userID),
+		Time:     time.Now(),
+	}
+
+	sent, skipped := 0, 0
+	for _, c := range contacts {
+		if c.ContactUserID.Valid {
+			connected, err := d.isConnected(ctx, userID, c.ContactUserID.UUID)
+			if err != nil {
+				log.Error().Err(err).Str("contact_id", c.ID.String()).Msg("failed to check safety alert contact")
+				continue
+			}
+			if !connected {
+				// No longer a connection, e.g. blocked since: they don't get the alert or location
+				skipped++
+				continue
+			}
+			// No related user: the notification must survive a panic-mode account deletion
+			_, err = d.store.CreateNotification(ctx, db.CreateNotificationParams{
+				UserID:  c.ContactUserID.UUID,
+				Type:    db.NotificationTypeSafetyAlert,
+				Title:   "Safety alert",
+				Message: alert.Message(),
+			})
+		} else {
+			err = d.notifier.Notify(ctx, Contact{
+				Name:  c.Name,
+				Email: c.Email.String,
+				Phone: c.Phone.String,
+			}, alert)
+		}
+		if err != nil {
+			log.Error().Err(err).Str("contact_id", c.ID.String()).Msg("failed to deliver safety alert")
+			continue
+		}
+		sent++
+	}
+
+	if reachable := len(contacts) - skipped; sent == 0 && reachable > 0 {
+		return 0, fmt.Errorf("safety alert not delivered to any of %d contacts", reachable)
+	}
+	return sent, nil
+}
+
+// isConnected reports whether an in-app contact is still an accepted connection of the user
+func (d *Dispatcher) isConnected(ctx context.Context, userID, contactID uuid.UUID) (bool, error) {
+	conn, err := d.store.GetConnection(ctx, db.GetConnectionParams{RequesterID: userID, TargetID: contactID})
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return conn.Status == db.ConnectionStatusAccepted, nil
+}
+
+func (d *Dispatcher) lastLocation(ctx context.Context, userID uuid.UUID) *Location {
+	res, err := d.redis.HGetAll(ctx, LastLocationKeyPrefix+userID.String()).Result()
+	if err != nil || len(res) == 0 {
+		return nil
+	}
+
+	lat, errLat := strconv.ParseFloat(res["lat"], 64)
+	lng, errLng := strconv.ParseFloat(res["lng"], 64)
+	if errLat != nil || errLng != nil {
+		return nil
+	}
+	seenAt, _ := time.Parse(time.RFC3339, res["time"])
+
+	loc := CoarseLocation(lat, lng, seenAt)
+	return &loc
+}
@@ -0,0 +1,97 @@
+package safetyalert
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"privacy-social-backend/internal/repository/db"
+	mockdb "privacy-social-backend/internal/repository/mock"
+)
+
+func TestCoarseLocation(t *testing.T) {
+	loc := CoarseLocation(51.507351, -0.127758, time.Now())
+	require.Equal(t, 51.51, loc.Lat)
+	require.Equal(t, -0.13, loc.Lng)
+}
+
+func TestAlertMessage(t *testing.T) {
+	seenAt := time.Date(2024, 5, 1, 21, 30, 0, 0, time.UTC)
+	loc := CoarseLocation(51.507351, -0.127758, seenAt)
+
+	msg := Alert{Username: "sam", Reason: ReasonMissedCheckin, Note: "coffee at the park", Location: &loc}.Message()
+	require.Contains(t, msg, "sam didn't check in")
+	require.Contains(t, msg, "coffee at the park")
+	require.Contains(t, msg, "51.51, -0.13 at 21:30 UTC")
+	require.NotContains(t, msg, "51.5073")
+
+	msg = Alert{Username: "sam", Reason: ReasonPanic}.Message()
+	require.Equal(t, "sam triggered an emergency alert.", msg)
+}
+
+// Without a notifier, external contacts are reported as undelivered
+// so the check-in worker retries instead of closing the check-in
+func TestSendWithoutNotifier(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := db.User{ID: uuid.New(), Username: "sam"}
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetUserByID(gomock.Any(), user.ID).Times(1).Return(user, nil)
+	store.EXPECT().ListEmergencyContacts(gomock.Any(), user.ID).Times(1).Return([]db.EmergencyContact{{
+		ID:     uuid.New(),
+		UserID: user.ID,
+		Name:   "Alex",
+		Email:  sql.NullString{String: "alex@example.com", Valid: true},
+	}}, nil)
+
+	rdb := redis.NewClient(&redis.Options{Addr: "localhost:0", MaxRetries: -1})
+	d := NewDispatcher(store, rdb, NewNotifier(""))
+
+	sent, err := d.Send(context.Background(), user.ID, ReasonMissedCheckin, "")
+	require.Error(t, err)
+	require.Zero(t, sent)
+}
+
+// In-app contacts who are no longer connections, e.g. after a block, get
+// neither the alert nor the location; the others still do
+func TestSendSkipsFormerConnections(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	user := db.User{ID: uuid.New(), Username: "sam"}
+	connected := db.EmergencyContact{ID: uuid.New(), UserID: user.ID, ContactUserID: uuid.NullUUID{UUID: uuid.New(), Valid: true}}
+	blocked := db.EmergencyContact{ID: uuid.New(), UserID: user.ID, ContactUserID: uuid.NullUUID{UUID: uuid.New(), Valid: true}}
+
+	store := mockdb.NewMockStore(ctrl)
+	store.EXPECT().GetUserByID(gomock.Any(), user.ID).Times(1).Return(user, nil)
+	store.EXPECT().ListEmergencyContacts(gomock.Any(), user.ID).Times(1).Return([]db.EmergencyContact{connected, blocked}, nil)
+	store.EXPECT().
+		GetConnection(gomock.Any(), db.GetConnectionParams{RequesterID: user.ID, TargetID: connected.ContactUserID.UUID}).
+		Times(1).
+		Return(db.Connection{Status: db.ConnectionStatusAccepted}, nil)
+	store.EXPECT().
+		GetConnection(gomock.Any(), db.GetConnectionParams{RequesterID: user.ID, TargetID: blocked.ContactUserID.UUID}).
+		Times(1).
+		Return(db.Connection{}, sql.ErrNoRows)
+	store.EXPECT().
+		CreateNotification(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.CreateNotificationParams) (db.Notification, error) {
+			require.Equal(t, connected.ContactUserID.UUID, arg.UserID)
+			return db.Notification{}, nil
+		})
+
+	rdb := redis.NewClient(&redis.Options{Addr: "localhost:0", MaxRetries: -1})
+	d := NewDispatcher(store, rdb, NewNotifier(""))
+
+	sent, err := d.Send(context.Background(), user.ID, ReasonPanic, "")
+	require.NoError(t, err)
+	require.Equal(t, 1, sent)
+}
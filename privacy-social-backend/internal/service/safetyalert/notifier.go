@@ -0,0 +1,94 @@
+package safetyalert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Contact is an external emergency contact, reached outside the app
+type Contact struct {
+	Name  string `json:"name"`
+	Email string `json:"email,omitempty"`
+	Phone string `json:"phone,omitempty"`
+}
+
+// Notifier delivers alerts to external contacts (email, SMS, ...)
+type Notifier interface {
+	Notify(ctx context.Context, contact Contact, alert Alert) error
+}
+
+// ErrNotifierNotConfigured is returned for external contacts when no
+// delivery channel is configured, so they are never counted as alerted
+var ErrNotifierNotConfigured = errors.New("safety alert not delivered: no notifier configured")
+
+// NewNotifier returns a webhook notifier when a URL is configured,
+// otherwise one that only logs (development).
+func NewNotifier(webhookURL string) Notifier {
+	if webhookURL == "" {
+		log.Warn().Msg("ALERT_WEBHOOK_URL is not set: external emergency contacts can't be alerted")
+		return LogNotifier{}
+	}
+	return NewWebhookNotifier(webhookURL)
+}
+
+// LogNotifier records alerts in the server log instead of delivering them.
+// Every alert fails with ErrNotifierNotConfigured.
+type LogNotifier struct{}
+
+func (LogNotifier) Notify(ctx context.Context, contact Contact, alert Alert) error {
+	log.Error().
+		Str("user_id", alert.UserID.String()).
+		Str("reason", alert.Reason).
+		Str("contact", contact.Name).
+		Msg(ErrNotifierNotConfigured.Error())
+	return ErrNotifierNotConfigured
+}
+
+// WebhookNotifier hands alerts to an email/SMS gateway.
+// Request: {"contact": {...}, "alert": {...}, "message": "..."}, any 2xx is success.
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{
+		url:    url,
+		client: &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, contact Contact, alert Alert) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"contact": contact,
+		"alert":   alert,
+		"message": alert.Message(),
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("alert webhook returned %d", resp.StatusCode)
+	}
+	return nil
+}
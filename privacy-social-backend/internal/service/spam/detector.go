@@ -0,0 +1,207 @@
+// Package spam detects chat spam: identical content fanned out to many
+// receivers, link-heavy messages, bursts, and new accounts.
+package spam
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	fingerprintKeyPrefix = "spam:fp:"
+	rateKeyPrefix        = "spam:rate:"
+	throttleKeyPrefix    = "spam:throttle:"
+)
+
+// Signal weights. No signal holds a message on its own: sending the same
+// text to many friends is normal, so fan-out needs links, a burst, or a new
+// account alongside it.
+const (
+	fanoutWeight        = 0.6
+	partialFanoutWeight = 0.3
+	linkDensityWeight   = 0.4
+	manyLinksWeight     = 0.4
+	burstWeight         = 0.4
+	newAccountLinkCost  = 0.4
+	newAccountFanout    = 0.4
+
+	// Signals needed before a message is held, whatever the score
+	minHoldSignals = 2
+
+	manyLinks        = 3
+	linkDensityRatio = 0.5
+)
+
+var (
+	linkPattern   = regexp.MustCompile(`(?i)(https?://|www\.)\S+|\b[a-z0-9-]+\.(com|net|org|io|ly|me|co|xyz|info|biz|link|click)\b\S*`)
+	digitPattern  = regexp.MustCompile(`[0-9]+`)
+	spacesPattern = regexp.MustCompile(`\s+`)
+)
+
+// Config holds the detector thresholds
+type Config struct {
+	Window           time.Duration // Sliding window for fingerprints
+	FanoutReceivers  int64         // Distinct receivers of the same content that count as a blast
+	BurstWindow      time.Duration // Window for the sender's overall message rate
+	BurstMessages    int64         // Messages in BurstWindow that count as a burst
+	NewAccountAge    time.Duration
+	HoldScore        float64
+	ThrottleDuration time.Duration
+}
+
+// Message is an outgoing chat message to check
+type Message struct {
+	SenderID        uuid.UUID
+	ReceiverID      uuid.UUID
+	Content         string
+	MediaURL        string
+	SenderCreatedAt time.Time
+	// Generated marks server-built text (e.g. story shares) that is identical
+	// by design, so repeating it to many receivers isn't a signal
+	Generated bool
+}
+
+// Signal is one suspicious observation contributing to the score
+type Signal struct {
+	Name   string  `json:"name"`
+	Weight float64 `json:"weight"`
+}
+
+// Verdict is the outcome of checking a message
+type Verdict struct {
+	Score       float64  `json:"score"`
+	Signals     []Signal `json:"signals"`
+	Fingerprint string   `json:"fingerprint"`
+	Receivers   int64    `json:"receivers"` // Distinct receivers of this content in the window
+	Hold        bool     `json:"hold"`
+}
+
+// Detector tracks content fingerprints per sender in Redis
+type Detector struct {
+	redis  *redis.Client
+	config Config
+}
+
+func NewDetector(redis *redis.Client, config Config) *Detector {
+	return &Detector{redis: redis, config: config}
+}
+
+// Fingerprint normalizes content so trivial variations (case, spacing,
+// changing numbers) map to the same value
+func Fingerprint(content, mediaURL string) string {
+	normalized := strings.ToLower(strings.TrimSpace(content))
+	normalized = digitPattern.ReplaceAllString(normalized, "#")
+	normalized = spacesPattern.ReplaceAllString(normalized, " ")
+	sum := sha1.Sum([]byte(normalized + "|" + mediaURL))
+	return hex.EncodeToString(sum[:8])
+}
+
+// CountLinks returns the number of URLs or bare domains in content
+func CountLinks(content string) int {
+	return len(linkPattern.FindAllString(content, -1))
+}
+
+// observation is what Check measured for one message
+type observation struct {
+	Receivers  int64 // Distinct receivers of this content in the window
+	Content    string
+	NewAccount bool
+	Burst      bool
+	Generated  bool
+}
+
+// Check records the message and scores it. The message counts towards the
+// fan-out and burst windows whether or not it is held.
+func (d *Detector) Check(ctx context.Context, msg Message) (Verdict, error) {
+	fp := Fingerprint(msg.Content, msg.MediaURL)
+	key := fingerprintKeyPrefix + msg.SenderID.String() + ":" + fp
+	rateKey := rateKeyPrefix + msg.SenderID.String() + ":" + strconv.FormatInt(time.Now().Truncate(d.config.BurstWindow).Unix(), 10)
+	now := time.Now()
+
+	var card, sent *redis.IntCmd
+	_, err := d.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZRemRangeByScore(ctx, key, "-inf", strconv.FormatInt(now.Add(-d.config.Window).UnixMilli(), 10))
+		pipe.ZAdd(ctx, key, redis.Z{Score: float64(now.UnixMilli()), Member: msg.ReceiverID.String()})
+		card = pipe.ZCard(ctx, key)
+		pipe.Expire(ctx, key, d.config.Window)
+		sent = pipe.Incr(ctx, rateKey)
+		pipe.Expire(ctx, rateKey, d.config.BurstWindow)
+		return nil
+	})
+	if err != nil {
+		return Verdict{Fingerprint: fp}, fmt.Errorf("spam fingerprint: %w", err)
+	}
+
+	verdict := d.score(observation{
+		Receivers:  card.Val(),
+		Content:    msg.Content,
+		NewAccount: now.Sub(msg.SenderCreatedAt) < d.config.NewAccountAge,
+		Burst:      sent.Val() > d.config.BurstMessages,
+		Generated:  msg.Generated,
+	})
+	verdict.Fingerprint = fp
+	return verdict, nil
+}
+
+// score combines fan-out, link, burst and account-age signals
+func (d *Detector) score(o observation) Verdict {
+	v := Verdict{Receivers: o.Receivers}
+	add := func(name string, weight float64) {
+		v.Signals = append(v.Signals, Signal{Name: name, Weight: weight})
+		v.Score += weight
+	}
+
+	if !o.Generated {
+		switch {
+		case o.Receivers >= d.config.FanoutReceivers:
+			add("duplicate_fanout", fanoutWeight)
+		case o.Receivers > 1 && o.Receivers*2 >= d.config.FanoutReceivers:
+			add("partial_fanout", partialFanoutWeight)
+		}
+	}
+
+	links := CountLinks(o.Content)
+	words := len(strings.Fields(o.Content))
+	if links > 0 && float64(links) >= float64(words)*linkDensityRatio {
+		add("link_density", linkDensityWeight)
+	}
+	if links >= manyLinks {
+		add("many_links", manyLinksWeight)
+	}
+
+	if o.Burst {
+		add("burst", burstWeight)
+	}
+
+	if o.NewAccount {
+		if links > 0 {
+			add("new_account_link", newAccountLinkCost)
+		}
+		if o.Receivers > 1 && !o.Generated {
+			add("new_account_fanout", newAccountFanout)
+		}
+	}
+
+	v.Hold = v.Score >= d.config.HoldScore && len(v.Signals) >= minHoldSignals
+	return v
+}
+
+// Throttle blocks the sender from sending for the throttle duration
+func (d *Detector) Throttle(ctx context.Context, senderID uuid.UUID) error {
+	return d.redis.Set(ctx, throttleKeyPrefix+senderID.String(), "1", d.config.ThrottleDuration).Err()
+}
+
+// IsThrottled reports whether the sender is currently throttled
+func (d *Detector) IsThrottled(ctx context.Context, senderID uuid.UUID) (bool, error) {
+	n, err := d.redis.Exists(ctx, throttleKeyPrefix+senderID.String()).Result()
+	return n > 0, err
+}
@@ -0,0 +1,55 @@
+package spam
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testDetector() *Detector {
+	return NewDetector(nil, Config{
+		Window:           10 * time.Minute,
+		FanoutReceivers:  5,
+		BurstWindow:      time.Minute,
+		BurstMessages:    20,
+		NewAccountAge:    48 * time.Hour,
+		HoldScore:        1.0,
+		ThrottleDuration: 30 * time.Minute,
+	})
+}
+
+func TestFingerprintIgnoresTrivialChanges(t *testing.T) {
+	a := Fingerprint("Win a FREE phone at promo.xyz/ref=123", "")
+	b := Fingerprint("  win a free   phone at promo.xyz/ref=987 ", "")
+	require.Equal(t, a, b)
+	require.NotEqual(t, a, Fingerprint("see you at 7?", ""))
+}
+
+func TestScore(t *testing.T) {
+	d := testDetector()
+
+	testCases := []struct {
+		name string
+		obs  observation
+		hold bool
+	}{
+		{name: "NormalMessage", obs: observation{Receivers: 1, Content: "are you coming tonight?"}},
+		{name: "SharedLinkToOneFriend", obs: observation{Receivers: 1, Content: "look at this https://example.com/a"}},
+		{name: "SameTextToManyReceivers", obs: observation{Receivers: 5, Content: "happy new year everyone!"}},
+		{name: "SameTextToManyReceiversInBurst", obs: observation{Receivers: 5, Content: "happy new year everyone!", Burst: true}, hold: true},
+		{name: "LinkToManyReceivers", obs: observation{Receivers: 5, Content: "https://promo.xyz/deal"}, hold: true},
+		{name: "StoryShareToManyFriends", obs: observation{Receivers: 8, Content: "📸 Shared a story with you: /view-story/x", Generated: true}},
+		{name: "StoryShareFromNewAccount", obs: observation{Receivers: 8, Content: "📸 Shared a story with you: /view-story/x", Generated: true, NewAccount: true}},
+		{name: "NewAccountLinkToFew", obs: observation{Receivers: 3, Content: "https://promo.xyz/deal", NewAccount: true}, hold: true},
+		{name: "NewAccountLinkToOne", obs: observation{Receivers: 1, Content: "check www.promo.xyz", NewAccount: true}},
+		{name: "LinkWall", obs: observation{Receivers: 1, Content: "a.com b.net c.org d.io", NewAccount: true}, hold: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			v := d.score(tc.obs)
+			require.Equal(t, tc.hold, v.Hold, "score %.2f signals %v", v.Score, v.Signals)
+		})
+	}
+}
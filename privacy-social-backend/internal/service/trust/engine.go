@@ -0,0 +1,47 @@
+package trust
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"privacy-social-backend/internal/repository"
+	"privacy-social-backend/internal/repository/db"
+)
+
+// Engine recomputes and stores trust levels
+type Engine struct {
+	store repository.Store
+}
+
+func NewEngine(store repository.Store) *Engine {
+	return &Engine{store: store}
+}
+
+// Recalculate recomputes a user's trust level and stores it if it changed
+func (e *Engine) Recalculate(ctx context.Context, userID uuid.UUID) (int32, error) {
+	row, err := e.store.GetTrustInputs(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	level := Score(Inputs{
+		AccountAge:          time.Since(row.CreatedAt),
+		PhoneVerified:       row.IsVerified,
+		AcceptedConnections: row.AcceptedConnections,
+		ReportsReceived:     row.ReportsReceived,
+		ReportsUpheld:       row.ReportsUpheld,
+		SafetyStrikes:       row.SafetyStrikes,
+		ReportPenalty:       row.ReportPenalty,
+	})
+	if level == row.TrustLevel {
+		return level, nil
+	}
+
+	_, err = e.store.UpdateUserTrust(ctx, db.UpdateUserTrustParams{
+		ID:         userID,
+		TrustLevel: level,
+	})
+	return level, err
+}
@@ -0,0 +1,73 @@
+// Package trust computes a user's trust level from account signals and
+// maps it to feature limits.
+package trust
+
+import "time"
+
+const (
+	// BaseLevel matches the users.trust_level column default
+	BaseLevel = 10
+	MaxLevel  = 30
+
+	// SuggestionMinLevel is the lowest level shown in /connections/suggested
+	SuggestionMinLevel = 5
+
+	newAccountAge = 7 * 24 * time.Hour
+)
+
+// Inputs are the signals the score is computed from
+type Inputs struct {
+	AccountAge          time.Duration
+	PhoneVerified       bool
+	AcceptedConnections int64
+	ReportsReceived     int64 // Distinct reporters, recent window
+	ReportsUpheld       int64 // Reports behind escalations that were not reverted
+	SafetyStrikes       int64 // Fake GPS strikes, recent window
+	ReportPenalty       int64 // Active trust penalties from report escalations
+}
+
+// Score computes the trust level. Good signals are capped so that age or
+// connections alone can't outweigh reports and strikes.
+func Score(in Inputs) int32 {
+	level := int64(BaseLevel)
+
+	if in.PhoneVerified {
+		level += 5
+	}
+
+	if in.AccountAge < newAccountAge {
+		level -= 3
+	} else {
+		level += min(int64(in.AccountAge/(30*24*time.Hour)), 5)
+	}
+
+	level += min(in.AcceptedConnections/5, 5)
+	level -= min(in.ReportsReceived/3, 5)
+	level -= 2 * min(in.ReportsUpheld, 5)
+	level -= 2 * min(in.SafetyStrikes, 5)
+	level -= in.ReportPenalty
+
+	return int32(max(0, min(level, MaxLevel)))
+}
+
+// Limits are the feature allowances for a trust level
+type Limits struct {
+	DailyConnectionRequests int64
+	StoriesPerHour          int64
+	Suggestable             bool
+	DMMedia                 bool
+}
+
+// LimitsFor maps a trust level to its tier
+func LimitsFor(level int32) Limits {
+	switch {
+	case level >= 20:
+		return Limits{DailyConnectionRequests: 40, StoriesPerHour: 50, Suggestable: true, DMMedia: true}
+	case level >= BaseLevel:
+		return Limits{DailyConnectionRequests: 20, StoriesPerHour: 30, Suggestable: true, DMMedia: true}
+	case level >= SuggestionMinLevel:
+		return Limits{DailyConnectionRequests: 10, StoriesPerHour: 10, Suggestable: true}
+	default:
+		return Limits{DailyConnectionRequests: 5, StoriesPerHour: 3}
+	}
+}
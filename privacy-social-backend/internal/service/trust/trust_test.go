@@ -0,0 +1,57 @@
+package trust
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScore(t *testing.T) {
+	month := 30 * 24 * time.Hour
+
+	testCases := []struct {
+		name  string
+		in    Inputs
+		check func(t *testing.T, level int32)
+	}{
+		{
+			name: "NewUnverifiedAccount",
+			in:   Inputs{AccountAge: time.Hour},
+			check: func(t *testing.T, level int32) {
+				require.Less(t, level, int32(BaseLevel))
+				require.False(t, LimitsFor(level).DMMedia)
+				require.True(t, LimitsFor(level).Suggestable)
+			},
+		},
+		{
+			name: "EstablishedVerifiedAccount",
+			in:   Inputs{AccountAge: 12 * month, PhoneVerified: true, AcceptedConnections: 40},
+			check: func(t *testing.T, level int32) {
+				require.GreaterOrEqual(t, level, int32(20))
+				require.Equal(t, int64(40), LimitsFor(level).DailyConnectionRequests)
+			},
+		},
+		{
+			name: "StrikesAndUpheldReports",
+			in:   Inputs{AccountAge: 12 * month, PhoneVerified: true, ReportsUpheld: 4, SafetyStrikes: 3, ReportPenalty: 3},
+			check: func(t *testing.T, level int32) {
+				require.Less(t, level, int32(SuggestionMinLevel))
+				require.False(t, LimitsFor(level).Suggestable)
+			},
+		},
+		{
+			name: "NeverNegative",
+			in:   Inputs{AccountAge: time.Hour, ReportsReceived: 100, ReportsUpheld: 100, SafetyStrikes: 100, ReportPenalty: 100},
+			check: func(t *testing.T, level int32) {
+				require.Zero(t, level)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.check(t, Score(tc.in))
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"privacy-social-backend/internal/repository"
+	"privacy-social-backend/internal/service/safetyalert"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	checkinInterval  = 1 * time.Minute
+	checkinBatchSize = 100
+)
+
+// CheckinWorker alerts emergency contacts when a meetup check-in passes
+// its deadline without being confirmed
+type CheckinWorker struct {
+	store  repository.Store
+	alerts *safetyalert.Dispatcher
+}
+
+func NewCheckinWorker(store repository.Store, alerts *safetyalert.Dispatcher) *CheckinWorker {
+	return &CheckinWorker{
+		store:  store,
+		alerts: alerts,
+	}
+}
+
+func (worker *CheckinWorker) Start() {
+	ticker := time.NewTicker(checkinInterval)
+	go func() {
+		for {
+			<-ticker.C
+			worker.alertOverdue()
+		}
+	}()
+}
+
+func (worker *CheckinWorker) alertOverdue() {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	// Claiming leases them, so a slow notifier can't cause double alerts.
+	// A check-in is only closed once its alert was delivered; otherwise the
+	// lease runs out and it is claimed again.
+	checkins, err := worker.store.ClaimOverdueMeetupCheckins(ctx, checkinBatchSize)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to claim overdue check-ins")
+		return
+	}
+
+	for _, c := range checkins {
+		sent, err := worker.alerts.Send(ctx, c.UserID, safetyalert.ReasonMissedCheckin, c.Note.String)
+		if err != nil {
+			log.Error().Err(err).
+				Str("checkin_id", c.ID.String()).
+				Int32("attempt", c.AlertAttempts).
+				Time("retry_at", c.NextAlertAt.Time).
+				Msg("failed to alert emergency contacts, will retry")
+			continue
+		}
+		if err := worker.store.MarkMeetupCheckinAlerted(ctx, c.ID); err != nil {
+			log.Error().Err(err).Str("checkin_id", c.ID.String()).Msg("failed to close alerted check-in")
+		}
+		log.Info().Str("checkin_id", c.ID.String()).Int("contacts", sent).Msg("Missed check-in alert sent")
+	}
+}
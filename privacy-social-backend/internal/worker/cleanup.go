@@ -2,13 +2,21 @@ package worker
 
 import (
 	"context"
+	"os"
 	"time"
 
 	"privacy-social-backend/internal/repository"
+	"privacy-social-backend/internal/repository/db"
 
 	"github.com/rs/zerolog/log"
 )
 
+const (
+	autoArchiveBatchSize = 500
+	// Owners without auto-archive are warned this long before a story expires
+	storyExpiryNotice = time.Hour
+)
+
 type CleanupWorker struct {
 	store repository.Store
 }
@@ -41,12 +49,26 @@ func (worker *CleanupWorker) cleanup() {
 		log.Info().Msg("Expired locations deleted")
 	}
 
-	// Cleanup expired stories
-	err = worker.store.DeleteExpiredStories(ctx)
+	// Warn owners whose stories are about to be lost
+	notified, err := worker.store.CreateStoryExpiryNotices(ctx, time.Now().Add(storyExpiryNotice))
 	if err != nil {
-		log.Error().Err(err).Msg("failed to delete expired stories")
+		log.Error().Err(err).Msg("failed to send story expiry notices")
+	} else if len(notified) > 0 {
+		log.Info().Int("count", len(notified)).Msg("Story expiry notices sent")
+	}
+
+	// Cleanup expired stories, once auto-archive has copied them. Both use the
+	// same cutoff so nothing expires between archiving and deleting.
+	expiredBefore := time.Now()
+	if err := worker.autoArchiveStories(ctx, expiredBefore); err != nil {
+		log.Error().Err(err).Msg("failed to auto-archive expired stories, keeping them for the next run")
 	} else {
-		log.Info().Msg("Expired stories deleted")
+		err = worker.store.DeleteExpiredStories(ctx, expiredBefore)
+		if err != nil {
+			log.Error().Err(err).Msg("failed to delete expired stories")
+		} else {
+			log.Info().Msg("Expired stories deleted")
+		}
 	}
 
 	// Cleanup old messages (30+ days)
@@ -65,6 +87,29 @@ func (worker *CleanupWorker) cleanup() {
 		log.Info().Msg("Expired messages deleted")
 	}
 
+	// Cleanup expired mutes (already ignored by reads)
+	err = worker.store.DeleteExpiredUserMutes(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to delete expired mutes")
+	} else {
+		log.Info().Msg("Expired mutes deleted")
+	}
+
+	// Cleanup report evidence past retention, including the stored media copies
+	mediaPaths, err := worker.store.DeleteExpiredReportEvidence(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("failed to delete expired report evidence")
+	} else {
+		for _, path := range mediaPaths {
+			if path.Valid {
+				if err := os.Remove(path.String); err != nil && !os.IsNotExist(err) {
+					log.Error().Err(err).Str("path", path.String).Msg("failed to remove evidence media")
+				}
+			}
+		}
+		log.Info().Int("count", len(mediaPaths)).Msg("Expired report evidence deleted")
+	}
+
 	// Cleanup old notifications (30+ days)
 	err = worker.store.DeleteOldNotifications(ctx)
 	if err != nil {
@@ -73,3 +118,26 @@ func (worker *CleanupWorker) cleanup() {
 		log.Info().Msg("Old notifications deleted")
 	}
 }
+
+// autoArchiveStories copies stories expired before the cutoff, of users with
+// auto-archive on, into their archive, in batches, until none are left
+func (worker *CleanupWorker) autoArchiveStories(ctx context.Context, expiredBefore time.Time) error {
+	total := 0
+	for {
+		archived, err := worker.store.AutoArchiveExpiredStories(ctx, db.AutoArchiveExpiredStoriesParams{
+			ExpiredBefore: expiredBefore,
+			BatchSize:     autoArchiveBatchSize,
+		})
+		if err != nil {
+			return err
+		}
+		total += len(archived)
+		if len(archived) < autoArchiveBatchSize {
+			break
+		}
+	}
+	if total > 0 {
+		log.Info().Int("count", total).Msg("Expired stories auto-archived")
+	}
+	return nil
+}
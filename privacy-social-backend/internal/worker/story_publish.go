@@ -0,0 +1,69 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"privacy-social-backend/internal/repository"
+	"privacy-social-backend/internal/service/feedcache"
+	"privacy-social-backend/internal/service/mentions"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	storyPublishInterval  = 30 * time.Second
+	storyPublishBatchSize = 100
+)
+
+// StoryPublishWorker makes scheduled stories live once their publish time
+// has passed, then refreshes the area's feed and notifies mentioned users
+type StoryPublishWorker struct {
+	store repository.Store
+	redis *redis.Client
+}
+
+func NewStoryPublishWorker(store repository.Store, redisClient *redis.Client) *StoryPublishWorker {
+	return &StoryPublishWorker{
+		store: store,
+		redis: redisClient,
+	}
+}
+
+func (worker *StoryPublishWorker) Start() {
+	ticker := time.NewTicker(storyPublishInterval)
+	go func() {
+		for {
+			<-ticker.C
+			worker.publishDue()
+		}
+	}()
+}
+
+func (worker *StoryPublishWorker) publishDue() {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	for {
+		stories, err := worker.store.PublishDueStories(ctx, storyPublishBatchSize)
+		if err != nil {
+			log.Error().Err(err).Msg("failed to publish scheduled stories")
+			return
+		}
+
+		for _, s := range stories {
+			feedcache.Invalidate(ctx, worker.redis, s.Geohash)
+
+			// Stories held for review aren't announced, as on create
+			if s.Caption.Valid && !s.Held {
+				mentions.NotifyStory(ctx, worker.store, s.ID, s.Caption.String)
+			}
+			log.Info().Str("story_id", s.ID.String()).Msg("Scheduled story published")
+		}
+
+		if len(stories) < storyPublishBatchSize {
+			return
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"privacy-social-backend/internal/repository"
+	"privacy-social-backend/internal/repository/db"
+	"privacy-social-backend/internal/service/trust"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	trustRecalcInterval = 1 * time.Hour
+	trustActiveWindow   = 30 * 24 * time.Hour
+	trustBatchSize      = 500
+)
+
+// TrustWorker periodically recomputes trust for recently active users, so
+// time-based signals (account age, expiring strikes) take effect without an event.
+type TrustWorker struct {
+	store  repository.Store
+	engine *trust.Engine
+}
+
+func NewTrustWorker(store repository.Store) *TrustWorker {
+	return &TrustWorker{
+		store:  store,
+		engine: trust.NewEngine(store),
+	}
+}
+
+func (worker *TrustWorker) Start() {
+	ticker := time.NewTicker(trustRecalcInterval)
+	go func() {
+		for {
+			<-ticker.C
+			log.Info().Msg("Running trust worker...")
+			worker.recalculate()
+		}
+	}()
+}
+
+func (worker *TrustWorker) recalculate() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	since := sql.NullTime{Time: time.Now().UTC().Add(-trustActiveWindow), Valid: true}
+	updated := 0
+	for offset := int32(0); ; offset += trustBatchSize {
+		ids, err := worker.store.ListRecentlyActiveUserIDs(ctx, db.ListRecentlyActiveUserIDsParams{
+			LastActiveAt: since,
+			Limit:        trustBatchSize,
+			Offset:       offset,
+		})
+		if err != nil {
+			log.Error().Err(err).Msg("failed to list users for trust recalculation")
+			return
+		}
+
+		for _, id := range ids {
+			if _, err := worker.engine.Recalculate(ctx, id); err != nil {
+				log.Error().Err(err).Str("user_id", id.String()).Msg("failed to recalculate trust")
+				continue
+			}
+			updated++
+		}
+
+		if len(ids) < trustBatchSize {
+			break
+		}
+	}
+
+	log.Info().Int("users", updated).Msg("Trust levels recalculated")
+}
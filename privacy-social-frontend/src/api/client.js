@@ -108,11 +108,13 @@ const apiService = {
 
     // Admin
     adminListUsers: (page = 1, pageSize = 20) => apiClient.get(`/admin/users?page=${page}&page_size=${pageSize}`),
-    adminBanUser: (userId, ban) => apiClient.post('/admin/users/ban', { user_id: userId, ban }),
-    adminDeleteUser: (id) => apiClient.delete(`/admin/users/${id}`),
+    // Every admin action needs a reason (3-500 chars) for the audit log
+    adminBanUser: (userId, ban, reason) => apiClient.post('/admin/users/ban', { user_id: userId, ban, reason }),
+    adminDeleteUser: (id, reason) => apiClient.delete(`/admin/users/${id}`, { params: { reason } }),
     adminGetStats: () => apiClient.get('/admin/stats'),
     adminListReports: (page = 1) => apiClient.get(`/admin/reports?page=${page}`),
-    adminResolveReport: (id) => apiClient.put(`/admin/reports/${id}/resolve`),
+    // status: 'actioned' | 'dismissed'; outcomes: 'story_removed' | 'user_warned' | 'user_banned'
+    adminResolveReport: (id, status, reason, outcomes = []) => apiClient.put(`/admin/reports/${id}/resolve`, { status, outcomes, reason }),
 
     // Expose the underlying axios instance if needed
     axiosInstance: apiClient,